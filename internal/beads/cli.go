@@ -0,0 +1,159 @@
+package beads
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CLI is a Client backed by the bd command, run in Dir.
+type CLI struct {
+	Dir string
+}
+
+// NewCLI returns a client that runs bd in dir.
+func NewCLI(dir string) *CLI {
+	return &CLI{Dir: dir}
+}
+
+// List reads every issue with `bd export`, which writes complete records
+// including dependencies to stdout, and filters them locally.
+func (c *CLI) List(ctx context.Context, opts ListOptions) ([]Issue, error) {
+	output, err := c.run(ctx, "export")
+	if err != nil {
+		return nil, err
+	}
+	issues, err := readIssues(bytes.NewReader(output))
+	if err != nil {
+		return nil, fmt.Errorf("parse bd export: %w", err)
+	}
+	return filterIssues(issues, opts), nil
+}
+
+func (c *CLI) Show(ctx context.Context, id string) (Issue, error) {
+	output, err := c.run(ctx, "show", id, "--json")
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return Issue{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+		}
+		return Issue{}, err
+	}
+	return parseShownIssue(output)
+}
+
+func (c *CLI) Create(ctx context.Context, input CreateInput) (string, error) {
+	output, err := c.run(ctx, append(input.Args(), "--json")...)
+	if err != nil {
+		return "", err
+	}
+	return parseCreatedID(output)
+}
+
+func (c *CLI) Update(ctx context.Context, id string, input UpdateInput) error {
+	_, err := c.run(ctx, input.Args(id)...)
+	return err
+}
+
+func (c *CLI) Close(ctx context.Context, id string, reason string) error {
+	args := []string{"close", id}
+	if reason != "" {
+		args = append(args, "--reason="+reason)
+	}
+	_, err := c.run(ctx, args...)
+	return err
+}
+
+func (c *CLI) AddDep(ctx context.Context, issueID string, dependsOnID string, depType string) error {
+	args := []string{"dep", "add", issueID, dependsOnID}
+	if depType != "" {
+		args = append(args, "--type="+depType)
+	}
+	_, err := c.run(ctx, args...)
+	return err
+}
+
+func (c *CLI) Status(ctx context.Context) (Status, error) {
+	output, err := c.run(ctx, "status", "--json")
+	if err != nil {
+		return Status{}, err
+	}
+	var status Status
+	if err := json.Unmarshal(output, &status); err != nil {
+		return Status{}, fmt.Errorf("parse bd status: %w", err)
+	}
+	return status, nil
+}
+
+func (c *CLI) run(ctx context.Context, args ...string) ([]byte, error) {
+	command := exec.CommandContext(ctx, "bd", args...)
+	command.Dir = c.Dir
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	output, err := command.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = strings.TrimSpace(string(output))
+		}
+		if message != "" {
+			message = "\n" + message
+		}
+		return nil, fmt.Errorf("bd %s failed: %w%s", strings.Join(args, " "), err, message)
+	}
+	return output, nil
+}
+
+// shownIssue is the `bd show --json` shape, where dependencies are the
+// depended-on issues annotated with the dependency type.
+type shownIssue struct {
+	Issue
+	Dependencies []struct {
+		ID             string `json:"id"`
+		DependsOnID    string `json:"depends_on_id"`
+		Type           string `json:"type"`
+		DependencyType string `json:"dependency_type"`
+	} `json:"dependencies,omitempty"`
+}
+
+func parseShownIssue(output []byte) (Issue, error) {
+	var shown shownIssue
+	if err := json.Unmarshal(output, &shown); err != nil {
+		var list []shownIssue
+		if listErr := json.Unmarshal(output, &list); listErr != nil || len(list) == 0 {
+			return Issue{}, fmt.Errorf("parse bd show: %w", err)
+		}
+		shown = list[0]
+	}
+
+	issue := shown.Issue
+	issue.Dependencies = nil
+	for _, dep := range shown.Dependencies {
+		dependsOn, depType := dep.DependsOnID, dep.Type
+		if dep.DependencyType != "" {
+			dependsOn, depType = dep.ID, dep.DependencyType
+		}
+		issue.Dependencies = append(issue.Dependencies, Dependency{IssueID: issue.ID, DependsOnID: dependsOn, Type: depType})
+	}
+	return issue, nil
+}
+
+func parseCreatedID(output []byte) (string, error) {
+	var created struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(output, &created); err == nil && created.ID != "" {
+		return created.ID, nil
+	}
+
+	var list []struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(output, &list); err == nil && len(list) > 0 && list[0].ID != "" {
+		return list[0].ID, nil
+	}
+
+	return "", fmt.Errorf("parse bd create output: no issue id in %q", strings.TrimSpace(string(output)))
+}
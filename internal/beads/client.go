@@ -0,0 +1,244 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned when an issue ID does not exist.
+	ErrNotFound = errors.New("issue not found")
+	// ErrReadOnly is returned by clients that cannot modify beads.
+	ErrReadOnly = errors.New("beads client is read-only")
+)
+
+// Client reads and modifies beads. CLI runs bd, JSONL reads the exported
+// issues file and Fake keeps issues in memory for tests.
+type Client interface {
+	List(ctx context.Context, opts ListOptions) ([]Issue, error)
+	Show(ctx context.Context, id string) (Issue, error)
+	Create(ctx context.Context, input CreateInput) (string, error)
+	Update(ctx context.Context, id string, input UpdateInput) error
+	Close(ctx context.Context, id string, reason string) error
+	AddDep(ctx context.Context, issueID string, dependsOnID string, depType string) error
+	Status(ctx context.Context) (Status, error)
+}
+
+// ListOptions filters List results. Empty filters match everything except
+// tombstones.
+type ListOptions struct {
+	Statuses          []string
+	Types             []string
+	IncludeTombstones bool
+	Limit             int
+}
+
+// Match reports whether issue passes the filters, ignoring Limit.
+func (opts ListOptions) Match(issue Issue) bool {
+	if issue.IsTombstone() && !opts.IncludeTombstones && !contains(opts.Statuses, StatusTombstone) {
+		return false
+	}
+	if len(opts.Statuses) > 0 && !contains(opts.Statuses, issue.Status) {
+		return false
+	}
+	if len(opts.Types) > 0 && !contains(opts.Types, issue.IssueType) {
+		return false
+	}
+	return true
+}
+
+// filterIssues applies opts to issues, keeping their order.
+func filterIssues(issues []Issue, opts ListOptions) []Issue {
+	filtered := make([]Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !opts.Match(issue) {
+			continue
+		}
+		filtered = append(filtered, issue)
+		if opts.Limit > 0 && len(filtered) == opts.Limit {
+			break
+		}
+	}
+	return filtered
+}
+
+// CreateInput describes an issue to create.
+type CreateInput struct {
+	Title       string
+	Type        string
+	Priority    int
+	Description string
+	Parent      string
+	Labels      []string
+}
+
+// Args returns the `bd create` arguments for the input.
+func (input CreateInput) Args() []string {
+	args := []string{
+		"create",
+		"--title=" + input.Title,
+		"--type=" + input.Type,
+		"--priority=" + strconv.Itoa(input.Priority),
+	}
+	if input.Description != "" {
+		args = append(args, "--description="+input.Description)
+	}
+	if input.Parent != "" {
+		args = append(args, "--parent", input.Parent)
+	}
+	if len(input.Labels) > 0 {
+		args = append(args, "--labels="+strings.Join(input.Labels, ","))
+	}
+	return args
+}
+
+// UpdateInput holds the fields to change on an issue; nil fields are left
+// as they are.
+type UpdateInput struct {
+	Title       *string
+	Description *string
+	Status      *string
+	Priority    *int
+	Assignee    *string
+}
+
+// Args returns the `bd update` arguments for the input.
+func (input UpdateInput) Args(id string) []string {
+	args := []string{"update", id}
+	if input.Title != nil {
+		args = append(args, "--title="+*input.Title)
+	}
+	if input.Description != nil {
+		args = append(args, "--description="+*input.Description)
+	}
+	if input.Status != nil {
+		args = append(args, "--status="+*input.Status)
+	}
+	if input.Priority != nil {
+		args = append(args, "--priority="+strconv.Itoa(*input.Priority))
+	}
+	if input.Assignee != nil {
+		args = append(args, "--assignee="+*input.Assignee)
+	}
+	return args
+}
+
+// StatusSummary counts issues the way `bd status` reports them.
+type StatusSummary struct {
+	TotalIssues             int     `json:"total_issues"`
+	OpenIssues              int     `json:"open_issues"`
+	InProgressIssues        int     `json:"in_progress_issues"`
+	ClosedIssues            int     `json:"closed_issues"`
+	BlockedIssues           int     `json:"blocked_issues"`
+	DeferredIssues          int     `json:"deferred_issues"`
+	ReadyIssues             int     `json:"ready_issues"`
+	TombstoneIssues         int     `json:"tombstone_issues"`
+	PinnedIssues            int     `json:"pinned_issues"`
+	EpicsEligibleForClosure int     `json:"epics_eligible_for_closure"`
+	AverageLeadTimeHours    float64 `json:"average_lead_time_hours"`
+}
+
+type RecentActivity struct {
+	HoursTracked   int `json:"hours_tracked"`
+	CommitCount    int `json:"commit_count"`
+	IssuesCreated  int `json:"issues_created"`
+	IssuesClosed   int `json:"issues_closed"`
+	IssuesUpdated  int `json:"issues_updated"`
+	IssuesReopened int `json:"issues_reopened"`
+	TotalChanges   int `json:"total_changes"`
+}
+
+type Status struct {
+	Summary        StatusSummary  `json:"summary"`
+	RecentActivity RecentActivity `json:"recent_activity"`
+}
+
+// Summarize computes the status summary of issues. Readiness follows bd: an
+// open issue is ready when none of its blockers are still open, and blocked
+// otherwise. Recent activity needs git history and is left empty.
+func Summarize(issues []Issue) Status {
+	graph := NewGraph(issues)
+	var summary StatusSummary
+	var leadHours float64
+	var leadCount int
+
+	for _, issue := range issues {
+		if issue.IsTombstone() {
+			summary.TombstoneIssues++
+			continue
+		}
+		summary.TotalIssues++
+		switch issue.Status {
+		case StatusOpen:
+			summary.OpenIssues++
+		case StatusInProgress:
+			summary.InProgressIssues++
+		case StatusClosed:
+			summary.ClosedIssues++
+			if issue.ClosedAt != nil && !issue.CreatedAt.IsZero() {
+				leadHours += issue.ClosedAt.Sub(issue.CreatedAt).Hours()
+				leadCount++
+			}
+		case "deferred":
+			summary.DeferredIssues++
+		case "pinned":
+			summary.PinnedIssues++
+		}
+
+		if issue.IsClosed() {
+			continue
+		}
+		switch {
+		case graph.IsBlocked(issue):
+			summary.BlockedIssues++
+		case issue.Status == StatusOpen:
+			summary.ReadyIssues++
+		}
+
+		if issue.IssueType == "epic" && eligibleForClosure(graph, issue.ID) {
+			summary.EpicsEligibleForClosure++
+		}
+	}
+
+	if leadCount > 0 {
+		summary.AverageLeadTimeHours = leadHours / float64(leadCount)
+	}
+	return Status{Summary: summary}
+}
+
+// Ready returns the open issues that nothing open blocks: what bd ready
+// lists and Summarize counts as ready.
+func Ready(issues []Issue) []Issue {
+	graph := NewGraph(issues)
+	var ready []Issue
+	for _, issue := range graph.Issues {
+		if issue.Status == StatusOpen && !graph.IsBlocked(issue) {
+			ready = append(ready, issue)
+		}
+	}
+	return ready
+}
+
+func eligibleForClosure(graph *Graph, id string) bool {
+	children := graph.Children[id]
+	if len(children) == 0 {
+		return false
+	}
+	for _, childID := range children {
+		if !graph.ByID[childID].IsClosed() {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFakeClient(t *testing.T) {
+	ctx := context.Background()
+	client := NewFake(Issue{ID: "e1", Title: "Epic", Status: StatusOpen, IssueType: "epic"})
+
+	id, err := client.Create(ctx, CreateInput{Title: "Task", Type: "task", Priority: 1, Parent: "e1", Labels: []string{"api"}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	blocker, err := client.Create(ctx, CreateInput{Title: "Blocker", Type: "task", Parent: "e1"})
+	if err != nil {
+		t.Fatalf("create blocker: %v", err)
+	}
+	if err := client.AddDep(ctx, id, blocker, DepBlocks); err != nil {
+		t.Fatalf("add dep: %v", err)
+	}
+
+	status, err := client.Status(ctx)
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if status.Summary.ReadyIssues != 2 || status.Summary.BlockedIssues != 1 {
+		t.Fatalf("expected epic and blocker ready and task blocked, got %+v", status.Summary)
+	}
+
+	inProgress := StatusInProgress
+	if err := client.Update(ctx, blocker, UpdateInput{Status: &inProgress}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := client.Close(ctx, blocker, "done"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	closed, err := client.Show(ctx, blocker)
+	if err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	if closed.Status != StatusClosed || closed.CloseReason != "done" || closed.ClosedAt == nil {
+		t.Fatalf("expected closed issue with reason, got %+v", closed)
+	}
+
+	task, _ := client.Show(ctx, id)
+	if task.ParentID() != "e1" || !task.HasLabel("api") {
+		t.Fatalf("expected parent and label on %+v", task)
+	}
+
+	open, err := client.List(ctx, ListOptions{Statuses: []string{StatusOpen}, Types: []string{"task"}})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(open) != 1 || open[0].ID != id {
+		t.Fatalf("expected only the open task, got %+v", open)
+	}
+
+	if _, err := client.Show(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestJSONLClient(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, Dir), 0755); err != nil {
+		t.Fatalf("create beads dir: %v", err)
+	}
+	data := `{"id":"cn-1","title":"Live","status":"open","issue_type":"task","labels":["ui"]}
+{"id":"cn-2","title":"Done","status":"closed","issue_type":"task","close_reason":"shipped","closed_at":"2026-02-02T10:00:00Z","created_at":"2026-02-01T10:00:00Z"}
+{"id":"cn-3","title":"Gone","status":"tombstone","issue_type":"task","delete_reason":"duplicate"}
+`
+	if err := os.WriteFile(filepath.Join(root, Dir, IssuesFile), []byte(data), 0644); err != nil {
+		t.Fatalf("write issues: %v", err)
+	}
+
+	ctx := context.Background()
+	client := NewJSONL(root)
+
+	live, err := client.List(ctx, ListOptions{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(live) != 2 {
+		t.Fatalf("expected tombstones to be skipped, got %d issues", len(live))
+	}
+	all, _ := client.List(ctx, ListOptions{IncludeTombstones: true})
+	if len(all) != 3 || all[2].DeleteReason != "duplicate" {
+		t.Fatalf("expected tombstone with delete reason, got %+v", all)
+	}
+
+	done, err := client.Show(ctx, "cn-2")
+	if err != nil || done.CloseReason != "shipped" {
+		t.Fatalf("expected close reason, got %+v (%v)", done, err)
+	}
+
+	status, _ := client.Status(ctx)
+	if status.Summary.TotalIssues != 2 || status.Summary.AverageLeadTimeHours != 24 {
+		t.Fatalf("unexpected summary %+v", status.Summary)
+	}
+
+	if err := client.Close(ctx, "cn-1", "nope"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+}
+
+func TestParseShownIssue(t *testing.T) {
+	output := []byte(`[{"id":"cn-2","title":"Child","status":"open","labels":["api"],
+		"dependencies":[{"id":"cn-1","title":"Parent","dependency_type":"parent-child"},{"id":"cn-0","dependency_type":"blocks"}]}]`)
+
+	issue, err := parseShownIssue(output)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if issue.ParentID() != "cn-1" || len(issue.Dependencies) != 2 || issue.Dependencies[1].Type != DepBlocks {
+		t.Fatalf("unexpected dependencies %+v", issue.Dependencies)
+	}
+}
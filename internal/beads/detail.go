@@ -0,0 +1,49 @@
+package beads
+
+import (
+	"context"
+	"fmt"
+)
+
+// Detail is an issue with its parent, children and blocks links resolved.
+type Detail struct {
+	Issue
+	Parent    *Issue  `json:"parent,omitempty"`
+	Children  []Issue `json:"children"`
+	BlockedBy []Issue `json:"blocked_by"`
+	Blocks    []Issue `json:"blocks"`
+}
+
+// LoadDetail returns the issue called id with its relations, looked up in
+// everything client lists.
+func LoadDetail(ctx context.Context, client Client, id string) (Detail, error) {
+	issues, err := client.List(ctx, ListOptions{})
+	if err != nil {
+		return Detail{}, err
+	}
+	graph := NewGraph(issues)
+	issue, ok := graph.ByID[id]
+	if !ok {
+		return Detail{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	detail := Detail{Issue: issue, Children: []Issue{}, BlockedBy: []Issue{}, Blocks: []Issue{}}
+	if parentID, ok := graph.Parent[id]; ok {
+		parent := graph.ByID[parentID]
+		detail.Parent = &parent
+	}
+	for _, childID := range graph.Children[id] {
+		detail.Children = append(detail.Children, graph.ByID[childID])
+	}
+	for _, blockerID := range graph.Blocks[id] {
+		detail.BlockedBy = append(detail.BlockedBy, graph.ByID[blockerID])
+	}
+	for _, other := range graph.Issues {
+		for _, blockerID := range graph.Blocks[other.ID] {
+			if blockerID == id {
+				detail.Blocks = append(detail.Blocks, other)
+			}
+		}
+	}
+	return detail, nil
+}
@@ -0,0 +1,37 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestLoadDetail(t *testing.T) {
+	client := NewFake(
+		Issue{ID: "cn-1", Title: "Auth", Status: StatusOpen, IssueType: "epic"},
+		Issue{ID: "cn-2", Title: "Login", Status: StatusOpen, Dependencies: []Dependency{
+			{IssueID: "cn-2", DependsOnID: "cn-1", Type: DepParentChild},
+			{IssueID: "cn-2", DependsOnID: "cn-3", Type: DepBlocks},
+		}},
+		Issue{ID: "cn-3", Title: "Schema", Status: StatusOpen},
+	)
+
+	detail, err := LoadDetail(context.Background(), client, "cn-2")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if detail.Parent == nil || detail.Parent.ID != "cn-1" {
+		t.Fatalf("expected parent cn-1, got %+v", detail.Parent)
+	}
+	if len(detail.BlockedBy) != 1 || detail.BlockedBy[0].ID != "cn-3" || len(detail.Blocks) != 0 || len(detail.Children) != 0 {
+		t.Fatalf("unexpected relations %+v", detail)
+	}
+
+	schema, err := LoadDetail(context.Background(), client, "cn-3")
+	if err != nil || len(schema.Blocks) != 1 || schema.Blocks[0].ID != "cn-2" {
+		t.Fatalf("expected cn-3 to block cn-2, got %+v (%v)", schema.Blocks, err)
+	}
+	if _, err := LoadDetail(context.Background(), client, "cn-9"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,163 @@
+package beads
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Fake is an in-memory Client for tests. Issues keep their insertion order;
+// created issues get IDs of the form "<Prefix>-<n>".
+type Fake struct {
+	Prefix string
+
+	mu     sync.Mutex
+	issues []Issue
+	next   int
+}
+
+// NewFake returns a fake client holding issues.
+func NewFake(issues ...Issue) *Fake {
+	return &Fake{
+		Prefix: "fake",
+		issues: append([]Issue(nil), issues...),
+	}
+}
+
+// Issues returns a copy of every issue, including tombstones.
+func (f *Fake) Issues() []Issue {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Issue(nil), f.issues...)
+}
+
+func (f *Fake) List(ctx context.Context, opts ListOptions) ([]Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return filterIssues(f.issues, opts), nil
+}
+
+func (f *Fake) Show(ctx context.Context, id string) (Issue, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, err := f.find(id)
+	if err != nil {
+		return Issue{}, err
+	}
+	return f.issues[index], nil
+}
+
+func (f *Fake) Create(ctx context.Context, input CreateInput) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if input.Title == "" {
+		return "", fmt.Errorf("title is required")
+	}
+
+	f.next++
+	now := time.Now()
+	issue := Issue{
+		ID:          fmt.Sprintf("%s-%d", f.Prefix, f.next),
+		Title:       input.Title,
+		Description: input.Description,
+		Status:      StatusOpen,
+		Priority:    input.Priority,
+		IssueType:   input.Type,
+		Labels:      append([]string(nil), input.Labels...),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if input.Parent != "" {
+		if _, err := f.find(input.Parent); err != nil {
+			return "", err
+		}
+		issue.Dependencies = []Dependency{{IssueID: issue.ID, DependsOnID: input.Parent, Type: DepParentChild, CreatedAt: now}}
+	}
+	f.issues = append(f.issues, issue)
+	return issue.ID, nil
+}
+
+func (f *Fake) Update(ctx context.Context, id string, input UpdateInput) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, err := f.find(id)
+	if err != nil {
+		return err
+	}
+
+	issue := &f.issues[index]
+	if input.Title != nil {
+		issue.Title = *input.Title
+	}
+	if input.Description != nil {
+		issue.Description = *input.Description
+	}
+	if input.Status != nil {
+		issue.Status = *input.Status
+	}
+	if input.Priority != nil {
+		issue.Priority = *input.Priority
+	}
+	if input.Assignee != nil {
+		issue.Assignee = *input.Assignee
+	}
+	issue.UpdatedAt = time.Now()
+	return nil
+}
+
+func (f *Fake) Close(ctx context.Context, id string, reason string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, err := f.find(id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	issue := &f.issues[index]
+	issue.Status = StatusClosed
+	issue.CloseReason = reason
+	issue.ClosedAt = &now
+	issue.UpdatedAt = now
+	return nil
+}
+
+func (f *Fake) AddDep(ctx context.Context, issueID string, dependsOnID string, depType string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	index, err := f.find(issueID)
+	if err != nil {
+		return err
+	}
+	if _, err := f.find(dependsOnID); err != nil {
+		return err
+	}
+	if depType == "" {
+		depType = DepBlocks
+	}
+
+	issue := &f.issues[index]
+	for _, dep := range issue.Dependencies {
+		if dep.DependsOnID == dependsOnID && dep.Type == depType {
+			return nil
+		}
+	}
+	issue.Dependencies = append(issue.Dependencies, Dependency{IssueID: issueID, DependsOnID: dependsOnID, Type: depType, CreatedAt: time.Now()})
+	return nil
+}
+
+func (f *Fake) Status(ctx context.Context) (Status, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return Summarize(f.issues), nil
+}
+
+func (f *Fake) find(id string) (int, error) {
+	for index, issue := range f.issues {
+		if issue.ID == id && !issue.IsTombstone() {
+			return index, nil
+		}
+	}
+	return -1, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
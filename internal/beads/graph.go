@@ -0,0 +1,153 @@
+package beads
+
+import "sort"
+
+// Graph indexes live (non-tombstone) issues by ID with their parent/child and
+// blocking relations.
+type Graph struct {
+	Issues   []Issue
+	ByID     map[string]Issue
+	Parent   map[string]string   // child ID -> parent ID
+	Children map[string][]string // parent ID -> child IDs, in issue order
+	Blocks   map[string][]string // issue ID -> IDs it depends on (blocks links)
+}
+
+// NewGraph builds a graph from issues, skipping tombstones and links to
+// issues that are not present.
+func NewGraph(issues []Issue) *Graph {
+	graph := &Graph{
+		ByID:     make(map[string]Issue, len(issues)),
+		Parent:   make(map[string]string),
+		Children: make(map[string][]string),
+		Blocks:   make(map[string][]string),
+	}
+	for _, issue := range issues {
+		if issue.Status == StatusTombstone {
+			continue
+		}
+		graph.Issues = append(graph.Issues, issue)
+		graph.ByID[issue.ID] = issue
+	}
+
+	for _, issue := range graph.Issues {
+		for _, dep := range issue.Dependencies {
+			if _, ok := graph.ByID[dep.DependsOnID]; !ok {
+				continue
+			}
+			switch dep.Type {
+			case DepParentChild:
+				graph.Parent[issue.ID] = dep.DependsOnID
+				graph.Children[dep.DependsOnID] = append(graph.Children[dep.DependsOnID], issue.ID)
+			case DepBlocks:
+				graph.Blocks[issue.ID] = append(graph.Blocks[issue.ID], dep.DependsOnID)
+			}
+		}
+	}
+	return graph
+}
+
+// IsBlocked reports whether issue is marked blocked or depends on an issue
+// that is not closed yet.
+func (g *Graph) IsBlocked(issue Issue) bool {
+	if issue.Status == StatusBlocked {
+		return true
+	}
+	for _, depID := range g.Blocks[issue.ID] {
+		if !g.ByID[depID].IsClosed() {
+			return true
+		}
+	}
+	return false
+}
+
+// Descendants returns every issue below id in the parent/child tree.
+func (g *Graph) Descendants(id string) []string {
+	var result []string
+	seen := map[string]bool{id: true}
+	queue := append([]string(nil), g.Children[id]...)
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		result = append(result, next)
+		queue = append(queue, g.Children[next]...)
+	}
+	return result
+}
+
+// BlockingCycles returns each cycle in the blocks relation once, as the list
+// of issue IDs on the cycle starting from its smallest ID.
+func (g *Graph) BlockingCycles() [][]string {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(g.Issues))
+	var stack []string
+	seen := make(map[string]bool)
+	var cycles [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = visiting
+		stack = append(stack, id)
+		for _, dep := range g.Blocks[id] {
+			switch state[dep] {
+			case visiting:
+				for i, item := range stack {
+					if item != dep {
+						continue
+					}
+					cycle := normalizeCycle(stack[i:])
+					key := joinIDs(cycle)
+					if !seen[key] {
+						seen[key] = true
+						cycles = append(cycles, cycle)
+					}
+					break
+				}
+			case unvisited:
+				visit(dep)
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[id] = done
+	}
+
+	ids := make([]string, 0, len(g.Issues))
+	for _, issue := range g.Issues {
+		ids = append(ids, issue.ID)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if state[id] == unvisited {
+			visit(id)
+		}
+	}
+	return cycles
+}
+
+func normalizeCycle(cycle []string) []string {
+	start := 0
+	for i, id := range cycle {
+		if id < cycle[start] {
+			start = i
+		}
+	}
+	result := make([]string, 0, len(cycle))
+	result = append(result, cycle[start:]...)
+	result = append(result, cycle[:start]...)
+	return result
+}
+
+func joinIDs(ids []string) string {
+	key := ""
+	for _, id := range ids {
+		key += id + ","
+	}
+	return key
+}
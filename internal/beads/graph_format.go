@@ -0,0 +1,297 @@
+package beads
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// GraphFormats are the output formats FormatNeighborhood supports.
+var GraphFormats = []string{"ascii", "dot", "mermaid"}
+
+// statusFill is the node fill used by the dot and mermaid formats.
+var statusFill = map[string]string{
+	StatusOpen:       "#e6f4ea",
+	StatusInProgress: "#dbe9ff",
+	StatusBlocked:    "#fde2e1",
+	StatusClosed:     "#e8e8e8",
+}
+
+// FormatNeighborhood renders hood as Graphviz dot, a Mermaid flowchart or an
+// ASCII layered graph no wider than width (0 for unlimited).
+func FormatNeighborhood(hood Neighborhood, format string, width int) (string, error) {
+	switch format {
+	case "dot":
+		return FormatDOT(hood), nil
+	case "mermaid":
+		return FormatMermaid(hood), nil
+	case "ascii":
+		return FormatASCII(hood, width, nil), nil
+	default:
+		return "", fmt.Errorf("unknown graph format %q (use %s)", format, strings.Join(GraphFormats, ", "))
+	}
+}
+
+// FormatDOT renders hood as a left-to-right Graphviz digraph, nodes filled by
+// status and the root drawn bold.
+func FormatDOT(hood Neighborhood) string {
+	var b strings.Builder
+	b.WriteString("digraph beads {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+	for _, node := range hood.Nodes {
+		attrs := fmt.Sprintf("label=%q, fillcolor=%q", node.ID+"\n"+node.Title+"\n["+node.Status+"]", fillFor(node.Status))
+		if node.ID == hood.Root {
+			attrs += ", penwidth=2"
+		}
+		fmt.Fprintf(&b, "  %q [%s];\n", node.ID, attrs)
+	}
+	for _, edge := range hood.Edges {
+		fmt.Fprintf(&b, "  %q -> %q;\n", edge.From, edge.To)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// FormatMermaid renders hood as a left-to-right Mermaid flowchart with one
+// class per status.
+func FormatMermaid(hood Neighborhood) string {
+	var b strings.Builder
+	b.WriteString("graph LR\n")
+	for _, node := range hood.Nodes {
+		label := strings.ReplaceAll(node.ID+": "+node.Title, `"`, "#quot;")
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", mermaidID(node.ID), label)
+	}
+	for _, edge := range hood.Edges {
+		fmt.Fprintf(&b, "  %s --> %s\n", mermaidID(edge.From), mermaidID(edge.To))
+	}
+
+	members := make(map[string][]string)
+	var statuses []string
+	for _, node := range hood.Nodes {
+		if _, ok := members[node.Status]; !ok {
+			statuses = append(statuses, node.Status)
+		}
+		members[node.Status] = append(members[node.Status], mermaidID(node.ID))
+	}
+	for _, status := range statuses {
+		class := mermaidID(status)
+		fmt.Fprintf(&b, "  classDef %s fill:%s\n", class, fillFor(status))
+		fmt.Fprintf(&b, "  class %s %s\n", strings.Join(members[status], ","), class)
+	}
+	fmt.Fprintf(&b, "  style %s stroke-width:3px\n", mermaidID(hood.Root))
+	return b.String()
+}
+
+func fillFor(status string) string {
+	if fill, ok := statusFill[status]; ok {
+		return fill
+	}
+	return "#ffffff"
+}
+
+// mermaidID turns an issue ID into a Mermaid node ID, which may not contain
+// dashes or dots.
+func mermaidID(id string) string {
+	var b strings.Builder
+	for _, r := range id {
+		if r == '_' || ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+const (
+	asciiMaxBox = 28
+	asciiMinBox = 16
+	asciiGap    = 5
+	asciiArrow  = 2 // the box line that carries the outgoing arrow
+)
+
+// FormatASCII draws hood as boxes, one column per layer from blockers to
+// dependents. Each box lists the issues it blocks. When the columns do not
+// fit in width the layers are stacked top to bottom instead. paint, when
+// set, styles each rendered box line, e.g. to color nodes by status.
+func FormatASCII(hood Neighborhood, width int, paint func(node GraphNode, line string) string) string {
+	if paint == nil {
+		paint = func(_ GraphNode, line string) string { return line }
+	}
+	layers := len(hood.Layers)
+	if layers == 0 {
+		return ""
+	}
+
+	boxWidth := asciiMaxBox
+	if width > 0 {
+		boxWidth = minInt((width-asciiGap*(layers-1))/layers, asciiMaxBox)
+	}
+	if boxWidth >= asciiMinBox {
+		return asciiColumns(hood, boxWidth, paint)
+	}
+	if width > 0 {
+		boxWidth = maxInt(minInt(width, asciiMaxBox), 8)
+	}
+	return asciiRows(hood, boxWidth, width, paint)
+}
+
+func asciiColumns(hood Neighborhood, boxWidth int, paint func(GraphNode, string) string) string {
+	var columns [][]string
+	height := 0
+	for layer, ids := range hood.Layers {
+		var lines []string
+		for i, id := range ids {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			box := asciiBox(hood, id, boxWidth, paint)
+			if layer < len(hood.Layers)-1 {
+				arrow := strings.Repeat(" ", asciiGap)
+				if len(hood.Outgoing(id)) > 0 {
+					arrow = " " + strings.Repeat("─", asciiGap-2) + "▶"
+				}
+				for j := range box {
+					if j == asciiArrow {
+						box[j] += arrow
+					} else {
+						box[j] += strings.Repeat(" ", asciiGap)
+					}
+				}
+			}
+			lines = append(lines, box...)
+		}
+		height = maxInt(height, len(lines))
+		columns = append(columns, lines)
+	}
+
+	var b strings.Builder
+	for row := 0; row < height; row++ {
+		var line strings.Builder
+		for layer, column := range columns {
+			cell := ""
+			if row < len(column) {
+				cell = column[row]
+			}
+			cellWidth := boxWidth
+			if layer < len(columns)-1 {
+				cellWidth += asciiGap
+			}
+			if pad := cellWidth - visibleWidth(cell); pad > 0 && layer < len(columns)-1 {
+				cell += strings.Repeat(" ", pad)
+			}
+			line.WriteString(cell)
+		}
+		b.WriteString(strings.TrimRight(line.String(), " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+func asciiRows(hood Neighborhood, boxWidth int, width int, paint func(GraphNode, string) string) string {
+	perRow := 1
+	if width > 0 {
+		perRow = maxInt(1, (width+1)/(boxWidth+1))
+	}
+	var b strings.Builder
+	for layer, ids := range hood.Layers {
+		if layer > 0 {
+			fmt.Fprintf(&b, "%s▼\n", strings.Repeat(" ", boxWidth/2))
+		}
+		for start := 0; start < len(ids); start += perRow {
+			end := minInt(start+perRow, len(ids))
+			var boxes [][]string
+			for _, id := range ids[start:end] {
+				boxes = append(boxes, asciiBox(hood, id, boxWidth, paint))
+			}
+			for row := range boxes[0] {
+				var parts []string
+				for _, box := range boxes {
+					parts = append(parts, box[row])
+				}
+				b.WriteString(strings.Join(parts, " "))
+				b.WriteByte('\n')
+			}
+		}
+	}
+	return b.String()
+}
+
+// asciiBox draws one node: ID and status, title, and the issues it blocks.
+// The root issue gets a double border.
+func asciiBox(hood Neighborhood, id string, width int, paint func(GraphNode, string) string) []string {
+	node, _ := hood.Node(id)
+	inner := width - 2
+	h, v, corners := "─", "│", [4]string{"┌", "┐", "└", "┘"}
+	if id == hood.Root {
+		h, v, corners = "═", "║", [4]string{"╔", "╗", "╚", "╝"}
+	}
+
+	blocks := ""
+	if targets := hood.Outgoing(id); len(targets) > 0 {
+		blocks = "-> " + strings.Join(targets, " ")
+	}
+	content := []string{
+		node.ID + " [" + node.Status + "]",
+		node.Title,
+		blocks,
+	}
+	lines := []string{corners[0] + strings.Repeat(h, inner) + corners[1]}
+	for _, text := range content {
+		text = truncateRunes(text, inner)
+		lines = append(lines, v+text+strings.Repeat(" ", inner-utf8.RuneCountInString(text))+v)
+	}
+	lines = append(lines, corners[2]+strings.Repeat(h, inner)+corners[3])
+	for i := range lines {
+		lines[i] = paint(node, lines[i])
+	}
+	return lines
+}
+
+func truncateRunes(value string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(value) <= width {
+		return value
+	}
+	runes := []rune(value)
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// visibleWidth counts runes outside ANSI escape sequences.
+func visibleWidth(value string) int {
+	width := 0
+	escape := false
+	for _, r := range value {
+		switch {
+		case escape:
+			if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+				escape = false
+			}
+		case r == '\x1b':
+			escape = true
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,152 @@
+package beads
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	Dir        = ".beads"
+	IssuesFile = "issues.jsonl"
+)
+
+// Dependency types used by bd.
+const (
+	DepBlocks         = "blocks"
+	DepParentChild    = "parent-child"
+	DepDiscoveredFrom = "discovered-from"
+)
+
+// Issue statuses used by bd.
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusBlocked    = "blocked"
+	StatusClosed     = "closed"
+	StatusTombstone  = "tombstone"
+)
+
+// Issue is a bead as bd exports it. Tombstones are deleted issues kept so
+// the deletion syncs; their Deleted* fields record who removed them and why.
+type Issue struct {
+	ID                 string       `json:"id"`
+	Title              string       `json:"title"`
+	Description        string       `json:"description,omitempty"`
+	AcceptanceCriteria string       `json:"acceptance_criteria,omitempty"`
+	Status             string       `json:"status"`
+	Priority           int          `json:"priority"`
+	IssueType          string       `json:"issue_type"`
+	Owner              string       `json:"owner,omitempty"`
+	Assignee           string       `json:"assignee,omitempty"`
+	Labels             []string     `json:"labels,omitempty"`
+	Estimate           *int         `json:"estimated_minutes,omitempty"`
+	CreatedAt          time.Time    `json:"created_at"`
+	CreatedBy          string       `json:"created_by,omitempty"`
+	UpdatedAt          time.Time    `json:"updated_at"`
+	ClosedAt           *time.Time   `json:"closed_at,omitempty"`
+	CloseReason        string       `json:"close_reason,omitempty"`
+	DeletedAt          *time.Time   `json:"deleted_at,omitempty"`
+	DeletedBy          string       `json:"deleted_by,omitempty"`
+	DeleteReason       string       `json:"delete_reason,omitempty"`
+	OriginalType       string       `json:"original_type,omitempty"`
+	Dependencies       []Dependency `json:"dependencies,omitempty"`
+}
+
+// Dependency links IssueID to DependsOnID. For parent-child links the
+// depends-on side is the parent.
+type Dependency struct {
+	IssueID     string    `json:"issue_id"`
+	DependsOnID string    `json:"depends_on_id"`
+	Type        string    `json:"type"`
+	CreatedAt   time.Time `json:"created_at"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+}
+
+// IsClosed reports whether the issue no longer needs work.
+func (i Issue) IsClosed() bool {
+	return i.Status == StatusClosed || i.Status == StatusTombstone
+}
+
+// IsTombstone reports whether the issue has been deleted.
+func (i Issue) IsTombstone() bool {
+	return i.Status == StatusTombstone
+}
+
+// ParentID returns the issue's parent from its parent-child dependency.
+func (i Issue) ParentID() string {
+	for _, dep := range i.Dependencies {
+		if dep.Type == DepParentChild {
+			return dep.DependsOnID
+		}
+	}
+	return ""
+}
+
+// HasLabel reports whether the issue carries label.
+func (i Issue) HasLabel(label string) bool {
+	for _, value := range i.Labels {
+		if value == label {
+			return true
+		}
+	}
+	return false
+}
+
+// FindRoot walks up from startDir to the directory containing .beads.
+func FindRoot(startDir string) (string, error) {
+	dir := startDir
+	for {
+		if info, err := os.Stat(filepath.Join(dir, Dir)); err == nil && info.IsDir() {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no %s directory found", Dir)
+		}
+		dir = parent
+	}
+}
+
+// LoadIssues reads the issues exported to .beads/issues.jsonl under root.
+func LoadIssues(root string) ([]Issue, error) {
+	return LoadIssuesFile(filepath.Join(root, Dir, IssuesFile))
+}
+
+// LoadIssuesFile reads issues from a JSONL file, one issue per line.
+func LoadIssuesFile(path string) ([]Issue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open issues file: %w", err)
+	}
+	defer file.Close()
+
+	return readIssues(file)
+}
+
+func readIssues(reader io.Reader) ([]Issue, error) {
+	var issues []Issue
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var issue Issue
+		if err := json.Unmarshal(line, &issue); err != nil {
+			return nil, fmt.Errorf("parse issue at line %d: %w", lineNum, err)
+		}
+		issues = append(issues, issue)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read issues: %w", err)
+	}
+	return issues, nil
+}
@@ -0,0 +1,63 @@
+package beads
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+)
+
+// JSONL is a read-only Client over an exported issues file. It needs no bd
+// binary, so it suits lookups that only need titles and structure.
+type JSONL struct {
+	Path string
+}
+
+// NewJSONL returns a reader for .beads/issues.jsonl under root.
+func NewJSONL(root string) *JSONL {
+	return &JSONL{Path: filepath.Join(root, Dir, IssuesFile)}
+}
+
+func (r *JSONL) List(ctx context.Context, opts ListOptions) ([]Issue, error) {
+	issues, err := LoadIssuesFile(r.Path)
+	if err != nil {
+		return nil, err
+	}
+	return filterIssues(issues, opts), nil
+}
+
+func (r *JSONL) Show(ctx context.Context, id string) (Issue, error) {
+	issues, err := LoadIssuesFile(r.Path)
+	if err != nil {
+		return Issue{}, err
+	}
+	for _, issue := range issues {
+		if issue.ID == id {
+			return issue, nil
+		}
+	}
+	return Issue{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+func (r *JSONL) Create(ctx context.Context, input CreateInput) (string, error) {
+	return "", ErrReadOnly
+}
+
+func (r *JSONL) Update(ctx context.Context, id string, input UpdateInput) error {
+	return ErrReadOnly
+}
+
+func (r *JSONL) Close(ctx context.Context, id string, reason string) error {
+	return ErrReadOnly
+}
+
+func (r *JSONL) AddDep(ctx context.Context, issueID string, dependsOnID string, depType string) error {
+	return ErrReadOnly
+}
+
+func (r *JSONL) Status(ctx context.Context) (Status, error) {
+	issues, err := LoadIssuesFile(r.Path)
+	if err != nil {
+		return Status{}, err
+	}
+	return Summarize(issues), nil
+}
@@ -0,0 +1,162 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity ranks lint findings.
+type Severity string
+
+const (
+	SeverityOff     Severity = "off"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+func (s Severity) rank() int {
+	switch s {
+	case SeverityInfo:
+		return 1
+	case SeverityWarning:
+		return 2
+	case SeverityError:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// AtLeast reports whether s is as severe as other.
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank() && s.rank() > 0
+}
+
+func ParseSeverity(value string) (Severity, error) {
+	switch Severity(strings.TrimSpace(strings.ToLower(value))) {
+	case SeverityOff:
+		return SeverityOff, nil
+	case SeverityInfo:
+		return SeverityInfo, nil
+	case SeverityWarning, "warn":
+		return SeverityWarning, nil
+	case SeverityError:
+		return SeverityError, nil
+	}
+	return "", fmt.Errorf("invalid severity %q (use off, info, warning or error)", value)
+}
+
+// Lint rule names.
+const (
+	RuleCycle              = "cycle"
+	RuleOrphanTask         = "orphan-task"
+	RuleMissingDescription = "missing-description"
+	RuleEpicSize           = "epic-size"
+	RuleClosedParent       = "closed-parent-open-child"
+)
+
+// DefaultMaxEpicChildren matches the 3-7 tasks per epic the planning prompt asks for.
+const DefaultMaxEpicChildren = 7
+
+// DefaultSeverities are used for rules that are not configured.
+var DefaultSeverities = map[string]Severity{
+	RuleCycle:              SeverityError,
+	RuleOrphanTask:         SeverityWarning,
+	RuleMissingDescription: SeverityWarning,
+	RuleEpicSize:           SeverityWarning,
+	RuleClosedParent:       SeverityError,
+}
+
+// Rules lists the rule names in report order.
+func Rules() []string {
+	return []string{RuleCycle, RuleClosedParent, RuleOrphanTask, RuleMissingDescription, RuleEpicSize}
+}
+
+// LintOptions overrides rule severities and thresholds.
+type LintOptions struct {
+	Severities      map[string]Severity
+	MaxEpicChildren int
+}
+
+func (o LintOptions) severity(rule string) Severity {
+	if severity, ok := o.Severities[rule]; ok {
+		return severity
+	}
+	return DefaultSeverities[rule]
+}
+
+// Finding is a single lint result.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	IssueID  string   `json:"issue_id"`
+	Message  string   `json:"message"`
+}
+
+// Lint checks the issue graph for structural problems.
+func Lint(graph *Graph, opts LintOptions) []Finding {
+	maxChildren := opts.MaxEpicChildren
+	if maxChildren <= 0 {
+		maxChildren = DefaultMaxEpicChildren
+	}
+
+	var findings []Finding
+	add := func(rule string, issueID string, format string, args ...any) {
+		severity := opts.severity(rule)
+		if severity == SeverityOff || severity == "" {
+			return
+		}
+		findings = append(findings, Finding{
+			Rule:     rule,
+			Severity: severity,
+			IssueID:  issueID,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, cycle := range graph.BlockingCycles() {
+		add(RuleCycle, cycle[0], "dependency cycle: %s -> %s", strings.Join(cycle, " -> "), cycle[0])
+	}
+
+	for _, issue := range graph.Issues {
+		if issue.IsClosed() {
+			var open []string
+			for _, childID := range graph.Children[issue.ID] {
+				if !graph.ByID[childID].IsClosed() {
+					open = append(open, childID)
+				}
+			}
+			if len(open) > 0 {
+				add(RuleClosedParent, issue.ID, "closed %s has %d open children: %s", issue.IssueType, len(open), strings.Join(open, ", "))
+			}
+			continue
+		}
+
+		if issue.IssueType == "task" && graph.Parent[issue.ID] == "" {
+			add(RuleOrphanTask, issue.ID, "task %q has no parent feature or epic", issue.Title)
+		}
+		if strings.TrimSpace(issue.Description) == "" {
+			add(RuleMissingDescription, issue.ID, "%s %q has no description", issue.IssueType, issue.Title)
+		}
+		if issue.IssueType == "epic" && len(graph.Children[issue.ID]) > maxChildren {
+			add(RuleEpicSize, issue.ID, "epic %q has %d children (max %d); consider splitting it", issue.Title, len(graph.Children[issue.ID]), maxChildren)
+		}
+	}
+
+	order := make(map[string]int, len(Rules()))
+	for i, rule := range Rules() {
+		order[rule] = i
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Severity.rank() != findings[j].Severity.rank() {
+			return findings[i].Severity.rank() > findings[j].Severity.rank()
+		}
+		if order[findings[i].Rule] != order[findings[j].Rule] {
+			return order[findings[i].Rule] < order[findings[j].Rule]
+		}
+		return findings[i].IssueID < findings[j].IssueID
+	})
+	return findings
+}
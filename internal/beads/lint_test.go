@@ -0,0 +1,70 @@
+package beads
+
+import (
+	"testing"
+)
+
+func dep(issueID string, dependsOn string, kind string) Dependency {
+	return Dependency{IssueID: issueID, DependsOnID: dependsOn, Type: kind}
+}
+
+func TestLintFindsStructuralProblems(t *testing.T) {
+	issues := []Issue{
+		{ID: "e1", Title: "Epic", Description: "d", Status: StatusClosed, IssueType: "epic"},
+		{ID: "t1", Title: "Child", Description: "d", Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep("t1", "e1", DepParentChild), dep("t1", "t2", DepBlocks)}},
+		{ID: "t2", Title: "Other", Description: "d", Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep("t2", "e1", DepParentChild), dep("t2", "t1", DepBlocks)}},
+		{ID: "t3", Title: "Orphan", Status: StatusOpen, IssueType: "task"},
+		{ID: "gone", Title: "Deleted", Status: StatusTombstone, IssueType: "task"},
+	}
+
+	findings := Lint(NewGraph(issues), LintOptions{})
+
+	got := map[string]string{}
+	for _, finding := range findings {
+		got[finding.Rule+"/"+finding.IssueID] = string(finding.Severity)
+	}
+	want := map[string]string{
+		RuleCycle + "/t1":              "error",
+		RuleClosedParent + "/e1":       "error",
+		RuleOrphanTask + "/t3":         "warning",
+		RuleMissingDescription + "/t3": "warning",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected findings %v, got %v", want, got)
+	}
+	for key, severity := range want {
+		if got[key] != severity {
+			t.Fatalf("expected %s=%s, got %v", key, severity, got)
+		}
+	}
+	if findings[0].Severity != SeverityError {
+		t.Fatalf("expected errors first, got %s", findings[0].Severity)
+	}
+}
+
+func TestLintRespectsOptions(t *testing.T) {
+	issues := []Issue{{ID: "e1", Title: "Epic", Description: "d", Status: StatusOpen, IssueType: "epic"}}
+	for _, id := range []string{"a", "b", "c"} {
+		issues = append(issues, Issue{ID: id, Title: id, Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep(id, "e1", DepParentChild)}})
+	}
+
+	findings := Lint(NewGraph(issues), LintOptions{
+		Severities:      map[string]Severity{RuleMissingDescription: SeverityOff, RuleEpicSize: SeverityInfo},
+		MaxEpicChildren: 2,
+	})
+	if len(findings) != 1 || findings[0].Rule != RuleEpicSize || findings[0].Severity != SeverityInfo {
+		t.Fatalf("expected a single info epic-size finding, got %+v", findings)
+	}
+}
+
+func TestParseSeverity(t *testing.T) {
+	if severity, err := ParseSeverity("WARN"); err != nil || severity != SeverityWarning {
+		t.Fatalf("expected warning, got %s (%v)", severity, err)
+	}
+	if _, err := ParseSeverity("fatal"); err == nil {
+		t.Fatal("expected invalid severity error")
+	}
+	if !SeverityError.AtLeast(SeverityWarning) || SeverityInfo.AtLeast(SeverityWarning) {
+		t.Fatal("unexpected severity ordering")
+	}
+}
@@ -0,0 +1,144 @@
+package beads
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Versioned is implemented by clients that can cheaply tell whether their
+// data changed: Version returns a different value after every change.
+type Versioned interface {
+	Version() string
+}
+
+// Local reads beads straight from disk without running bd: from bd's SQLite
+// database, opened read-only, when there is one, otherwise from
+// issues.jsonl. Nothing is exported or rewritten. Parsed issues are cached
+// until the files change. Writes go through the embedded bd CLI client.
+type Local struct {
+	*CLI
+	Root string
+
+	mu      sync.Mutex
+	version string
+	issues  []Issue
+}
+
+// NewLocal returns a local reader for the beads under root.
+func NewLocal(root string) *Local {
+	return &Local{CLI: NewCLI(root), Root: root}
+}
+
+// Version fingerprints the modification time and size of the database, its
+// WAL and the issues file.
+func (l *Local) Version() string {
+	var builder strings.Builder
+	for _, path := range l.sourcePaths() {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			builder.WriteString("-;")
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&builder, "%d:%d;", info.ModTime().UnixNano(), info.Size())
+	}
+	return builder.String()
+}
+
+func (l *Local) List(ctx context.Context, opts ListOptions) ([]Issue, error) {
+	issues, err := l.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return filterIssues(issues, opts), nil
+}
+
+func (l *Local) Show(ctx context.Context, id string) (Issue, error) {
+	issues, err := l.load(ctx)
+	if err != nil {
+		return Issue{}, err
+	}
+	for _, issue := range issues {
+		if issue.ID == id {
+			return issue, nil
+		}
+	}
+	return Issue{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Status summarizes the issues locally; recent activity is not available.
+func (l *Local) Status(ctx context.Context) (Status, error) {
+	issues, err := l.load(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+	return Summarize(issues), nil
+}
+
+func (l *Local) load(ctx context.Context) ([]Issue, error) {
+	version := l.Version()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.issues != nil && version == l.version {
+		return l.issues, nil
+	}
+
+	var issues []Issue
+	var err error
+	if path := l.databaseSource(); path != "" {
+		issues, err = LoadDatabase(ctx, path)
+	} else {
+		issues, err = LoadIssues(l.Root)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if issues == nil {
+		issues = []Issue{}
+	}
+
+	l.issues = issues
+	l.version = version
+	return issues, nil
+}
+
+// databaseSource returns the database to read, or "" to read issues.jsonl
+// instead: when there is no database, or when the issues file is newer
+// because a git pull updated it before bd imported it.
+func (l *Local) databaseSource() string {
+	db := DatabasePath(l.Root)
+	if db == "" {
+		return ""
+	}
+	jsonl, err := os.Stat(filepath.Join(l.Root, Dir, IssuesFile))
+	if err != nil {
+		return db
+	}
+	newest := time.Time{}
+	for _, path := range []string{db, db + "-wal"} {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	if jsonl.ModTime().After(newest) {
+		return ""
+	}
+	return db
+}
+
+func (l *Local) sourcePaths() []string {
+	paths := []string{filepath.Join(l.Root, Dir, IssuesFile)}
+	if db := DatabasePath(l.Root); db != "" {
+		paths = append(paths, db, db+"-wal")
+	}
+	return paths
+}
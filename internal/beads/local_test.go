@@ -0,0 +1,167 @@
+package beads
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testSchema = `
+CREATE TABLE issues (
+	id TEXT PRIMARY KEY,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL DEFAULT '',
+	acceptance_criteria TEXT NOT NULL DEFAULT '',
+	status TEXT NOT NULL DEFAULT 'open',
+	priority INTEGER NOT NULL DEFAULT 2,
+	issue_type TEXT NOT NULL DEFAULT 'task',
+	assignee TEXT,
+	estimated_minutes INTEGER,
+	created_at DATETIME NOT NULL,
+	updated_at DATETIME NOT NULL,
+	closed_at DATETIME,
+	close_reason TEXT DEFAULT ''
+);
+CREATE TABLE dependencies (
+	issue_id TEXT NOT NULL,
+	depends_on_id TEXT NOT NULL,
+	type TEXT NOT NULL DEFAULT 'blocks',
+	created_at DATETIME NOT NULL
+);
+CREATE TABLE labels (issue_id TEXT NOT NULL, label TEXT NOT NULL);
+`
+
+func writeTestDatabase(t *testing.T, root string) string {
+	t.Helper()
+	path := filepath.Join(root, Dir, defaultDatabase)
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer db.Close()
+
+	statements := []string{
+		testSchema,
+		`INSERT INTO issues (id, title, status, priority, issue_type, estimated_minutes, created_at, updated_at)
+			VALUES ('cn-1', 'Epic', 'open', 1, 'epic', NULL, '2026-02-01 10:00:00+00:00', '2026-02-01 10:00:00+00:00')`,
+		`INSERT INTO issues (id, title, acceptance_criteria, status, priority, issue_type, estimated_minutes, created_at, updated_at, closed_at, close_reason)
+			VALUES ('cn-2', 'Task', 'Tests pass', 'closed', 2, 'task', 45, '2026-02-01T10:00:00Z', '2026-02-02T10:00:00Z', '2026-02-02T10:00:00Z', 'done')`,
+		`INSERT INTO dependencies VALUES ('cn-2', 'cn-1', 'parent-child', '2026-02-01T10:00:00Z')`,
+		`INSERT INTO labels VALUES ('cn-2', 'backend')`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("exec %q: %v", statement, err)
+		}
+	}
+	return path
+}
+
+func TestLoadDatabase(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, Dir), 0755); err != nil {
+		t.Fatalf("create beads dir: %v", err)
+	}
+	path := writeTestDatabase(t, root)
+
+	if got := DatabasePath(root); got != path {
+		t.Fatalf("expected database path %s, got %q", path, got)
+	}
+
+	issues, err := LoadDatabase(context.Background(), path)
+	if err != nil {
+		t.Fatalf("load database: %v", err)
+	}
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %d", len(issues))
+	}
+	task := issues[1]
+	if task.ParentID() != "cn-1" || !task.HasLabel("backend") || task.CloseReason != "done" || task.AcceptanceCriteria != "Tests pass" {
+		t.Fatalf("unexpected task %+v", task)
+	}
+	if task.Estimate == nil || *task.Estimate != 45 || task.ClosedAt == nil {
+		t.Fatalf("expected estimate and closed time on %+v", task)
+	}
+	if issues[0].CreatedAt.IsZero() || issues[0].Estimate != nil {
+		t.Fatalf("unexpected epic %+v", issues[0])
+	}
+}
+
+func TestLocalCachesUntilFilesChange(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, Dir), 0755); err != nil {
+		t.Fatalf("create beads dir: %v", err)
+	}
+	issuesPath := filepath.Join(root, Dir, IssuesFile)
+	if err := os.WriteFile(issuesPath, []byte(`{"id":"cn-1","title":"One","status":"open"}`+"\n"), 0644); err != nil {
+		t.Fatalf("write issues: %v", err)
+	}
+
+	ctx := context.Background()
+	client := NewLocal(root)
+	version := client.Version()
+	issues, err := client.List(ctx, ListOptions{})
+	if err != nil || len(issues) != 1 {
+		t.Fatalf("expected one issue, got %d (%v)", len(issues), err)
+	}
+	if client.Version() != version {
+		t.Fatalf("expected reading to leave the version unchanged")
+	}
+
+	data := `{"id":"cn-1","title":"One","status":"open"}` + "\n" + `{"id":"cn-2","title":"Two","status":"open"}` + "\n"
+	if err := os.WriteFile(issuesPath, []byte(data), 0644); err != nil {
+		t.Fatalf("rewrite issues: %v", err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(issuesPath, later, later); err != nil {
+		t.Fatalf("touch issues: %v", err)
+	}
+	if client.Version() == version {
+		t.Fatalf("expected the version to change")
+	}
+	issues, err = client.List(ctx, ListOptions{})
+	if err != nil || len(issues) != 2 {
+		t.Fatalf("expected reload with two issues, got %d (%v)", len(issues), err)
+	}
+}
+
+func TestLocalPrefersDatabase(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, Dir), 0755); err != nil {
+		t.Fatalf("create beads dir: %v", err)
+	}
+	issuesPath := filepath.Join(root, Dir, IssuesFile)
+	if err := os.WriteFile(issuesPath, []byte(`{"id":"cn-9","title":"Stale","status":"open"}`+"\n"), 0644); err != nil {
+		t.Fatalf("write issues: %v", err)
+	}
+	earlier := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(issuesPath, earlier, earlier); err != nil {
+		t.Fatalf("touch issues: %v", err)
+	}
+	path := writeTestDatabase(t, root)
+
+	client := NewLocal(root)
+	issue, err := client.Show(context.Background(), "cn-2")
+	if err != nil {
+		t.Fatalf("show from database: %v", err)
+	}
+	if issue.Title != "Task" {
+		t.Fatalf("unexpected issue %+v", issue)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("stat database: %v", err)
+	}
+	status, err := client.Status(context.Background())
+	if err != nil || status.Summary.TotalIssues != 2 {
+		t.Fatalf("unexpected status %+v (%v)", status.Summary, err)
+	}
+	after, _ := os.Stat(path)
+	if !after.ModTime().Equal(info.ModTime()) {
+		t.Fatalf("expected the database to be left untouched")
+	}
+}
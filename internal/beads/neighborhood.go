@@ -0,0 +1,211 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DefaultGraphDepth is how many blocks links Neighborhood follows in each
+// direction when no depth is given.
+const DefaultGraphDepth = 2
+
+// GraphNode is an issue in a Neighborhood.
+type GraphNode struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Type     string `json:"issue_type,omitempty"`
+	Priority int    `json:"priority"`
+	Layer    int    `json:"layer"`
+}
+
+// GraphEdge is a blocks link: From must be done before To can start.
+type GraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Neighborhood is the blocks DAG around one issue: the issues that block it
+// (upstream) and the issues it blocks (downstream), up to Depth links away.
+// Layers run from the furthest blockers to the furthest dependents, so every
+// edge points to a later layer when the links form no cycle.
+type Neighborhood struct {
+	Root   string      `json:"root"`
+	Depth  int         `json:"depth"`
+	Nodes  []GraphNode `json:"nodes"`
+	Edges  []GraphEdge `json:"edges"`
+	Layers [][]string  `json:"layers"`
+}
+
+// Node returns the node with the given ID.
+func (n Neighborhood) Node(id string) (GraphNode, bool) {
+	for _, node := range n.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return GraphNode{}, false
+}
+
+// Outgoing returns the IDs the node blocks, in layer order.
+func (n Neighborhood) Outgoing(id string) []string {
+	var targets []string
+	for _, edge := range n.Edges {
+		if edge.From == id {
+			targets = append(targets, edge.To)
+		}
+	}
+	return targets
+}
+
+// Neighborhood collects the issues within depth blocks links of id, in both
+// directions. A depth below one uses DefaultGraphDepth.
+func (g *Graph) Neighborhood(id string, depth int) (Neighborhood, error) {
+	if _, ok := g.ByID[id]; !ok {
+		return Neighborhood{}, fmt.Errorf("issue %s not found", id)
+	}
+	if depth < 1 {
+		depth = DefaultGraphDepth
+	}
+
+	dependents := make(map[string][]string)
+	for _, issue := range g.Issues {
+		for _, blocker := range g.Blocks[issue.ID] {
+			dependents[blocker] = append(dependents[blocker], issue.ID)
+		}
+	}
+
+	// distance is negative upstream and positive downstream; the first
+	// direction to reach an issue wins.
+	distance := map[string]int{id: 0}
+	walk := func(next map[string][]string, sign int) {
+		frontier := []string{id}
+		for step := 1; step <= depth && len(frontier) > 0; step++ {
+			var following []string
+			for _, current := range frontier {
+				for _, other := range next[current] {
+					if _, seen := distance[other]; seen {
+						continue
+					}
+					distance[other] = sign * step
+					following = append(following, other)
+				}
+			}
+			frontier = following
+		}
+	}
+	walk(g.Blocks, -1)
+	walk(dependents, 1)
+
+	var edges []GraphEdge
+	for _, issue := range g.Issues {
+		if _, ok := distance[issue.ID]; !ok {
+			continue
+		}
+		for _, blocker := range g.Blocks[issue.ID] {
+			if _, ok := distance[blocker]; ok {
+				edges = append(edges, GraphEdge{From: blocker, To: issue.ID})
+			}
+		}
+	}
+
+	layerOf := longestPathLayers(distance, edges)
+	if layerOf == nil {
+		layerOf = make(map[string]int, len(distance))
+		for node, dist := range distance {
+			layerOf[node] = dist + depth
+		}
+	}
+
+	hood := Neighborhood{Root: id, Depth: depth, Edges: edges}
+	for node := range distance {
+		issue := g.ByID[node]
+		hood.Nodes = append(hood.Nodes, GraphNode{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Status:   issue.Status,
+			Type:     issue.IssueType,
+			Priority: issue.Priority,
+			Layer:    layerOf[node],
+		})
+	}
+	compactLayers(hood.Nodes)
+	sort.Slice(hood.Nodes, func(i, j int) bool {
+		if hood.Nodes[i].Layer != hood.Nodes[j].Layer {
+			return hood.Nodes[i].Layer < hood.Nodes[j].Layer
+		}
+		return hood.Nodes[i].ID < hood.Nodes[j].ID
+	})
+	for _, node := range hood.Nodes {
+		for len(hood.Layers) <= node.Layer {
+			hood.Layers = append(hood.Layers, nil)
+		}
+		hood.Layers[node.Layer] = append(hood.Layers[node.Layer], node.ID)
+	}
+	sort.Slice(hood.Edges, func(i, j int) bool {
+		if hood.Edges[i].From != hood.Edges[j].From {
+			return hood.Edges[i].From < hood.Edges[j].From
+		}
+		return hood.Edges[i].To < hood.Edges[j].To
+	})
+	return hood, nil
+}
+
+// longestPathLayers places each node one layer after its latest blocker, or
+// returns nil when the edges contain a cycle.
+func longestPathLayers(nodes map[string]int, edges []GraphEdge) map[string]int {
+	indegree := make(map[string]int, len(nodes))
+	next := make(map[string][]string)
+	for _, edge := range edges {
+		indegree[edge.To]++
+		next[edge.From] = append(next[edge.From], edge.To)
+	}
+	var queue []string
+	for node := range nodes {
+		if indegree[node] == 0 {
+			queue = append(queue, node)
+		}
+	}
+	sort.Strings(queue)
+
+	layers := make(map[string]int, len(nodes))
+	placed := 0
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		placed++
+		for _, target := range next[current] {
+			if layers[current]+1 > layers[target] {
+				layers[target] = layers[current] + 1
+			}
+			indegree[target]--
+			if indegree[target] == 0 {
+				queue = append(queue, target)
+			}
+		}
+	}
+	if placed != len(nodes) {
+		return nil
+	}
+	return layers
+}
+
+// compactLayers renumbers layers from zero without gaps.
+func compactLayers(nodes []GraphNode) {
+	used := make(map[int]bool)
+	for _, node := range nodes {
+		used[node.Layer] = true
+	}
+	var values []int
+	for layer := range used {
+		values = append(values, layer)
+	}
+	sort.Ints(values)
+	index := make(map[int]int, len(values))
+	for i, layer := range values {
+		index[layer] = i
+	}
+	for i := range nodes {
+		nodes[i].Layer = index[nodes[i].Layer]
+	}
+}
@@ -0,0 +1,104 @@
+package beads
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func neighborhoodIssues() []Issue {
+	return []Issue{
+		{ID: "a", Title: "Schema", Status: StatusClosed},
+		{ID: "b", Title: "API", Status: StatusInProgress, Dependencies: []Dependency{dep("b", "a", DepBlocks)}},
+		{ID: "c", Title: "Auth", Status: StatusOpen},
+		{ID: "d", Title: "UI", Status: StatusOpen, Dependencies: []Dependency{dep("d", "b", DepBlocks), dep("d", "c", DepBlocks)}},
+		{ID: "e", Title: "Docs", Status: StatusBlocked, Dependencies: []Dependency{dep("e", "d", DepBlocks)}},
+		{ID: "f", Title: "Launch", Status: StatusOpen, Dependencies: []Dependency{dep("f", "e", DepBlocks)}},
+		{ID: "x", Title: "Unrelated", Status: StatusOpen, Dependencies: []Dependency{dep("x", "a", DepBlocks)}},
+	}
+}
+
+func TestNeighborhoodLayersAndDepth(t *testing.T) {
+	graph := NewGraph(neighborhoodIssues())
+
+	hood, err := graph.Neighborhood("d", 1)
+	if err != nil {
+		t.Fatalf("neighborhood: %v", err)
+	}
+	if want := [][]string{{"b", "c"}, {"d"}, {"e"}}; !reflect.DeepEqual(hood.Layers, want) {
+		t.Fatalf("expected layers %v, got %v", want, hood.Layers)
+	}
+
+	hood, err = graph.Neighborhood("d", 2)
+	if err != nil {
+		t.Fatalf("neighborhood: %v", err)
+	}
+	if want := [][]string{{"a", "c"}, {"b"}, {"d"}, {"e"}, {"f"}}; !reflect.DeepEqual(hood.Layers, want) {
+		t.Fatalf("expected layers %v, got %v", want, hood.Layers)
+	}
+	if _, ok := hood.Node("x"); ok {
+		t.Fatal("expected issues only sharing a blocker to be left out")
+	}
+	if want := []string{"d"}; !reflect.DeepEqual(hood.Outgoing("b"), want) {
+		t.Fatalf("expected b to block %v, got %v", want, hood.Outgoing("b"))
+	}
+
+	if _, err := graph.Neighborhood("missing", 1); err == nil {
+		t.Fatal("expected an unknown issue to fail")
+	}
+}
+
+func TestNeighborhoodToleratesCycles(t *testing.T) {
+	issues := []Issue{
+		{ID: "a", Status: StatusOpen, Dependencies: []Dependency{dep("a", "b", DepBlocks)}},
+		{ID: "b", Status: StatusOpen, Dependencies: []Dependency{dep("b", "a", DepBlocks)}},
+	}
+	hood, err := NewGraph(issues).Neighborhood("a", 1)
+	if err != nil {
+		t.Fatalf("neighborhood: %v", err)
+	}
+	if len(hood.Nodes) != 2 || len(hood.Edges) != 2 {
+		t.Fatalf("expected both issues and links, got %+v", hood)
+	}
+}
+
+func TestFormatNeighborhood(t *testing.T) {
+	hood, err := NewGraph(neighborhoodIssues()).Neighborhood("b", 1)
+	if err != nil {
+		t.Fatalf("neighborhood: %v", err)
+	}
+
+	dot, _ := FormatNeighborhood(hood, "dot", 0)
+	for _, want := range []string{`"a" -> "b";`, `"b" -> "d";`, `fillcolor="#dbe9ff", penwidth=2`} {
+		if !strings.Contains(dot, want) {
+			t.Fatalf("expected dot to contain %q:\n%s", want, dot)
+		}
+	}
+
+	mermaid, _ := FormatNeighborhood(hood, "mermaid", 0)
+	for _, want := range []string{"graph LR", `b["b: API"]`, "a --> b", "class b in_progress"} {
+		if !strings.Contains(mermaid, want) {
+			t.Fatalf("expected mermaid to contain %q:\n%s", want, mermaid)
+		}
+	}
+
+	ascii, _ := FormatNeighborhood(hood, "ascii", 0)
+	lines := strings.Split(strings.TrimRight(ascii, "\n"), "\n")
+	if len(lines) != 5 || !strings.Contains(lines[0], "╔") || !strings.Contains(lines[2], "───▶") {
+		t.Fatalf("expected three boxed columns with arrows:\n%s", ascii)
+	}
+
+	narrow, _ := FormatNeighborhood(hood, "ascii", 30)
+	for _, line := range strings.Split(strings.TrimRight(narrow, "\n"), "\n") {
+		if visibleWidth(line) > 30 {
+			t.Fatalf("expected lines within 30 columns, got %q", line)
+		}
+	}
+	if !strings.Contains(narrow, "▼") {
+		t.Fatalf("expected stacked layers:\n%s", narrow)
+	}
+
+	if _, err := FormatNeighborhood(hood, "svg", 0); err == nil {
+		t.Fatal("expected an unknown format to fail")
+	}
+}
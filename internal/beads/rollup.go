@@ -0,0 +1,142 @@
+package beads
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultClosureWindow is how far back ClosureRate looks by default.
+const DefaultClosureWindow = 14 * 24 * time.Hour
+
+// StatusCounts counts issues by status. Statuses other than in progress,
+// blocked and closed count as open.
+type StatusCounts struct {
+	Open       int `json:"open"`
+	InProgress int `json:"in_progress"`
+	Blocked    int `json:"blocked"`
+	Closed     int `json:"closed"`
+}
+
+func (c *StatusCounts) add(status string) {
+	switch status {
+	case StatusInProgress:
+		c.InProgress++
+	case StatusBlocked:
+		c.Blocked++
+	case StatusClosed:
+		c.Closed++
+	default:
+		c.Open++
+	}
+}
+
+// Total is the number of issues counted.
+func (c StatusCounts) Total() int {
+	return c.Open + c.InProgress + c.Blocked + c.Closed
+}
+
+// Remaining is the number of issues not yet closed.
+func (c StatusCounts) Remaining() int {
+	return c.Total() - c.Closed
+}
+
+// Rollup summarizes the tree below an issue. Counts and ByType cover every
+// descendant, not only direct children.
+type Rollup struct {
+	Issue    Issue                   `json:"issue"`
+	Counts   StatusCounts            `json:"counts"`
+	ByType   map[string]StatusCounts `json:"by_type,omitempty"`
+	Children []Rollup                `json:"children,omitempty"`
+}
+
+// Progress is the closed share of the descendants, or 0 or 1 by the issue's
+// own status when it has none.
+func (r Rollup) Progress() float64 {
+	if total := r.Counts.Total(); total > 0 {
+		return float64(r.Counts.Closed) / float64(total)
+	}
+	if r.Issue.IsClosed() {
+		return 1
+	}
+	return 0
+}
+
+// Rollup builds the rollup of id and, recursively, of each of its children.
+func (g *Graph) Rollup(id string) (Rollup, bool) {
+	if _, ok := g.ByID[id]; !ok {
+		return Rollup{}, false
+	}
+	return g.rollup(id, map[string]bool{}), true
+}
+
+func (g *Graph) rollup(id string, seen map[string]bool) Rollup {
+	seen[id] = true
+	result := Rollup{Issue: g.ByID[id]}
+	for _, childID := range g.Children[id] {
+		if seen[childID] {
+			continue
+		}
+		child := g.rollup(childID, seen)
+		result.Children = append(result.Children, child)
+		result.Counts.add(child.Issue.Status)
+		result.addType(child.Issue.IssueType, child.Issue.Status)
+		result.Counts.Open += child.Counts.Open
+		result.Counts.InProgress += child.Counts.InProgress
+		result.Counts.Blocked += child.Counts.Blocked
+		result.Counts.Closed += child.Counts.Closed
+		for issueType, counts := range child.ByType {
+			merged := result.ByType[issueType]
+			merged.Open += counts.Open
+			merged.InProgress += counts.InProgress
+			merged.Blocked += counts.Blocked
+			merged.Closed += counts.Closed
+			result.ByType[issueType] = merged
+		}
+	}
+	return result
+}
+
+func (r *Rollup) addType(issueType string, status string) {
+	if r.ByType == nil {
+		r.ByType = make(map[string]StatusCounts)
+	}
+	counts := r.ByType[issueType]
+	counts.add(status)
+	r.ByType[issueType] = counts
+}
+
+// EligibleForClosure reports whether id has children and all of them are
+// closed, the rule behind StatusSummary.EpicsEligibleForClosure.
+func (g *Graph) EligibleForClosure(id string) bool {
+	return eligibleForClosure(g, id)
+}
+
+// ClosureRate is how many of ids were closed per day over the window before
+// now.
+func (g *Graph) ClosureRate(ids []string, now time.Time, window time.Duration) float64 {
+	if window <= 0 {
+		window = DefaultClosureWindow
+	}
+	since := now.Add(-window)
+	closed := 0
+	for _, id := range ids {
+		issue, ok := g.ByID[id]
+		if ok && issue.IsClosed() && issue.ClosedAt != nil && issue.ClosedAt.After(since) && !issue.ClosedAt.After(now) {
+			closed++
+		}
+	}
+	return float64(closed) / window.Hours() * 24
+}
+
+// EstimateCompletion projects when remaining issues will be closed at perDay
+// closures a day. It reports false when nothing has been closed recently.
+func EstimateCompletion(remaining int, perDay float64, now time.Time) (time.Time, bool) {
+	if remaining <= 0 {
+		return now, true
+	}
+	if perDay <= 0 {
+		return time.Time{}, false
+	}
+	days := math.Ceil(float64(remaining) / perDay)
+	return now.Add(time.Duration(days) * 24 * time.Hour), true
+}
@@ -0,0 +1,52 @@
+package beads
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRollupCountsDescendants(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-48 * time.Hour)
+	longAgo := now.Add(-30 * 24 * time.Hour)
+	graph := NewGraph([]Issue{
+		{ID: "e", Title: "Epic", Status: StatusOpen, IssueType: "epic"},
+		{ID: "f1", Status: StatusInProgress, IssueType: "feature", Dependencies: []Dependency{dep("f1", "e", DepParentChild)}},
+		{ID: "f2", Status: StatusClosed, IssueType: "feature", ClosedAt: &closedAt, Dependencies: []Dependency{dep("f2", "e", DepParentChild)}},
+		{ID: "t1", Status: StatusClosed, IssueType: "task", ClosedAt: &closedAt, Dependencies: []Dependency{dep("t1", "f1", DepParentChild)}},
+		{ID: "t2", Status: StatusBlocked, IssueType: "task", Dependencies: []Dependency{dep("t2", "f1", DepParentChild)}},
+		{ID: "t3", Status: StatusClosed, IssueType: "task", ClosedAt: &longAgo, Dependencies: []Dependency{dep("t3", "f2", DepParentChild)}},
+	})
+
+	rollup, ok := graph.Rollup("e")
+	if !ok {
+		t.Fatal("expected a rollup for e")
+	}
+	if want := (StatusCounts{InProgress: 1, Blocked: 1, Closed: 3}); rollup.Counts != want {
+		t.Fatalf("expected counts %+v, got %+v", want, rollup.Counts)
+	}
+	if features := rollup.ByType["feature"]; features.Total() != 2 || features.Closed != 1 {
+		t.Fatalf("unexpected feature counts %+v", features)
+	}
+	if tasks := rollup.ByType["task"]; tasks.Total() != 3 || tasks.Remaining() != 1 {
+		t.Fatalf("unexpected task counts %+v", tasks)
+	}
+	if len(rollup.Children) != 2 || rollup.Children[0].Progress() != 0.5 || rollup.Progress() != 0.6 {
+		t.Fatalf("unexpected progress: epic %.2f, children %+v", rollup.Progress(), rollup.Children)
+	}
+	if graph.EligibleForClosure("e") || !graph.EligibleForClosure("f2") {
+		t.Fatal("expected only f2 to be eligible for closure")
+	}
+
+	rate := graph.ClosureRate(graph.Descendants("e"), now, 0)
+	if rate != 2.0/14 {
+		t.Fatalf("expected two closures in 14 days, got %.3f/day", rate)
+	}
+	eta, ok := EstimateCompletion(rollup.Counts.Remaining(), rate, now)
+	if !ok || !eta.Equal(now.Add(14*24*time.Hour)) {
+		t.Fatalf("expected completion in 14 days, got %v (%v)", eta, ok)
+	}
+	if _, ok := EstimateCompletion(1, 0, now); ok {
+		t.Fatal("expected no estimate without recent closures")
+	}
+}
@@ -0,0 +1,246 @@
+package beads
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultEstimateMinutes is assumed for work items without an estimate when
+// other items in the schedule carry one.
+const DefaultEstimateMinutes = 60
+
+// ScheduleItem is an open work item with its resolved dependencies.
+type ScheduleItem struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Weight    int      `json:"weight"`    // estimate in minutes, or 1 when no item is estimated
+	Estimated bool     `json:"estimated"` // the weight comes from the bead's estimate
+	DependsOn []string `json:"depends_on,omitempty"`
+	Start     int      `json:"start"`  // earliest start with unlimited parallelism
+	Finish    int      `json:"finish"` // earliest finish with unlimited parallelism
+}
+
+// Schedule is the dependency analysis of an epic's open work.
+type Schedule struct {
+	EpicID           string         `json:"epic_id"`
+	EpicTitle        string         `json:"epic_title"`
+	UsesEstimates    bool           `json:"uses_estimates"` // weights are minutes rather than item counts
+	Items            []ScheduleItem `json:"items"`
+	Waves            [][]string     `json:"waves"`
+	MaxParallelism   int            `json:"max_parallelism"`
+	CriticalPath     []string       `json:"critical_path"`
+	CriticalLength   int            `json:"critical_length"`
+	TotalWork        int            `json:"total_work"`
+	ExternalBlockers []string       `json:"external_blockers,omitempty"` // open issues outside the epic that block its work
+}
+
+// Item returns the schedule item with the given ID.
+func (s Schedule) Item(id string) (ScheduleItem, bool) {
+	for _, item := range s.Items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return ScheduleItem{}, false
+}
+
+// PlanEpic analyses the open leaf work under epicID. Blocks dependencies on a
+// feature (or on an ancestor of a task) apply to all of the open leaves under
+// it; dependencies on closed issues are already satisfied. Items are grouped
+// into waves that can run concurrently, and the critical path is the longest
+// weighted chain of dependencies.
+func PlanEpic(graph *Graph, epicID string) (Schedule, error) {
+	epic, ok := graph.ByID[epicID]
+	if !ok {
+		return Schedule{}, fmt.Errorf("issue %s not found", epicID)
+	}
+
+	inScope := map[string]bool{epicID: true}
+	for _, id := range graph.Descendants(epicID) {
+		inScope[id] = true
+	}
+
+	leaves := make(map[string][]string)
+	var leavesOf func(id string) []string
+	leavesOf = func(id string) []string {
+		if cached, ok := leaves[id]; ok {
+			return cached
+		}
+		leaves[id] = nil // guards against parent/child loops
+		var result []string
+		for _, childID := range graph.Children[id] {
+			if !graph.ByID[childID].IsClosed() {
+				result = append(result, leavesOf(childID)...)
+			}
+		}
+		if len(result) == 0 && id != epicID {
+			result = []string{id}
+		}
+		leaves[id] = result
+		return result
+	}
+
+	work := leavesOf(epicID)
+	schedule := Schedule{EpicID: epicID, EpicTitle: epic.Title}
+	if len(work) == 0 {
+		return schedule, nil
+	}
+
+	for _, id := range work {
+		if graph.ByID[id].Estimate != nil {
+			schedule.UsesEstimates = true
+			break
+		}
+	}
+
+	inWork := make(map[string]bool, len(work))
+	for _, id := range work {
+		inWork[id] = true
+	}
+
+	external := map[string]bool{}
+	items := make(map[string]*ScheduleItem, len(work))
+	order := make([]string, 0, len(work))
+	for _, id := range work {
+		issue := graph.ByID[id]
+		item := &ScheduleItem{ID: id, Title: issue.Title, Weight: 1}
+		if schedule.UsesEstimates {
+			item.Weight = DefaultEstimateMinutes
+			if issue.Estimate != nil {
+				item.Weight = *issue.Estimate
+				item.Estimated = true
+			}
+		}
+
+		deps := map[string]bool{}
+		for ancestor := id; ancestor != "" && ancestor != epicID; ancestor = graph.Parent[ancestor] {
+			for _, depID := range graph.Blocks[ancestor] {
+				dep := graph.ByID[depID]
+				switch {
+				case dep.IsClosed():
+				case !inScope[depID]:
+					external[depID] = true
+				default:
+					// Open work under a closed parent is outside the
+					// schedule, so it blocks like an external issue.
+					for _, leaf := range leavesOf(depID) {
+						switch {
+						case leaf == id:
+						case !inWork[leaf]:
+							external[leaf] = true
+						default:
+							deps[leaf] = true
+						}
+					}
+				}
+			}
+		}
+		for depID := range deps {
+			item.DependsOn = append(item.DependsOn, depID)
+		}
+		sort.Strings(item.DependsOn)
+
+		items[id] = item
+		order = append(order, id)
+	}
+
+	waves, err := layer(order, items)
+	if err != nil {
+		return Schedule{}, err
+	}
+	schedule.Waves = waves
+
+	var last *ScheduleItem
+	for _, wave := range waves {
+		if len(wave) > schedule.MaxParallelism {
+			schedule.MaxParallelism = len(wave)
+		}
+		for _, id := range wave {
+			item := items[id]
+			for _, depID := range item.DependsOn {
+				if items[depID].Finish > item.Start {
+					item.Start = items[depID].Finish
+				}
+			}
+			item.Finish = item.Start + item.Weight
+			schedule.TotalWork += item.Weight
+			if last == nil || item.Finish > last.Finish {
+				last = item
+			}
+		}
+	}
+
+	schedule.CriticalLength = last.Finish
+	for current := last; current != nil; {
+		schedule.CriticalPath = append([]string{current.ID}, schedule.CriticalPath...)
+		var next *ScheduleItem
+		for _, depID := range current.DependsOn {
+			dep := items[depID]
+			if dep.Finish == current.Start && (next == nil || dep.ID < next.ID) {
+				next = dep
+			}
+		}
+		current = next
+	}
+
+	for _, id := range order {
+		schedule.Items = append(schedule.Items, *items[id])
+	}
+	for id := range external {
+		schedule.ExternalBlockers = append(schedule.ExternalBlockers, id)
+	}
+	sort.Strings(schedule.ExternalBlockers)
+
+	return schedule, nil
+}
+
+// layer groups items into waves: each wave holds the items whose
+// dependencies are all in earlier waves.
+func layer(order []string, items map[string]*ScheduleItem) ([][]string, error) {
+	remaining := make(map[string]int, len(order))
+	dependents := make(map[string][]string)
+	for _, id := range order {
+		remaining[id] = len(items[id].DependsOn)
+		for _, depID := range items[id].DependsOn {
+			dependents[depID] = append(dependents[depID], id)
+		}
+	}
+
+	var current []string
+	for _, id := range order {
+		if remaining[id] == 0 {
+			current = append(current, id)
+		}
+	}
+
+	var waves [][]string
+	placed := 0
+	for len(current) > 0 {
+		sort.Strings(current)
+		waves = append(waves, current)
+		placed += len(current)
+
+		var next []string
+		for _, id := range current {
+			for _, dependent := range dependents[id] {
+				remaining[dependent]--
+				if remaining[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		current = next
+	}
+
+	if placed < len(order) {
+		var stuck []string
+		for _, id := range order {
+			if remaining[id] > 0 {
+				stuck = append(stuck, id)
+			}
+		}
+		return nil, fmt.Errorf("dependency cycle among %s", strings.Join(stuck, ", "))
+	}
+	return waves, nil
+}
@@ -0,0 +1,80 @@
+package beads
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanEpicWavesAndCriticalPath(t *testing.T) {
+	minutes := func(value int) *int { return &value }
+	issues := []Issue{
+		{ID: "e1", Title: "Epic", Status: StatusOpen, IssueType: "epic"},
+		{ID: "f1", Title: "Backend", Status: StatusOpen, IssueType: "feature", Dependencies: []Dependency{dep("f1", "e1", DepParentChild)}},
+		{ID: "a", Title: "Schema", Status: StatusOpen, IssueType: "task", Estimate: minutes(30), Dependencies: []Dependency{dep("a", "f1", DepParentChild)}},
+		{ID: "b", Title: "API", Status: StatusOpen, IssueType: "task", Estimate: minutes(120), Dependencies: []Dependency{dep("b", "f1", DepParentChild), dep("b", "a", DepBlocks)}},
+		{ID: "c", Title: "Docs", Status: StatusOpen, IssueType: "task", Estimate: minutes(15), Dependencies: []Dependency{dep("c", "e1", DepParentChild)}},
+		{ID: "d", Title: "UI", Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep("d", "e1", DepParentChild), dep("d", "f1", DepBlocks), dep("d", "x", DepBlocks)}},
+		{ID: "done", Title: "Spike", Status: StatusClosed, IssueType: "task", Dependencies: []Dependency{dep("done", "e1", DepParentChild)}},
+		{ID: "x", Title: "Elsewhere", Status: StatusOpen, IssueType: "task"},
+	}
+
+	schedule, err := PlanEpic(NewGraph(issues), "e1")
+	if err != nil {
+		t.Fatalf("plan epic: %v", err)
+	}
+
+	if !schedule.UsesEstimates {
+		t.Fatalf("expected estimates to be used")
+	}
+	wantWaves := [][]string{{"a", "c"}, {"b"}, {"d"}}
+	if !reflect.DeepEqual(schedule.Waves, wantWaves) {
+		t.Fatalf("expected waves %v, got %v", wantWaves, schedule.Waves)
+	}
+	if schedule.MaxParallelism != 2 {
+		t.Fatalf("expected max parallelism 2, got %d", schedule.MaxParallelism)
+	}
+	if want := []string{"a", "b", "d"}; !reflect.DeepEqual(schedule.CriticalPath, want) {
+		t.Fatalf("expected critical path %v, got %v", want, schedule.CriticalPath)
+	}
+	if schedule.CriticalLength != 30+120+DefaultEstimateMinutes {
+		t.Fatalf("unexpected critical length %d", schedule.CriticalLength)
+	}
+	if want := []string{"x"}; !reflect.DeepEqual(schedule.ExternalBlockers, want) {
+		t.Fatalf("expected external blockers %v, got %v", want, schedule.ExternalBlockers)
+	}
+}
+
+func TestPlanEpicRejectsCycles(t *testing.T) {
+	issues := []Issue{
+		{ID: "e1", Title: "Epic", Status: StatusOpen, IssueType: "epic"},
+		{ID: "a", Title: "A", Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep("a", "e1", DepParentChild), dep("a", "b", DepBlocks)}},
+		{ID: "b", Title: "B", Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep("b", "e1", DepParentChild), dep("b", "a", DepBlocks)}},
+	}
+
+	if _, err := PlanEpic(NewGraph(issues), "e1"); err == nil {
+		t.Fatalf("expected a cycle error")
+	}
+}
+
+func TestPlanEpicTreatsOpenWorkUnderClosedParentsAsExternal(t *testing.T) {
+	issues := []Issue{
+		{ID: "e1", Title: "Epic", Status: StatusOpen, IssueType: "epic"},
+		{ID: "f1", Title: "Shipped", Status: StatusClosed, IssueType: "feature", Dependencies: []Dependency{dep("f1", "e1", DepParentChild)}},
+		{ID: "t", Title: "Leftover", Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep("t", "f1", DepParentChild)}},
+		{ID: "u", Title: "Follow-up", Status: StatusOpen, IssueType: "task", Dependencies: []Dependency{dep("u", "e1", DepParentChild), dep("u", "t", DepBlocks)}},
+	}
+
+	schedule, err := PlanEpic(NewGraph(issues), "e1")
+	if err != nil {
+		t.Fatalf("plan epic: %v", err)
+	}
+	if want := [][]string{{"u"}}; !reflect.DeepEqual(schedule.Waves, want) {
+		t.Fatalf("expected waves %v, got %v", want, schedule.Waves)
+	}
+	if len(schedule.Items) != 1 || len(schedule.Items[0].DependsOn) != 0 {
+		t.Fatalf("expected u without in-schedule dependencies, got %+v", schedule.Items)
+	}
+	if want := []string{"t"}; !reflect.DeepEqual(schedule.ExternalBlockers, want) {
+		t.Fatalf("expected external blockers %v, got %v", want, schedule.ExternalBlockers)
+	}
+}
@@ -0,0 +1,259 @@
+package beads
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	metadataFile    = "metadata.json"
+	defaultDatabase = "beads.db"
+)
+
+// DatabasePath returns bd's SQLite database under root, as named in
+// .beads/metadata.json, or "" when there is none.
+func DatabasePath(root string) string {
+	name := defaultDatabase
+	if data, err := os.ReadFile(filepath.Join(root, Dir, metadataFile)); err == nil {
+		var metadata struct {
+			Database string `json:"database"`
+		}
+		if json.Unmarshal(data, &metadata) == nil && metadata.Database != "" {
+			name = metadata.Database
+		}
+	}
+	path := filepath.Join(root, Dir, name)
+	if info, err := os.Stat(path); err != nil || info.IsDir() {
+		return ""
+	}
+	return path
+}
+
+// issueColumns maps bd's issues table columns to Issue fields. Columns are
+// selected only when present, so older and newer bd schemas both load.
+var issueColumns = []struct {
+	name string
+	set  func(issue *Issue, value any)
+}{
+	{"id", func(issue *Issue, value any) { issue.ID = sqlString(value) }},
+	{"title", func(issue *Issue, value any) { issue.Title = sqlString(value) }},
+	{"description", func(issue *Issue, value any) { issue.Description = sqlString(value) }},
+	{"acceptance_criteria", func(issue *Issue, value any) { issue.AcceptanceCriteria = sqlString(value) }},
+	{"status", func(issue *Issue, value any) { issue.Status = sqlString(value) }},
+	{"priority", func(issue *Issue, value any) { issue.Priority = sqlInt(value) }},
+	{"issue_type", func(issue *Issue, value any) { issue.IssueType = sqlString(value) }},
+	{"owner", func(issue *Issue, value any) { issue.Owner = sqlString(value) }},
+	{"assignee", func(issue *Issue, value any) { issue.Assignee = sqlString(value) }},
+	{"estimated_minutes", func(issue *Issue, value any) {
+		if value != nil {
+			minutes := sqlInt(value)
+			issue.Estimate = &minutes
+		}
+	}},
+	{"created_at", func(issue *Issue, value any) { issue.CreatedAt = sqlTime(value) }},
+	{"created_by", func(issue *Issue, value any) { issue.CreatedBy = sqlString(value) }},
+	{"updated_at", func(issue *Issue, value any) { issue.UpdatedAt = sqlTime(value) }},
+	{"closed_at", func(issue *Issue, value any) { issue.ClosedAt = sqlTimePtr(value) }},
+	{"close_reason", func(issue *Issue, value any) { issue.CloseReason = sqlString(value) }},
+	{"deleted_at", func(issue *Issue, value any) { issue.DeletedAt = sqlTimePtr(value) }},
+	{"deleted_by", func(issue *Issue, value any) { issue.DeletedBy = sqlString(value) }},
+	{"delete_reason", func(issue *Issue, value any) { issue.DeleteReason = sqlString(value) }},
+	{"original_type", func(issue *Issue, value any) { issue.OriginalType = sqlString(value) }},
+}
+
+// LoadDatabase reads every issue, with labels and dependencies, from bd's
+// SQLite database. The database is opened read-only and never migrated.
+func LoadDatabase(ctx context.Context, path string) ([]Issue, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?mode=ro&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, fmt.Errorf("open beads database: %w", err)
+	}
+	defer db.Close()
+
+	available, err := tableColumns(ctx, db, "issues")
+	if err != nil {
+		return nil, err
+	}
+	if !available["id"] {
+		return nil, fmt.Errorf("beads database %s has no issues table", path)
+	}
+
+	var names []string
+	var setters []func(*Issue, any)
+	for _, column := range issueColumns {
+		if available[column.name] {
+			names = append(names, column.name)
+			setters = append(setters, column.set)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT "+strings.Join(names, ", ")+" FROM issues ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("query beads issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []Issue
+	index := make(map[string]int)
+	for rows.Next() {
+		values := make([]any, len(names))
+		pointers := make([]any, len(names))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, fmt.Errorf("scan beads issue: %w", err)
+		}
+		var issue Issue
+		for i, set := range setters {
+			set(&issue, values[i])
+		}
+		index[issue.ID] = len(issues)
+		issues = append(issues, issue)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read beads issues: %w", err)
+	}
+
+	if err := loadDatabaseLabels(ctx, db, issues, index); err != nil {
+		return nil, err
+	}
+	if err := loadDatabaseDependencies(ctx, db, issues, index); err != nil {
+		return nil, err
+	}
+	return issues, nil
+}
+
+func loadDatabaseLabels(ctx context.Context, db *sql.DB, issues []Issue, index map[string]int) error {
+	columns, err := tableColumns(ctx, db, "labels")
+	if err != nil || !columns["label"] {
+		return err
+	}
+	rows, err := db.QueryContext(ctx, "SELECT issue_id, label FROM labels ORDER BY issue_id, label")
+	if err != nil {
+		return fmt.Errorf("query beads labels: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var issueID, label string
+		if err := rows.Scan(&issueID, &label); err != nil {
+			return fmt.Errorf("scan beads label: %w", err)
+		}
+		if i, ok := index[issueID]; ok {
+			issues[i].Labels = append(issues[i].Labels, label)
+		}
+	}
+	return rows.Err()
+}
+
+func loadDatabaseDependencies(ctx context.Context, db *sql.DB, issues []Issue, index map[string]int) error {
+	columns, err := tableColumns(ctx, db, "dependencies")
+	if err != nil || !columns["depends_on_id"] {
+		return err
+	}
+	query := "SELECT issue_id, depends_on_id, type, created_at, '' FROM dependencies ORDER BY issue_id, created_at"
+	if columns["created_by"] {
+		query = strings.Replace(query, "''", "created_by", 1)
+	}
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("query beads dependencies: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var dep Dependency
+		var createdAt any
+		var createdBy sql.NullString
+		if err := rows.Scan(&dep.IssueID, &dep.DependsOnID, &dep.Type, &createdAt, &createdBy); err != nil {
+			return fmt.Errorf("scan beads dependency: %w", err)
+		}
+		dep.CreatedAt = sqlTime(createdAt)
+		dep.CreatedBy = createdBy.String
+		if i, ok := index[dep.IssueID]; ok {
+			issues[i].Dependencies = append(issues[i].Dependencies, dep)
+		}
+	}
+	return rows.Err()
+}
+
+// tableColumns returns the column names of table; a missing table has none.
+func tableColumns(ctx context.Context, db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		return nil, fmt.Errorf("inspect beads %s table: %w", table, err)
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("inspect beads %s table: %w", table, err)
+		}
+		columns[name] = true
+	}
+	return columns, rows.Err()
+}
+
+func sqlString(value any) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case []byte:
+		return string(typed)
+	default:
+		return fmt.Sprint(typed)
+	}
+}
+
+func sqlInt(value any) int {
+	switch typed := value.(type) {
+	case int64:
+		return int(typed)
+	case float64:
+		return int(typed)
+	default:
+		parsed, _ := strconv.Atoi(sqlString(value))
+		return parsed
+	}
+}
+
+var sqlTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+func sqlTime(value any) time.Time {
+	if typed, ok := value.(time.Time); ok {
+		return typed
+	}
+	text := sqlString(value)
+	for _, layout := range sqlTimeLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed
+		}
+	}
+	return time.Time{}
+}
+
+func sqlTimePtr(value any) *time.Time {
+	parsed := sqlTime(value)
+	if parsed.IsZero() {
+		return nil
+	}
+	return &parsed
+}
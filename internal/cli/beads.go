@@ -0,0 +1,271 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newBeadsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "beads",
+		Short: "Inspect the beads issue graph",
+	}
+
+	cmd.AddCommand(newBeadsLintCommand())
+	cmd.AddCommand(newBeadsPlanGraphCommand())
+	cmd.AddCommand(newBeadsGraphCommand())
+
+	return cmd
+}
+
+func newBeadsLintCommand() *cobra.Command {
+	var format string
+	var failOn string
+
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Report structural problems in the beads issue graph",
+		Long: `Checks the beads issue graph for dependency cycles, closed parents with open
+children, orphan tasks, missing descriptions and oversized epics. Rule
+severities are configured under lint in camp.yml. Exits non-zero when a
+finding is at or above --fail-on.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			threshold, err := beads.ParseSeverity(failOn)
+			if err != nil {
+				return err
+			}
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q (use text or json)", format)
+			}
+
+			root, err := beads.FindRoot(mustGetwd())
+			if err != nil {
+				return err
+			}
+			issues, err := beads.NewLocal(root).List(context.Background(), beads.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			_, cfg := loadCamp()
+			opts, err := lintOptions(cfg)
+			if err != nil {
+				return err
+			}
+
+			findings := beads.Lint(beads.NewGraph(issues), opts)
+			counts := map[beads.Severity]int{}
+			failing := 0
+			for _, finding := range findings {
+				counts[finding.Severity]++
+				if finding.Severity.AtLeast(threshold) {
+					failing++
+				}
+			}
+
+			if format == "json" {
+				output := struct {
+					Findings []beads.Finding        `json:"findings"`
+					Summary  map[beads.Severity]int `json:"summary"`
+				}{Findings: findings, Summary: counts}
+				if output.Findings == nil {
+					output.Findings = []beads.Finding{}
+				}
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				if err := encoder.Encode(output); err != nil {
+					return err
+				}
+			} else {
+				for _, finding := range findings {
+					fmt.Fprintf(cmd.OutOrStdout(), "%-7s %-24s %-10s %s\n", strings.ToUpper(string(finding.Severity)), finding.Rule, finding.IssueID, finding.Message)
+				}
+				fmt.Fprintf(
+					cmd.OutOrStdout(),
+					"%d errors, %d warnings, %d info\n",
+					counts[beads.SeverityError],
+					counts[beads.SeverityWarning],
+					counts[beads.SeverityInfo],
+				)
+			}
+
+			if failing > 0 && threshold != beads.SeverityOff {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d findings at or above %s", failing, threshold)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.Flags().StringVar(&failOn, "fail-on", string(beads.SeverityError), "Exit non-zero for findings at or above this severity (off to never fail)")
+
+	return cmd
+}
+
+func newBeadsPlanGraphCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "plan-graph <epic-id>",
+		Short: "Show the critical path and parallel waves of an epic",
+		Long: `Analyses the open work under an epic through its blocks dependencies: the
+critical path, waves of work that can run concurrently and the maximum
+parallelism, i.e. how many Carnies can usefully work on the epic at once.
+Bead estimates (estimated_minutes) are used when present.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid --format %q (use text or json)", format)
+			}
+
+			root, err := beads.FindRoot(mustGetwd())
+			if err != nil {
+				return err
+			}
+			issues, err := beads.NewLocal(root).List(context.Background(), beads.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			schedule, err := beads.PlanEpic(beads.NewGraph(issues), args[0])
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				encoder := json.NewEncoder(cmd.OutOrStdout())
+				encoder.SetIndent("", "  ")
+				return encoder.Encode(schedule)
+			}
+			printSchedule(cmd, schedule)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+
+	return cmd
+}
+
+func newBeadsGraphCommand() *cobra.Command {
+	var format string
+	var depth int
+	var width int
+
+	cmd := &cobra.Command{
+		Use:   "graph <id>",
+		Short: "Draw the blocks dependencies around an issue",
+		Long: `Draws the issues that block an issue (upstream) and the issues it blocks
+(downstream), following blocks dependencies up to --depth links in each
+direction. dot and mermaid output can be pasted into docs and pull requests;
+ascii draws boxes in the terminal, blockers on the left.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := beads.FindRoot(mustGetwd())
+			if err != nil {
+				return err
+			}
+			issues, err := beads.NewLocal(root).List(context.Background(), beads.ListOptions{})
+			if err != nil {
+				return err
+			}
+
+			hood, err := beads.NewGraph(issues).Neighborhood(args[0], depth)
+			if err != nil {
+				return err
+			}
+			output, err := beads.FormatNeighborhood(hood, format, width)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "ascii", "Output format: "+strings.Join(beads.GraphFormats, ", "))
+	cmd.Flags().IntVar(&depth, "depth", beads.DefaultGraphDepth, "Blocks links to follow in each direction")
+	cmd.Flags().IntVar(&width, "width", 0, "Maximum ascii width (0 for unlimited)")
+
+	return cmd
+}
+
+func printSchedule(cmd *cobra.Command, schedule beads.Schedule) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Epic %s: %s\n", schedule.EpicID, schedule.EpicTitle)
+	if len(schedule.Items) == 0 {
+		fmt.Fprintln(out, "No open work under this epic")
+		return
+	}
+
+	weight := func(value int) string {
+		if !schedule.UsesEstimates {
+			return fmt.Sprintf("%d items", value)
+		}
+		return strings.TrimSuffix((time.Duration(value) * time.Minute).String(), "0s")
+	}
+
+	estimated := 0
+	for _, item := range schedule.Items {
+		if item.Estimated {
+			estimated++
+		}
+	}
+	fmt.Fprintf(out, "Open work items: %d", len(schedule.Items))
+	if schedule.UsesEstimates {
+		fmt.Fprintf(out, " (%d estimated, others assume %dm)", estimated, beads.DefaultEstimateMinutes)
+	}
+	fmt.Fprintf(out, ", total work %s\n", weight(schedule.TotalWork))
+
+	fmt.Fprintf(out, "\nCritical path (%s):\n", weight(schedule.CriticalLength))
+	for i, id := range schedule.CriticalPath {
+		item, _ := schedule.Item(id)
+		suffix := ""
+		if schedule.UsesEstimates {
+			suffix = " (" + weight(item.Weight) + ")"
+		}
+		fmt.Fprintf(out, "  %d. %s  %s%s\n", i+1, id, truncateASCII(item.Title, 60), suffix)
+	}
+
+	fmt.Fprintf(out, "\nWaves (max parallelism %d):\n", schedule.MaxParallelism)
+	for i, wave := range schedule.Waves {
+		fmt.Fprintf(out, "  Wave %d (%d): %s\n", i+1, len(wave), strings.Join(wave, ", "))
+	}
+
+	if len(schedule.ExternalBlockers) > 0 {
+		fmt.Fprintf(out, "\nBlocked by open issues outside the epic: %s\n", strings.Join(schedule.ExternalBlockers, ", "))
+	}
+	fmt.Fprintf(out, "\nSuggested Carnies: %d\n", schedule.MaxParallelism)
+}
+
+func lintOptions(cfg *config.CampConfig) (beads.LintOptions, error) {
+	opts := beads.LintOptions{Severities: map[string]beads.Severity{}}
+	if cfg == nil {
+		return opts, nil
+	}
+
+	known := map[string]bool{}
+	for _, rule := range beads.Rules() {
+		known[rule] = true
+	}
+	for rule, value := range cfg.Lint.Rules {
+		if !known[rule] {
+			return opts, fmt.Errorf("camp.yml lint.rules: unknown rule %q (known: %s)", rule, strings.Join(beads.Rules(), ", "))
+		}
+		severity, err := beads.ParseSeverity(value)
+		if err != nil {
+			return opts, fmt.Errorf("camp.yml lint.rules.%s: %w", rule, err)
+		}
+		opts.Severities[rule] = severity
+	}
+	opts.MaxEpicChildren = cfg.Lint.MaxEpicChildren
+	return opts, nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/workorder"
+	"github.com/spf13/cobra"
+)
+
+func newCostsCommand() *cobra.Command {
+	var since string
+	var by string
+
+	cmd := &cobra.Command{
+		Use:   "costs",
+		Short: "Report token usage and cost of agent runs",
+		Long:  "Aggregates recorded run usage from carniecamp.db, grouped by model, bead, status or work order.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			group, err := workorder.ParseUsageGroup(by)
+			if err != nil {
+				return err
+			}
+
+			var filter workorder.UsageFilter
+			if since != "" {
+				sinceTime, err := parseSince(since, time.Now())
+				if err != nil {
+					return err
+				}
+				filter.Since = &sinceTime
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			summaries, err := store.UsageReport(context.Background(), group, filter)
+			if err != nil {
+				return err
+			}
+			totals, err := store.UsageTotals(context.Background(), filter)
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintf(writer, "%s\tRuns\tInput\tOutput\tCost\n", usageGroupLabels[group])
+			for _, summary := range summaries {
+				key := summary.Key
+				if key == "" {
+					key = "(none)"
+				}
+				fmt.Fprintf(
+					writer,
+					"%s\t%d\t%s\t%s\t$%.2f\n",
+					key,
+					summary.Runs,
+					formatTokens(summary.Usage.InputTokens+summary.Usage.CacheReadTokens+summary.Usage.CacheWriteTokens),
+					formatTokens(summary.Usage.OutputTokens),
+					summary.Usage.CostUSD,
+				)
+			}
+			fmt.Fprintf(
+				writer,
+				"total\t%d\t%s\t%s\t$%.2f\n",
+				totals.Runs,
+				formatTokens(totals.Usage.InputTokens+totals.Usage.CacheReadTokens+totals.Usage.CacheWriteTokens),
+				formatTokens(totals.Usage.OutputTokens),
+				totals.Usage.CostUSD,
+			)
+			return writer.Flush()
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "Only include runs since a duration ago (e.g. 24h, 7d) or a date (YYYY-MM-DD)")
+	cmd.Flags().StringVar(&by, "by", string(workorder.UsageByModel), "Group by model, bead, status or workorder")
+
+	return cmd
+}
+
+var usageGroupLabels = map[workorder.UsageGroup]string{
+	workorder.UsageByModel:     "Model",
+	workorder.UsageByBead:      "Bead",
+	workorder.UsageByStatus:    "Status",
+	workorder.UsageByWorkOrder: "Work Order",
+}
+
+func parseSince(value string, now time.Time) (time.Time, error) {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasSuffix(trimmed, "d") {
+		days, err := strconv.Atoi(strings.TrimSuffix(trimmed, "d"))
+		if err == nil {
+			return now.AddDate(0, 0, -days), nil
+		}
+	}
+	if duration, err := time.ParseDuration(trimmed); err == nil {
+		return now.Add(-duration), nil
+	}
+	if date, err := time.ParseInLocation("2006-01-02", trimmed, time.Local); err == nil {
+		return date, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, trimmed); err == nil {
+		return parsed, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since value %q (use a duration like 24h or 7d, or a date)", value)
+}
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/spf13/cobra"
+)
+
+func newCrewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "crew",
+		Short: "Inspect the camp crew roster",
+	}
+
+	cmd.AddCommand(newCrewListCommand())
+
+	return cmd
+}
+
+func newCrewListCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List crew members and their current load",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, cfg := loadCamp()
+			if cfg == nil || len(cfg.Crew) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No crew members defined in camp.yml")
+				return nil
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			load, err := store.CrewLoad(context.Background())
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "Name\tTool\tModel\tLoad\tLabels")
+			for _, member := range cfg.Crew {
+				fmt.Fprintf(
+					writer,
+					"%s\t%s\t%s\t%s\t%s\n",
+					member.Name,
+					crewValue(member.Tool, cfg.Defaults.AgentTool, config.DefaultAgentTool),
+					crewValue(member.Model, cfg.Defaults.AgentModel, config.DefaultAgentModel),
+					formatCrewLoad(load[member.Name], member.Concurrency),
+					strings.Join(member.Labels, ","),
+				)
+			}
+			return writer.Flush()
+		},
+	}
+
+	return cmd
+}
+
+// crewValue returns the first non-empty value, mirroring how the runner falls
+// back from the crew member to camp defaults.
+func crewValue(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func formatCrewLoad(inProgress int, concurrency int) string {
+	if concurrency <= 0 {
+		return fmt.Sprintf("%d", inProgress)
+	}
+	return fmt.Sprintf("%d/%d", inProgress, concurrency)
+}
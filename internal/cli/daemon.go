@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/daemon"
+	"github.com/rikurb8/carnie/internal/workorder"
+	"github.com/spf13/cobra"
+)
+
+func newDaemonCommand() *cobra.Command {
+	var detach bool
+
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Dispatch ready work orders to agents in the background",
+		Long: `Runs the camp orchestrator: ready work orders are claimed and run by agents
+within crew capacity, stale leases are expired and failed runs are retried with
+backoff. Control a running daemon with the status, pause, resume and drain
+subcommands.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, cfg := loadCamp()
+			if cfg == nil {
+				return fmt.Errorf("no %s found; run carnie camp init first", config.CampConfigFile)
+			}
+			if pid := daemon.RunningPID(root); pid != 0 {
+				return fmt.Errorf("daemon already running (pid %d)", pid)
+			}
+
+			if detach {
+				pid, err := daemon.StartDetached(root, []string{"daemon"})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Started daemon (pid %d), logging to %s\n", pid, daemon.LogPath(root))
+				return nil
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			return daemon.New(root, cfg, store, cmd.OutOrStdout()).Run(ctx)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&detach, "detach", "d", false, "Run in the background")
+
+	cmd.AddCommand(newDaemonControlCommand(daemon.CommandStatus, "Show daemon state and active runs"))
+	cmd.AddCommand(newDaemonControlCommand(daemon.CommandPause, "Stop launching new runs"))
+	cmd.AddCommand(newDaemonControlCommand(daemon.CommandResume, "Resume launching runs"))
+	cmd.AddCommand(newDaemonControlCommand(daemon.CommandDrain, "Finish active runs, then exit"))
+
+	return cmd
+}
+
+func newDaemonControlCommand(command string, short string) *cobra.Command {
+	return &cobra.Command{
+		Use:   command,
+		Short: short,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := workorder.FindCampRoot(mustGetwd())
+			if err != nil {
+				return err
+			}
+
+			status, err := daemon.Send(root, command)
+			if errors.Is(err, daemon.ErrNotRunning) && command == daemon.CommandStatus {
+				fmt.Fprintln(cmd.OutOrStdout(), "Daemon is not running")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			printDaemonStatus(cmd, status)
+			return nil
+		},
+	}
+}
+
+func printDaemonStatus(cmd *cobra.Command, status daemon.Status) {
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Daemon: %s (pid %d, up %s)\n", status.State, status.PID, time.Since(status.StartedAt).Round(time.Second))
+
+	if len(status.Active) == 0 {
+		fmt.Fprintln(out, "No active runs")
+	} else {
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "\nWork Order\tAgent\tRunning\tTitle")
+		for _, run := range status.Active {
+			fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", run.WorkOrderID, run.Agent, time.Since(run.StartedAt).Round(time.Second), truncateASCII(run.Title, 60))
+		}
+		_ = writer.Flush()
+	}
+
+	if len(status.Retries) > 0 {
+		writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "\nRetrying\tAttempts\tNext\tLast Error")
+		for _, retry := range status.Retries {
+			fmt.Fprintf(writer, "%d\t%d\t%s\t%s\n", retry.WorkOrderID, retry.Attempts, retry.NextAttempt.Local().Format("15:04:05"), truncateASCII(retry.LastError, 60))
+		}
+		_ = writer.Flush()
+	}
+}
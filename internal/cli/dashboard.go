@@ -1,30 +1,123 @@
 package cli
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/config"
 	"github.com/rikurb8/carnie/internal/dashboard"
+	"github.com/rikurb8/carnie/internal/runner"
+	"github.com/rikurb8/carnie/internal/workorder"
 	"github.com/spf13/cobra"
 )
 
 func newDashboardCommand() *cobra.Command {
 	var refresh time.Duration
 	var limit int
+	var watch bool
+	var snapshot bool
+	var format string
+	var output string
 
 	cmd := &cobra.Command{
 		Use:   "dashboard",
 		Short: "Launch the Carnie dashboard",
+		Long: `Launches the full-screen dashboard. Changes to .beads and the work order
+database are picked up as they happen; the refresh interval remains as a
+polling fallback.
+
+With --snapshot the dashboard prints what it would show (status summary,
+open features with their tasks, blocked issues and work orders) once as
+text, markdown, html or json and exits, for CI jobs and chat posts.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			model := dashboard.NewModel(refresh, limit)
+			root, err := beads.FindRoot(mustGetwd())
+			if err != nil {
+				return fmt.Errorf("find beads: %w", err)
+			}
+
+			model := dashboard.NewModel(beads.NewLocal(root), refresh, limit)
+			campRoot, cfg := loadCamp()
+			actor, _ := agentIdentity("")
+			model = model.WithSearch(dashboardSearch(actor, campRoot, cfg))
+			if store, err := openWorkOrderStore(); err == nil {
+				defer store.Close()
+				model = model.WithWorkOrders(dashboard.WorkOrders{
+					Store: store,
+					Actor: actor,
+					Prompt: func(order workorder.WorkOrder) (string, error) {
+						return runner.RenderPrompt(campRoot, cfg, order)
+					},
+				})
+			}
+			if snapshot {
+				return writeDashboardSnapshot(cmd, model, format, output)
+			}
 			program := tea.NewProgram(model, tea.WithAltScreen())
-			_, err := program.Run()
+
+			if watch {
+				ctx, cancel := context.WithCancel(context.Background())
+				defer cancel()
+
+				dirs := []string{filepath.Join(root, beads.Dir)}
+				if dbPath, err := workorder.DefaultDBPath(root); err == nil {
+					dirs = append(dirs, filepath.Dir(dbPath))
+				}
+				// Without a watcher the dashboard still polls every --refresh.
+				_ = dashboard.Watch(ctx, program, dirs...)
+			}
+
+			_, err = program.Run()
 			return err
 		},
 	}
 
-	cmd.Flags().DurationVar(&refresh, "refresh", 6*time.Second, "Auto-refresh interval (0 to disable)")
+	cmd.Flags().DurationVar(&refresh, "refresh", 6*time.Second, "Polling interval, a fallback when watching (0 to disable)")
 	cmd.Flags().IntVar(&limit, "limit", 200, "Max issues per column (0 for unlimited)")
+	cmd.Flags().BoolVar(&watch, "watch", true, "Reload as soon as beads or work orders change")
+	cmd.Flags().BoolVar(&snapshot, "snapshot", false, "Print the dashboard once instead of opening it")
+	cmd.Flags().StringVar(&format, "format", "text", "Snapshot format: "+strings.Join(dashboard.SnapshotFormats, ", "))
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the snapshot to a file instead of stdout")
 
 	return cmd
 }
+
+// dashboardSearch hands the dashboard the camp's saved views. Views can only
+// be saved when there is a camp.yml to save them to.
+func dashboardSearch(me string, campRoot string, cfg *config.CampConfig) dashboard.Search {
+	search := dashboard.Search{Me: me}
+	if cfg == nil {
+		return search
+	}
+	for _, view := range cfg.Dashboard.Views {
+		search.Views = append(search.Views, dashboard.SavedView{Name: view.Name, Query: view.Query})
+	}
+	search.SaveView = func(view dashboard.SavedView) error {
+		return config.SaveDashboardView(filepath.Join(campRoot, config.CampConfigFile), config.SavedView{Name: view.Name, Query: view.Query})
+	}
+	return search
+}
+
+func writeDashboardSnapshot(cmd *cobra.Command, model dashboard.Model, format string, output string) error {
+	snapshot, err := model.Snapshot(context.Background(), time.Now())
+	if err != nil {
+		return err
+	}
+	rendered, err := dashboard.RenderSnapshot(snapshot, format)
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		_, err = fmt.Fprint(cmd.OutOrStdout(), rendered)
+		return err
+	}
+	if err := os.WriteFile(output, []byte(rendered), 0644); err != nil {
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+	return nil
+}
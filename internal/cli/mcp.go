@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/mcp"
+	"github.com/rikurb8/carnie/internal/session"
+	"github.com/rikurb8/carnie/internal/workorder"
+	"github.com/spf13/cobra"
+)
+
+func newMCPCommand() *cobra.Command {
+	var agent string
+	var lease time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Serve work orders and beads to agents over MCP",
+		Long: `Runs a Model Context Protocol server on stdin and stdout. Agents get tools
+to list ready work, claim work orders, change their status, add notes, read
+bead details and create follow-up beads, validated like the CLI commands.
+
+MCP clients start this command themselves; add it to a project with
+carnie mcp install.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := beads.FindRoot(mustGetwd())
+			if err != nil {
+				return fmt.Errorf("find beads: %w", err)
+			}
+			name, err := agentIdentity(agent)
+			if err != nil {
+				return err
+			}
+
+			server := &mcp.Server{Beads: beads.NewLocal(root), Agent: name, Lease: lease}
+			if store, err := openWorkOrderStore(); err == nil {
+				defer store.Close()
+				server.Store = store
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return server.Serve(ctx, cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&agent, "as", "", "Agent name (default: $CN_AGENT or $USER)")
+	cmd.Flags().DurationVar(&lease, "lease", workorder.DefaultLeaseDuration, "Default lease for claims")
+
+	cmd.AddCommand(newMCPInstallCommand())
+	return cmd
+}
+
+func newMCPInstallCommand() *cobra.Command {
+	var tools []string
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Add the carnie MCP server to the project's opencode and claude configs",
+		Long: `Writes a "carnie" server entry to opencode.json and .mcp.json in the camp
+root (or the current directory outside a camp). Other settings and servers
+in those files are kept.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := loadCamp()
+			for _, name := range tools {
+				if name != string(session.ToolClaude) && name != string(session.ToolOpencode) {
+					return fmt.Errorf("unknown tool %q (use claude or opencode)", name)
+				}
+				path, err := mcp.WriteConfig(root, session.Tool(name), []string{"carnie", "mcp"})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Added %s MCP server to %s\n", mcp.ServerName, path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&tools, "tool", []string{string(session.ToolOpencode), string(session.ToolClaude)}, "Tools to configure: opencode, claude")
+	return cmd
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/atotto/clipboard"
 	"github.com/rikurb8/carnie/internal/operator"
+	"github.com/rikurb8/carnie/internal/templates"
 	"github.com/spf13/cobra"
 )
 
@@ -58,13 +59,14 @@ func newOperatorIssueToBeadsCommand() *cobra.Command {
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			issueNumber := args[0]
+			root, _ := loadCamp()
 
 			issue, err := operator.FetchGHIssue(issueNumber)
 			if err != nil {
 				return err
 			}
 
-			planning, err := operator.BuildIssueToBeadsCommand(issue, "")
+			planning, err := operator.BuildIssueToBeadsCommand(templates.NewEngine(root), issue, "")
 			if err != nil {
 				return err
 			}
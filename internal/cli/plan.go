@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/plan"
+	"github.com/rikurb8/carnie/internal/session"
+	"github.com/spf13/cobra"
+)
+
+func newPlanCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Validate and apply structured epic plans",
+		Long:  "Work with plan files (YAML or JSON) describing an epic, its features, tasks and dependencies. See docs/PLANS.md for the format.",
+	}
+
+	cmd.AddCommand(newPlanValidateCommand())
+	cmd.AddCommand(newPlanApplyCommand())
+
+	return cmd
+}
+
+func newPlanValidateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate <plan-file>",
+		Short: "Check a plan file for errors",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loaded, err := plan.Load(args[0])
+			if err != nil {
+				return err
+			}
+			if err := loaded.Validate(); err != nil {
+				return fmt.Errorf("invalid plan:\n%w", err)
+			}
+
+			entries := loaded.Entries()
+			fmt.Fprintf(cmd.OutOrStdout(), "Plan is valid: %d beads (%s)\n", len(entries), countPlanTypes(entries))
+			return nil
+		},
+	}
+}
+
+func newPlanApplyCommand() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "apply <plan-file>",
+		Short: "Create the beads described by a plan file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			loaded, err := plan.Load(args[0])
+			if err != nil {
+				return err
+			}
+			if err := loaded.Validate(); err != nil {
+				return fmt.Errorf("invalid plan:\n%w", err)
+			}
+
+			if dryRun {
+				creator := plan.NewDryRunCreator(loaded)
+				created, err := plan.Apply(context.Background(), loaded, creator)
+				if err != nil {
+					return err
+				}
+				for _, command := range creator.Commands {
+					fmt.Fprintln(cmd.OutOrStdout(), session.FormatCommand(command[0], command[1:]))
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "\nDry run: would create %d beads\n", len(created))
+				return nil
+			}
+
+			created, err := plan.Apply(context.Background(), loaded, plan.BeadsCreator{Client: beads.NewCLI(mustGetwd())})
+			printPlanMapping(cmd, created)
+			if err != nil {
+				if len(created) > 0 {
+					return fmt.Errorf("%w (the beads listed above were created before the failure)", err)
+				}
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the bd commands without running them")
+
+	return cmd
+}
+
+func printPlanMapping(cmd *cobra.Command, created []plan.Created) {
+	if len(created) == 0 {
+		return
+	}
+	writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "Key\tID\tType\tTitle")
+	for _, item := range created {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", item.Key, item.ID, item.Type, truncateASCII(item.Title, 60))
+	}
+	_ = writer.Flush()
+}
+
+func countPlanTypes(entries []plan.Entry) string {
+	counts := map[string]int{}
+	for _, entry := range entries {
+		counts[entry.Type]++
+	}
+	parts := make([]string, 0, 3)
+	for _, kind := range []string{"epic", "feature", "task"} {
+		switch counts[kind] {
+		case 0:
+		case 1:
+			parts = append(parts, fmt.Sprintf("1 %s", kind))
+		default:
+			parts = append(parts, fmt.Sprintf("%d %ss", counts[kind], kind))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
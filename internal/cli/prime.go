@@ -1,39 +1,171 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"strings"
+	"text/tabwriter"
 
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/config"
 	"github.com/rikurb8/carnie/internal/prime"
+	"github.com/rikurb8/carnie/internal/templates"
 	"github.com/spf13/cobra"
 )
 
 func newPrimeCommand() *cobra.Command {
+	var live bool
+	var sections []string
+	var skip []string
+	var budgets map[string]int
+	var readyLimit int
+	var eventLimit int
+	var workOrderID int64
+	var agent string
+	var list bool
+
 	cmd := &cobra.Command{
 		Use:   "prime <role>",
 		Short: "Print role-specific workflow context",
-		Long:  "Outputs role-specific context in markdown format for AI agent workflow recovery.",
-		Args:  cobra.ExactArgs(1),
+		Long: `Outputs role-specific context in markdown format for AI agent workflow recovery.
+
+After the role's workflow the live state of the project is appended: the camp,
+your current work order with its bead and parent, ready beads, recent work
+order events and the git worktree. Pick sections with --sections or --skip,
+cap their size with --budget, or leave them out with --live=false.
+
+Besides the built-in operator and carnie roles, a camp can add its own in
+.carnie/roles/<name>.md or under roles in camp.yml; --list shows them all.`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			roleName := args[0]
+			root, cfg := loadCamp()
+			roles, err := prime.LoadRoles(root, cfg)
+			if err != nil {
+				return err
+			}
+			if list {
+				printPrimeRoles(cmd.OutOrStdout(), roles)
+				return nil
+			}
+			if len(args) == 0 {
+				return fmt.Errorf("role required, valid roles: %s (see --list)", strings.Join(prime.RoleNames(roles), ", "))
+			}
 
-			if !prime.IsValidRole(roleName) {
-				validRoles := make([]string, len(prime.ValidRoles()))
-				for i, r := range prime.ValidRoles() {
-					validRoles[i] = string(r)
-				}
-				return fmt.Errorf("invalid role %q, valid roles: %s", roleName, strings.Join(validRoles, ", "))
+			roleName := args[0]
+			role, ok := prime.FindRole(roles, roleName)
+			if !ok {
+				return fmt.Errorf("invalid role %q, valid roles: %s", roleName, strings.Join(prime.RoleNames(roles), ", "))
 			}
 
-			content, err := prime.LoadPrompt(prime.Role(roleName))
+			name, _ := agentIdentity(agent)
+			content, err := prime.RenderRole(templates.NewEngine(root), role, prime.NewRoleData(role.Name, root, cfg, name))
 			if err != nil {
 				return err
 			}
+			if live {
+				opts, err := primeLiveOptions(sections, skip, budgets)
+				if err != nil {
+					return err
+				}
+				opts.ReadyLimit = readyLimit
+				opts.EventLimit = eventLimit
+				content += renderPrimeLive(root, cfg, name, workOrderID, opts)
+			}
 
 			fmt.Fprint(cmd.OutOrStdout(), content)
 			return nil
 		},
 	}
 
+	cmd.Flags().BoolVar(&list, "list", false, "List available roles and where they come from")
+	cmd.Flags().BoolVar(&live, "live", true, "Append live project state")
+	cmd.Flags().StringSliceVar(&sections, "sections", nil, "Live sections to include (default all): "+primeSectionNames())
+	cmd.Flags().StringSliceVar(&skip, "skip", nil, "Live sections to leave out")
+	cmd.Flags().StringToIntVar(&budgets, "budget", nil, "Max bytes per section, e.g. work=4000,ready=500 (0 for no cap)")
+	cmd.Flags().IntVar(&readyLimit, "ready-limit", 10, "Ready beads to list")
+	cmd.Flags().IntVar(&eventLimit, "events-limit", 10, "Recent events to list")
+	cmd.Flags().Int64Var(&workOrderID, "workorder", 0, "Work order to show (default: the one you have claimed)")
+	cmd.Flags().StringVar(&agent, "as", "", "Agent name (default: $CN_AGENT or $USER)")
+
 	return cmd
 }
+
+func printPrimeRoles(w io.Writer, roles []prime.RoleInfo) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "Role\tSource\tInherits\tDescription")
+	for _, role := range roles {
+		inherits := string(role.Inherits)
+		if inherits == "" {
+			inherits = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", role.Name, role.Source, inherits, role.Description)
+	}
+	tw.Flush()
+}
+
+func primeLiveOptions(sections []string, skip []string, budgets map[string]int) (prime.LiveOptions, error) {
+	opts := prime.LiveOptions{Budgets: map[prime.Section]int{}}
+	if len(sections) > 0 {
+		opts.Sections = []prime.Section{}
+	}
+	for _, name := range sections {
+		section, err := prime.ParseSection(name)
+		if err != nil {
+			return opts, err
+		}
+		opts.Sections = append(opts.Sections, section)
+	}
+	if len(skip) > 0 {
+		selected := opts.Sections
+		if selected == nil {
+			selected = prime.Sections
+		}
+		skipped := map[prime.Section]bool{}
+		for _, name := range skip {
+			section, err := prime.ParseSection(name)
+			if err != nil {
+				return opts, err
+			}
+			skipped[section] = true
+		}
+		opts.Sections = []prime.Section{}
+		for _, section := range selected {
+			if !skipped[section] {
+				opts.Sections = append(opts.Sections, section)
+			}
+		}
+	}
+	for name, budget := range budgets {
+		section, err := prime.ParseSection(name)
+		if err != nil {
+			return opts, err
+		}
+		opts.Budgets[section] = budget
+	}
+	return opts, nil
+}
+
+// renderPrimeLive gathers whatever live sources exist here. Outside a camp
+// or a beads project the sections that need them say so instead.
+func renderPrimeLive(root string, cfg *config.CampConfig, agent string, workOrderID int64, opts prime.LiveOptions) string {
+	src := prime.LiveSources{Root: root, Camp: cfg, Agent: agent, WorkOrderID: workOrderID}
+	if beadsRoot, err := beads.FindRoot(root); err == nil {
+		src.Beads = beads.NewLocal(beadsRoot)
+	}
+	if cfg != nil {
+		if store, err := openWorkOrderStore(); err == nil {
+			defer store.Close()
+			src.Store = store
+		}
+	}
+	return prime.RenderLive(context.Background(), src, opts)
+}
+
+func primeSectionNames() string {
+	names := make([]string, len(prime.Sections))
+	for i, section := range prime.Sections {
+		names[i] = string(section)
+	}
+	return strings.Join(names, ", ")
+}
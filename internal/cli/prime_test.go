@@ -2,11 +2,17 @@ package cli
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
 
 func TestPrimeOperatorRole(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
 	root := NewRootCommand()
 	output := &bytes.Buffer{}
 	root.SetOut(output)
@@ -23,6 +29,10 @@ func TestPrimeOperatorRole(t *testing.T) {
 }
 
 func TestPrimeCarnieRole(t *testing.T) {
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(t.TempDir())
+
 	root := NewRootCommand()
 	output := &bytes.Buffer{}
 	root.SetOut(output)
@@ -70,3 +80,88 @@ func TestPrimeNoArgs(t *testing.T) {
 		t.Fatal("expected error when no role provided")
 	}
 }
+
+func TestPrimeLiveSections(t *testing.T) {
+	dir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(dir)
+
+	root := NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetArgs([]string{"camp", "init", "--name", "live-camp"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("camp init: %v", err)
+	}
+
+	root = NewRootCommand()
+	output := &bytes.Buffer{}
+	root.SetOut(output)
+	root.SetArgs([]string{"prime", "carnie", "--as", "bot", "--sections", "camp,work,worktree", "--skip", "worktree"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"# Live Context", "- Name: live-camp", "- You: bot", "## Current Work Order"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("expected output to contain %q", want)
+		}
+	}
+	for _, unwanted := range []string{"## Ready Beads", "## Worktree"} {
+		if strings.Contains(output.String(), unwanted) {
+			t.Errorf("expected %q to be left out", unwanted)
+		}
+	}
+
+	root = NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs([]string{"prime", "carnie", "--budget", "nope=10"})
+	if err := root.Execute(); err == nil || !strings.Contains(err.Error(), "unknown section") {
+		t.Fatalf("expected an unknown section error, got %v", err)
+	}
+}
+
+func TestPrimeCustomRoles(t *testing.T) {
+	dir := t.TempDir()
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(dir)
+
+	root := NewRootCommand()
+	root.SetOut(&bytes.Buffer{})
+	root.SetArgs([]string{"camp", "init", "--name", "role-camp"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("camp init: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".carnie", "roles"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	reviewer := "---\ninherits: carnie\ndescription: Reviews finished work\n---\n## Reviewer\n\nReview {{.CampName}} as {{.Agent}}.\n"
+	if err := os.WriteFile(filepath.Join(dir, ".carnie", "roles", "reviewer.md"), []byte(reviewer), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	root = NewRootCommand()
+	output := &bytes.Buffer{}
+	root.SetOut(output)
+	root.SetArgs([]string{"prime", "--list"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("prime --list: %v", err)
+	}
+	for _, want := range []string{"operator", "built-in", "reviewer", "camp", "Reviews finished work"} {
+		if !strings.Contains(output.String(), want) {
+			t.Errorf("expected --list output to contain %q:\n%s", want, output.String())
+		}
+	}
+
+	root = NewRootCommand()
+	output = &bytes.Buffer{}
+	root.SetOut(output)
+	root.SetArgs([]string{"prime", "reviewer", "--as", "bot", "--live=false"})
+	if err := root.Execute(); err != nil {
+		t.Fatalf("prime reviewer: %v", err)
+	}
+	if !strings.Contains(output.String(), "Carnie") || !strings.Contains(output.String(), "Review role-camp as bot.") {
+		t.Errorf("expected the carnie workflow followed by the rendered reviewer role:\n%s", output.String())
+	}
+}
@@ -23,9 +23,17 @@ func NewRootCommand() *cobra.Command {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.AddCommand(newDashboardCommand())
+	rootCmd.AddCommand(newBeadsCommand())
 	rootCmd.AddCommand(newCampCommand())
+	rootCmd.AddCommand(newCostsCommand())
+	rootCmd.AddCommand(newCrewCommand())
+	rootCmd.AddCommand(newDaemonCommand())
+	rootCmd.AddCommand(newMCPCommand())
 	rootCmd.AddCommand(newOperatorCommand())
+	rootCmd.AddCommand(newPlanCommand())
 	rootCmd.AddCommand(newPrimeCommand())
+	rootCmd.AddCommand(newServeCommand())
+	rootCmd.AddCommand(newTemplatesCommand())
 	rootCmd.AddCommand(newWorkOrderCommand())
 
 	return rootCmd
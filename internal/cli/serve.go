@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/runner"
+	"github.com/rikurb8/carnie/internal/server"
+	"github.com/rikurb8/carnie/internal/workorder"
+	"github.com/spf13/cobra"
+)
+
+func newServeCommand() *cobra.Command {
+	var addr string
+	var token string
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a web UI and JSON API over beads and work orders",
+		Long: `Serves a board and detail pages in the browser, a JSON API under /api and
+a Server-Sent Events stream at /api/events that fires whenever beads or work
+orders change. Reads are open; status changes need the token, sent as the
+X-Carnie-Token header or as a bearer token. The printed URL carries the
+token so the browser UI can make changes.
+
+The server listens on loopback by default. Pass --token to keep the same
+token across restarts; otherwise a new one is generated each time.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, err := beads.FindRoot(mustGetwd())
+			if err != nil {
+				return fmt.Errorf("find beads: %w", err)
+			}
+			if token == "" {
+				if token, err = server.NewToken(); err != nil {
+					return err
+				}
+			}
+
+			srv := server.New(beads.NewLocal(root), nil, token)
+			srv.Actor, _ = agentIdentity("")
+			if store, err := openWorkOrderStore(); err == nil {
+				defer store.Close()
+				srv.Store = store
+				campRoot, cfg := loadCamp()
+				srv.Prompt = func(order workorder.WorkOrder) (string, error) {
+					return runner.RenderPrompt(campRoot, cfg, order)
+				}
+			}
+
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return fmt.Errorf("listen: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Serving on http://%s/?token=%s\n", listener.Addr(), token)
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			httpServer := &http.Server{
+				Handler:           srv.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+				// Event streams hold requests open until the client leaves.
+				BaseContext: func(net.Listener) context.Context { return ctx },
+			}
+			go func() {
+				<-ctx.Done()
+				shutdown, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = httpServer.Shutdown(shutdown)
+			}()
+			if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&addr, "addr", server.DefaultAddr, "Address to listen on")
+	cmd.Flags().StringVar(&token, "token", "", "Token guarding changes (default: generated)")
+
+	return cmd
+}
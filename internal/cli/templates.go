@@ -0,0 +1,293 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/rikurb8/carnie/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+func newTemplatesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "templates",
+		Short: "Inspect and override prompt templates",
+		Long: `Prompt templates are looked up in the camp's .carnie/templates first, then
+in the user config directory (usually ~/.config/carnie/templates), then in the
+templates built into carnie.`,
+	}
+
+	cmd.AddCommand(newTemplatesListCommand())
+	cmd.AddCommand(newTemplatesEjectCommand())
+	cmd.AddCommand(newTemplatesDiffCommand())
+	cmd.AddCommand(newTemplatesRenderCommand())
+
+	return cmd
+}
+
+func newTemplatesListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List templates, where each is loaded from and whether overrides are valid",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := loadCamp()
+			loader := templates.NewLoader(root)
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "Name\tSource\tState\tPath")
+			var problems []string
+			for _, name := range templates.Names() {
+				layers, err := loader.Layers(name)
+				if err != nil {
+					return err
+				}
+				tmpl := layers[0]
+				state := "default"
+				path := tmpl.Path
+				builtIn, _ := templates.Load(name)
+				if tmpl.Source == templates.SourceBuiltIn {
+					path = "-"
+				} else if err := templates.ValidateTemplate(tmpl); err != nil {
+					state = "invalid"
+					problems = append(problems, fmt.Sprintf("%s: %v", tmpl.Path, err))
+				} else if base, ok := templates.Base(tmpl); ok && base != builtIn {
+					state = "outdated"
+				} else if builtIn == tmpl.Content {
+					state = "unchanged"
+				} else {
+					state = "customized"
+				}
+				fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", name, tmpl.Source, state, path)
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			if len(problems) > 0 {
+				fmt.Fprintln(cmd.OutOrStdout())
+				for _, problem := range problems {
+					fmt.Fprintln(cmd.OutOrStdout(), problem)
+				}
+				return fmt.Errorf("%d template override(s) failed validation", len(problems))
+			}
+			return nil
+		},
+	}
+}
+
+func newTemplatesEjectCommand() *cobra.Command {
+	var user bool
+	var force bool
+	var all bool
+
+	cmd := &cobra.Command{
+		Use:   "eject [name...]",
+		Short: "Copy built-in templates into the camp (or user config) for editing",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names := args
+			if all {
+				names = templates.Names()
+			}
+			if len(names) == 0 {
+				return errors.New("name a template to eject or pass --all; see carnie templates list")
+			}
+
+			dir, err := templatesEjectDir(user)
+			if err != nil {
+				return err
+			}
+			for _, name := range names {
+				path, err := templates.Eject(dir, name, force)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "Ejected %s to %s\n", name, path)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&user, "user", false, "Eject to the user config directory instead of the camp")
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite existing overrides")
+	cmd.Flags().BoolVar(&all, "all", false, "Eject every template")
+
+	return cmd
+}
+
+func newTemplatesDiffCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff [name...]",
+		Short: "Show how overrides differ from the built-in templates",
+		Long: `Prints a unified diff from each built-in template to the override that
+replaces it. Overrides made with carnie templates eject remember the built-in
+they were copied from; when carnie has since been upgraded and that built-in
+changed, the upstream changes are shown first and the override is diffed
+against the version it was ejected from.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := loadCamp()
+			loader := templates.NewLoader(root)
+			names := args
+			if len(names) == 0 {
+				names = templates.Names()
+			}
+
+			overrides := 0
+			for _, name := range names {
+				layers, err := loader.Layers(name)
+				if err != nil {
+					return err
+				}
+				tmpl := layers[0]
+				if tmpl.Source == templates.SourceBuiltIn {
+					continue
+				}
+				overrides++
+				if err := writeTemplateDiff(cmd.OutOrStdout(), tmpl); err != nil {
+					return err
+				}
+			}
+			if overrides == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No template overrides")
+			}
+			return nil
+		},
+	}
+}
+
+func newTemplatesRenderCommand() *cobra.Command {
+	var dataFile string
+	var like string
+
+	cmd := &cobra.Command{
+		Use:   "render <name|file>",
+		Short: "Render a template with data from a JSON file",
+		Long: `Renders a template the way carnie would, without running an agent. <name> is
+a template from carnie templates list (the active override is used) or the
+path of a template file. The JSON in --data is decoded into the struct the
+template normally gets, so unknown fields are reported; a file that isn't
+named after a template gets the JSON as-is unless --like names one.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			root, _ := loadCamp()
+			engine := templates.NewEngine(root)
+
+			name := args[0]
+			var tmpl templates.Template
+			if _, err := templates.Load(name); err == nil {
+				if tmpl, err = engine.Loader.Resolve(name); err != nil {
+					return err
+				}
+			} else {
+				content, err := os.ReadFile(name)
+				if err != nil {
+					return fmt.Errorf("%s is neither a template nor a readable file: %w", name, err)
+				}
+				tmpl = templates.Template{Name: filepath.Base(name), Path: name, Content: string(content)}
+			}
+			if like == "" {
+				like = tmpl.Name
+			}
+
+			var data any = &map[string]any{}
+			if newData, ok := templates.NewData(like); ok {
+				data = newData
+			} else if cmd.Flags().Changed("like") {
+				return fmt.Errorf("unknown template %q for --like", like)
+			}
+			if dataFile != "" {
+				if err := decodeTemplateData(cmd.InOrStdin(), dataFile, data); err != nil {
+					return err
+				}
+			}
+
+			label := tmpl.Name
+			if tmpl.Path != "" {
+				label = tmpl.Path
+			}
+			out, err := engine.RenderString(label, tmpl.Content, data)
+			if err != nil {
+				return err
+			}
+			fmt.Fprint(cmd.OutOrStdout(), out)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dataFile, "data", "", "JSON file with the template data (- for stdin)")
+	cmd.Flags().StringVar(&like, "like", "", "Render a file with the data struct of this template")
+
+	return cmd
+}
+
+func decodeTemplateData(stdin io.Reader, path string, data any) error {
+	r := stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("open data: %w", err)
+		}
+		defer file.Close()
+		r = file
+	}
+	decoder := json.NewDecoder(r)
+	if _, ok := data.(*map[string]any); !ok {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(data); err != nil {
+		return fmt.Errorf("decode %s: %w", path, err)
+	}
+	return nil
+}
+
+func templatesEjectDir(user bool) (string, error) {
+	if user {
+		return templates.UserDir()
+	}
+	root, cfg := loadCamp()
+	if cfg == nil {
+		return "", errors.New("no camp found; run carnie camp init first or pass --user")
+	}
+	return filepath.Join(root, templates.OverrideDir), nil
+}
+
+func writeTemplateDiff(w io.Writer, tmpl templates.Template) error {
+	builtIn, err := templates.Load(tmpl.Name)
+	if err != nil {
+		return err
+	}
+	from, fromFile := builtIn, "built-in/"+tmpl.Name
+	if base, ok := templates.Base(tmpl); ok && base != builtIn {
+		fmt.Fprintf(w, "%s: the built-in changed since it was ejected\n", tmpl.Name)
+		if err := writeUnifiedDiff(w, tmpl.Name, base, "ejected/"+tmpl.Name, builtIn, "built-in/"+tmpl.Name); err != nil {
+			return err
+		}
+		from, fromFile = base, "ejected/"+tmpl.Name
+	}
+	if from == tmpl.Content {
+		fmt.Fprintf(w, "%s: same as %s (%s)\n", tmpl.Name, strings.TrimSuffix(fromFile, "/"+tmpl.Name), tmpl.Path)
+		return nil
+	}
+	return writeUnifiedDiff(w, tmpl.Name, from, fromFile, tmpl.Content, tmpl.Path)
+}
+
+func writeUnifiedDiff(w io.Writer, name string, a string, fromFile string, b string, toFile string) error {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+	if err != nil {
+		return fmt.Errorf("diff %s: %w", name, err)
+	}
+	fmt.Fprint(w, diff)
+	return nil
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rikurb8/carnie/internal/templates"
+)
+
+func TestBuiltInTemplatesValidate(t *testing.T) {
+	for _, name := range templates.Names() {
+		content, err := templates.Load(name)
+		if err != nil {
+			t.Fatalf("load %s: %v", name, err)
+		}
+		if _, ok := templates.NewData(name); !ok {
+			t.Errorf("no data struct registered for %s", name)
+			continue
+		}
+		if err := templates.ValidateTemplate(templates.Template{Name: name, Content: content}); err != nil {
+			t.Errorf("built-in %s does not validate: %v", name, err)
+		}
+	}
+}
+
+func TestTemplatesEjectListDiff(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(dir)
+
+	run := func(args ...string) (string, error) {
+		root := NewRootCommand()
+		output := &bytes.Buffer{}
+		root.SetOut(output)
+		root.SetErr(output)
+		root.SetArgs(args)
+		err := root.Execute()
+		return output.String(), err
+	}
+
+	if _, err := run("templates", "eject", "workorder.md.tmpl"); err == nil || !strings.Contains(err.Error(), "no camp found") {
+		t.Fatalf("expected eject outside a camp to fail, got %v", err)
+	}
+	if _, err := run("camp", "init", "--name", "tmpl-camp"); err != nil {
+		t.Fatalf("camp init: %v", err)
+	}
+	if _, err := run("templates", "eject", "workorder.md.tmpl"); err != nil {
+		t.Fatalf("eject: %v", err)
+	}
+	if _, err := run("templates", "eject", "workorder.md.tmpl"); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("expected eject to refuse to overwrite, got %v", err)
+	}
+
+	out, err := run("templates", "list")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if !strings.Contains(out, "workorder.md.tmpl") || !strings.Contains(out, "unchanged") {
+		t.Fatalf("expected the ejected template to be listed as unchanged:\n%s", out)
+	}
+
+	path := filepath.Join(dir, templates.OverrideDir, "workorder.md.tmpl")
+	if err := os.WriteFile(path, []byte("# {{.WorkOrder.Title}}\n{{.RolePrompt}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = run("templates", "diff")
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if !strings.Contains(out, "--- built-in/workorder.md.tmpl") || !strings.Contains(out, "+# {{.WorkOrder.Title}}") {
+		t.Fatalf("expected a unified diff:\n%s", out)
+	}
+
+	base := filepath.Join(dir, templates.OverrideDir, templates.BaseDir, "workorder.md.tmpl")
+	if err := os.WriteFile(base, []byte("# Old {{.WorkOrder.Title}}\n{{.RolePrompt}}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = run("templates", "diff")
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if !strings.Contains(out, "changed since it was ejected") || !strings.Contains(out, "--- ejected/workorder.md.tmpl") || !strings.Contains(out, "+++ built-in/workorder.md.tmpl") {
+		t.Fatalf("expected the upstream changes since eject:\n%s", out)
+	}
+	if out, err = run("templates", "list"); err != nil || !strings.Contains(out, "outdated") {
+		t.Fatalf("expected the override to be listed as outdated, got %v:\n%s", err, out)
+	}
+
+	if err := os.WriteFile(path, []byte("{{if .CrewPrompt}}{{.WorkOrder.Titel}}{{end}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = run("templates", "list")
+	if err == nil || !strings.Contains(out, "invalid") || !strings.Contains(out, "Titel") {
+		t.Fatalf("expected the broken override to fail validation, got %v:\n%s", err, out)
+	}
+	if _, err := run("templates", "render", "workorder.md.tmpl"); err == nil || !strings.Contains(err.Error(), "Titel") {
+		t.Fatalf("expected the broken override to be refused when resolved, got %v", err)
+	}
+}
+
+func TestTemplatesRender(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	originalDir, _ := os.Getwd()
+	defer os.Chdir(originalDir)
+	os.Chdir(dir)
+
+	run := func(args ...string) (string, error) {
+		root := NewRootCommand()
+		output := &bytes.Buffer{}
+		root.SetOut(output)
+		root.SetErr(output)
+		root.SetArgs(args)
+		err := root.Execute()
+		return output.String(), err
+	}
+
+	data := filepath.Join(dir, "order.json")
+	if err := os.WriteFile(data, []byte(`{"RolePrompt": "Be careful.", "WorkOrder": {"id": 9, "title": "Fix login", "status": "ready"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err := run("templates", "render", "workorder.md.tmpl", "--data", data)
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	if !strings.Contains(out, "# Work Order 9: Fix login") || !strings.Contains(out, "Be careful.") {
+		t.Fatalf("unexpected render:\n%s", out)
+	}
+
+	if err := os.WriteFile(data, []byte(`{"WorkOrdr": {}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("templates", "render", "workorder.md.tmpl", "--data", data); err == nil || !strings.Contains(err.Error(), "WorkOrdr") {
+		t.Fatalf("expected an unknown field error, got %v", err)
+	}
+
+	custom := filepath.Join(dir, "custom.md.tmpl")
+	if err := os.WriteFile(custom, []byte(`{{.name | truncate 4}} {{.nope}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(data, []byte(`{"name": "carnival"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run("templates", "render", custom, "--data", data); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Fatalf("expected a missing key error, got %v", err)
+	}
+	if err := os.WriteFile(custom, []byte(`{{.name | truncate 4}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out, err = run("templates", "render", custom, "--data", data)
+	if err != nil || out != "car…" {
+		t.Fatalf("expected car…, got %q (%v)", out, err)
+	}
+}
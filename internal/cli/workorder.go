@@ -10,10 +10,13 @@ import (
 	"time"
 
 	"github.com/atotto/clipboard"
+	"github.com/rikurb8/carnie/internal/beads"
 	"github.com/rikurb8/carnie/internal/config"
-	"github.com/rikurb8/carnie/internal/prime"
+	"github.com/rikurb8/carnie/internal/runner"
+	"github.com/rikurb8/carnie/internal/session"
 	"github.com/rikurb8/carnie/internal/workorder"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func newWorkOrderCommand() *cobra.Command {
@@ -27,7 +30,14 @@ func newWorkOrderCommand() *cobra.Command {
 	cmd.AddCommand(newWorkOrderListCommand())
 	cmd.AddCommand(newWorkOrderShowCommand())
 	cmd.AddCommand(newWorkOrderUpdateCommand())
+	cmd.AddCommand(newWorkOrderAssignCommand())
+	cmd.AddCommand(newWorkOrderClaimCommand())
+	cmd.AddCommand(newWorkOrderReleaseCommand())
+	cmd.AddCommand(newWorkOrderHeartbeatCommand())
 	cmd.AddCommand(newWorkOrderPromptCommand())
+	cmd.AddCommand(newWorkOrderRunCommand())
+	cmd.AddCommand(newWorkOrderResumeCommand())
+	cmd.AddCommand(newWorkOrderStatsCommand())
 
 	return cmd
 }
@@ -37,6 +47,7 @@ func newWorkOrderCreateCommand() *cobra.Command {
 	var description string
 	var beadID string
 	var status string
+	var crew string
 
 	cmd := &cobra.Command{
 		Use:   "create",
@@ -57,6 +68,9 @@ func newWorkOrderCreateCommand() *cobra.Command {
 				}
 				statusValue = parsed
 			}
+			if err := validateCrewMember(crew); err != nil {
+				return err
+			}
 
 			store, err := openWorkOrderStore()
 			if err != nil {
@@ -69,6 +83,7 @@ func newWorkOrderCreateCommand() *cobra.Command {
 				Description: description,
 				BeadID:      beadID,
 				Status:      statusValue,
+				Crew:        crew,
 			})
 			if err != nil {
 				return err
@@ -83,6 +98,7 @@ func newWorkOrderCreateCommand() *cobra.Command {
 	cmd.Flags().StringVar(&description, "description", "", "Work order description")
 	cmd.Flags().StringVar(&beadID, "bead", "", "Associated bead ID")
 	cmd.Flags().StringVar(&status, "status", "", "Initial status (default: ready)")
+	cmd.Flags().StringVar(&crew, "crew", "", "Assign to a crew member from camp.yml")
 
 	return cmd
 }
@@ -90,6 +106,9 @@ func newWorkOrderCreateCommand() *cobra.Command {
 func newWorkOrderListCommand() *cobra.Command {
 	var status string
 	var beadID string
+	var crew string
+	var assignee string
+	var mine bool
 	var limit int
 
 	cmd := &cobra.Command{
@@ -102,6 +121,15 @@ func newWorkOrderListCommand() *cobra.Command {
 			}
 			defer store.Close()
 
+			if mine {
+				if assignee, err = agentIdentity(""); err != nil {
+					return err
+				}
+			}
+			if err := expireLeases(cmd, store); err != nil {
+				return err
+			}
+
 			var statusFilter *workorder.Status
 			if status != "" {
 				parsed, err := workorder.ParseStatus(status)
@@ -112,18 +140,20 @@ func newWorkOrderListCommand() *cobra.Command {
 			}
 
 			orders, err := store.List(context.Background(), workorder.ListOptions{
-				Status: statusFilter,
-				BeadID: beadID,
-				Limit:  limit,
+				Status:   statusFilter,
+				BeadID:   beadID,
+				Crew:     crew,
+				Assignee: assignee,
+				Limit:    limit,
 			})
 			if err != nil {
 				return err
 			}
 
-			beadIndex, _ := workorder.LoadBeadIndex(mustGetwd())
+			beadIndex := loadBeadIndex(mustGetwd())
 
 			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
-			fmt.Fprintln(writer, "ID\tStatus\tBead\tBead Description\tTitle\tUpdated")
+			fmt.Fprintln(writer, "ID\tStatus\tCrew\tAssignee\tBead\tBead Description\tTitle\tUpdated")
 			for _, order := range orders {
 				beadDesc := ""
 				if info, ok := beadIndex[order.BeadID]; ok {
@@ -134,9 +164,11 @@ func newWorkOrderListCommand() *cobra.Command {
 				}
 				fmt.Fprintf(
 					writer,
-					"%d\t%s\t%s\t%s\t%s\t%s\n",
+					"%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 					order.ID,
 					order.Status,
+					order.Crew,
+					order.Assignee,
 					order.BeadID,
 					truncateASCII(beadDesc, 50),
 					truncateASCII(order.Title, 60),
@@ -149,6 +181,9 @@ func newWorkOrderListCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&status, "status", "", "Filter by status")
 	cmd.Flags().StringVar(&beadID, "bead", "", "Filter by bead ID")
+	cmd.Flags().StringVar(&crew, "crew", "", "Filter by crew member")
+	cmd.Flags().StringVar(&assignee, "assignee", "", "Filter by assignee")
+	cmd.Flags().BoolVar(&mine, "mine", false, "Only show work orders claimed by this agent")
 	cmd.Flags().IntVar(&limit, "limit", 200, "Limit number of work orders")
 
 	return cmd
@@ -176,12 +211,21 @@ func newWorkOrderShowCommand() *cobra.Command {
 				return err
 			}
 
-			beadIndex, _ := workorder.LoadBeadIndex(mustGetwd())
+			beadIndex := loadBeadIndex(mustGetwd())
 			beadInfo := beadIndex[order.BeadID]
 
 			fmt.Fprintf(cmd.OutOrStdout(), "ID: %d\n", order.ID)
 			fmt.Fprintf(cmd.OutOrStdout(), "Title: %s\n", order.Title)
 			fmt.Fprintf(cmd.OutOrStdout(), "Status: %s\n", order.Status)
+			if order.Crew != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Crew: %s\n", order.Crew)
+			}
+			if order.Assignee != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Assignee: %s\n", order.Assignee)
+			}
+			if order.LeaseUntil != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "Lease Until: %s\n", order.LeaseUntil.Format(time.RFC3339))
+			}
 			if order.BeadID != "" {
 				fmt.Fprintf(cmd.OutOrStdout(), "Bead: %s\n", order.BeadID)
 			}
@@ -200,6 +244,33 @@ func newWorkOrderShowCommand() *cobra.Command {
 				fmt.Fprintf(cmd.OutOrStdout(), "Completed: %s\n", order.CompletedAt.Format(time.RFC3339))
 			}
 			fmt.Fprintf(cmd.OutOrStdout(), "\nDescription:\n%s\n", order.Description)
+
+			orderRuns, err := store.ListRuns(context.Background(), order.ID)
+			if err != nil {
+				return err
+			}
+			if len(orderRuns) > 0 {
+				totals, err := store.UsageTotals(context.Background(), workorder.UsageFilter{WorkOrderID: order.ID})
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "\nUsage: %s across %d runs\n", formatUsage(totals.Usage), totals.Runs)
+				fmt.Fprintf(cmd.OutOrStdout(), "\nRuns:\n")
+				for _, run := range orderRuns {
+					fmt.Fprintf(cmd.OutOrStdout(), "- #%d %s %s\n", run.ID, run.StartedAt.Format(time.RFC3339), formatRunSummary(run))
+				}
+			}
+
+			orderEvents, err := store.ListEvents(context.Background(), order.ID)
+			if err != nil {
+				return err
+			}
+			if len(orderEvents) > 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "\nEvents:\n")
+				for _, event := range orderEvents {
+					fmt.Fprintf(cmd.OutOrStdout(), "- %s %s\n", event.CreatedAt.Format(time.RFC3339), formatEvent(event))
+				}
+			}
 			return nil
 		},
 	}
@@ -247,16 +318,71 @@ func newWorkOrderUpdateCommand() *cobra.Command {
 	return cmd
 }
 
-func newWorkOrderPromptCommand() *cobra.Command {
+func newWorkOrderAssignCommand() *cobra.Command {
+	var clear bool
+
 	cmd := &cobra.Command{
-		Use:   "prompt <id>",
-		Short: "Render a work order prompt",
+		Use:   "assign <id> [member]",
+		Short: "Assign a work order to a crew member",
+		Args:  cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid work order id %q", args[0])
+			}
+
+			member := ""
+			if len(args) == 2 {
+				member = args[1]
+			}
+			if member == "" && !clear {
+				return fmt.Errorf("crew member is required (or use --clear)")
+			}
+			if err := validateCrewMember(member); err != nil {
+				return err
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			order, err := store.AssignCrew(context.Background(), id, member)
+			if err != nil {
+				return err
+			}
+			if order.Crew == "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Unassigned work order %d\n", order.ID)
+				return nil
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Assigned work order %d to %s\n", order.ID, order.Crew)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&clear, "clear", false, "Remove the crew assignment")
+	return cmd
+}
+
+func newWorkOrderClaimCommand() *cobra.Command {
+	var agent string
+	var lease time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "claim <id>",
+		Short: "Claim a work order for an agent",
+		Long:  "Claims a ready or in-progress work order and starts a lease. Keep it alive with heartbeat; an expired lease returns the order to ready.",
 		Args:  cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			id, err := strconv.ParseInt(args[0], 10, 64)
 			if err != nil {
 				return fmt.Errorf("invalid work order id %q", args[0])
 			}
+			name, err := agentIdentity(agent)
+			if err != nil {
+				return err
+			}
 
 			store, err := openWorkOrderStore()
 			if err != nil {
@@ -264,28 +390,174 @@ func newWorkOrderPromptCommand() *cobra.Command {
 			}
 			defer store.Close()
 
-			order, err := store.Get(context.Background(), id)
+			if err := expireLeases(cmd, store); err != nil {
+				return err
+			}
+			order, err := store.Claim(context.Background(), id, name, lease)
 			if err != nil {
 				return err
 			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Claimed work order %d as %s (lease until %s)\n", order.ID, order.Assignee, order.LeaseUntil.Format(time.RFC3339))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&agent, "as", "", "Agent name (default: $CN_AGENT or $USER)")
+	cmd.Flags().DurationVar(&lease, "lease", workorder.DefaultLeaseDuration, "Lease duration")
+	return cmd
+}
+
+func newWorkOrderReleaseCommand() *cobra.Command {
+	var agent string
+	var force bool
 
-			rolePrompt, err := prime.LoadPrompt(prime.RoleCarnie)
+	cmd := &cobra.Command{
+		Use:   "release <id>",
+		Short: "Release a claimed work order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid work order id %q", args[0])
+			}
+			name, err := agentIdentity(agent)
 			if err != nil {
 				return err
 			}
 
-			beadIndex, _ := workorder.LoadBeadIndex(mustGetwd())
-			beadInfo := beadIndex[order.BeadID]
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
 
-			projectName, projectDesc := loadCampMetadata()
-			prompt, err := workorder.RenderPrompt(workorder.PromptData{
-				RolePrompt:         rolePrompt,
-				WorkOrder:          order,
-				BeadTitle:          beadInfo.Title,
-				BeadDescription:    beadInfo.Description,
-				ProjectName:        projectName,
-				ProjectDescription: projectDesc,
-			})
+			order, err := store.Release(context.Background(), id, name, force)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Released work order %d (%s)\n", order.ID, order.Status)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&agent, "as", "", "Agent name (default: $CN_AGENT or $USER)")
+	cmd.Flags().BoolVar(&force, "force", false, "Release a claim held by another agent")
+	return cmd
+}
+
+func newWorkOrderHeartbeatCommand() *cobra.Command {
+	var agent string
+	var lease time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "heartbeat <id>",
+		Short: "Extend the lease on a claimed work order",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid work order id %q", args[0])
+			}
+			name, err := agentIdentity(agent)
+			if err != nil {
+				return err
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			order, err := store.Heartbeat(context.Background(), id, name, lease)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Lease on work order %d extended until %s\n", order.ID, order.LeaseUntil.Format(time.RFC3339))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&agent, "as", "", "Agent name (default: $CN_AGENT or $USER)")
+	cmd.Flags().DurationVar(&lease, "lease", workorder.DefaultLeaseDuration, "Lease duration")
+	return cmd
+}
+
+// agentIdentity resolves the claiming agent: explicit flag, then the "agent"
+// config key (CN_AGENT), then the login user.
+func agentIdentity(flag string) (string, error) {
+	if flag != "" {
+		return flag, nil
+	}
+	if agent := viper.GetString("agent"); agent != "" {
+		return agent, nil
+	}
+	if user := os.Getenv("USER"); user != "" {
+		return user, nil
+	}
+	return "", fmt.Errorf("unable to determine agent name; pass --as or set CN_AGENT")
+}
+
+// expireLeases returns stale claims to ready before commands that read or
+// take claims, so expiry works without a long-running process.
+func expireLeases(cmd *cobra.Command, store *workorder.Store) error {
+	expired, err := store.ExpireLeases(context.Background(), time.Now())
+	if err != nil {
+		return err
+	}
+	for _, order := range expired {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Lease expired on work order %d; returned to %s\n", order.ID, order.Status)
+	}
+	return nil
+}
+
+func formatEvent(event workorder.Event) string {
+	text := string(event.Kind)
+	if event.Actor != "" {
+		text += " by " + event.Actor
+	}
+	if event.Detail != "" {
+		text += " (" + event.Detail + ")"
+	}
+	return text
+}
+
+// validateCrewMember checks that a non-empty member name exists in camp.yml.
+func validateCrewMember(name string) error {
+	if name == "" {
+		return nil
+	}
+	_, cfg := loadCamp()
+	if _, ok := cfg.CrewMember(name); !ok {
+		return fmt.Errorf("unknown crew member %q (see carnie crew list)", name)
+	}
+	return nil
+}
+
+func newWorkOrderPromptCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "prompt <id>",
+		Short: "Render a work order prompt",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid work order id %q", args[0])
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			order, err := store.Get(context.Background(), id)
+			if err != nil {
+				return err
+			}
+
+			root, cfg := loadCamp()
+			prompt, err := runner.RenderPrompt(root, cfg, order)
 			if err != nil {
 				return err
 			}
@@ -302,6 +574,203 @@ func newWorkOrderPromptCommand() *cobra.Command {
 	return cmd
 }
 
+func newWorkOrderRunCommand() *cobra.Command {
+	var tool string
+	var model string
+	var dryRun bool
+	var agent string
+	var lease time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "run <id>",
+		Short: "Run an agent session for a work order",
+		Long:  "Launches the configured agent tool with the work order prompt and records the run and its session ID.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid work order id %q", args[0])
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			agentRunner := newRunner(cmd, store)
+			plan, err := agentRunner.PlanRun(context.Background(), id, runner.Options{Tool: tool, Model: model})
+			if err != nil {
+				return err
+			}
+
+			return executeRunPlan(cmd, agentRunner, plan, dryRun, agent, lease)
+		},
+	}
+
+	cmd.Flags().StringVar(&tool, "tool", "", "Agent tool override (claude or opencode)")
+	cmd.Flags().StringVar(&model, "model", "", "Model override")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the command instead of running it")
+	cmd.Flags().StringVar(&agent, "as", "", "Agent that claims the work order (default: $CN_AGENT or $USER)")
+	cmd.Flags().DurationVar(&lease, "lease", workorder.DefaultLeaseDuration, "Claim lease, renewed while the session runs")
+
+	return cmd
+}
+
+func newWorkOrderResumeCommand() *cobra.Command {
+	var model string
+	var message string
+	var dryRun bool
+	var agent string
+	var lease time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "resume <id>",
+		Short: "Resume the last agent session for a work order",
+		Long:  "Continues the most recent recorded conversation for the work order, telling the agent it was interrupted.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid work order id %q", args[0])
+			}
+
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			agentRunner := newRunner(cmd, store)
+			plan, err := agentRunner.PlanResume(context.Background(), id, runner.Options{Model: model, Message: message})
+			if err != nil {
+				return err
+			}
+
+			return executeRunPlan(cmd, agentRunner, plan, dryRun, agent, lease)
+		},
+	}
+
+	cmd.Flags().StringVar(&model, "model", "", "Model override")
+	cmd.Flags().StringVar(&message, "message", "", "Extra instructions for the resumed session")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the command instead of running it")
+	cmd.Flags().StringVar(&agent, "as", "", "Agent that claims the work order (default: $CN_AGENT or $USER)")
+	cmd.Flags().DurationVar(&lease, "lease", workorder.DefaultLeaseDuration, "Claim lease, renewed while the session runs")
+
+	return cmd
+}
+
+func newRunner(cmd *cobra.Command, store *workorder.Store) *runner.Runner {
+	root, cfg := loadCamp()
+	return &runner.Runner{
+		Store:  store,
+		Root:   root,
+		Config: cfg,
+		Stdout: cmd.OutOrStdout(),
+		Stderr: cmd.ErrOrStderr(),
+	}
+}
+
+// executeRunPlan runs plan as the agent named by agentFlag, which claims the
+// work order for the length of the session.
+func executeRunPlan(cmd *cobra.Command, agentRunner *runner.Runner, plan runner.Plan, dryRun bool, agentFlag string, lease time.Duration) error {
+	if dryRun {
+		fmt.Fprintln(cmd.OutOrStdout(), plan.Command())
+		return nil
+	}
+
+	agent, err := agentIdentity(agentFlag)
+	if err != nil {
+		return err
+	}
+	agentRunner.Agent = agent
+	agentRunner.Lease = lease
+
+	run, err := agentRunner.Execute(context.Background(), plan)
+	if run.ID != 0 {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Run #%d for work order %d: %s\n", run.ID, plan.Order.ID, formatRunSummary(run))
+	}
+	return err
+}
+
+func formatRunSummary(run workorder.Run) string {
+	summary := run.Tool
+	if run.Model != "" {
+		summary += " " + run.Model
+	}
+	switch {
+	case run.ExitCode == nil:
+		summary += " (running)"
+	case *run.ExitCode == 0:
+		summary += " (ok)"
+	default:
+		summary += fmt.Sprintf(" (exit %d)", *run.ExitCode)
+	}
+	if !run.Usage.IsZero() {
+		summary += ", " + formatUsage(run.Usage)
+	}
+	if run.SessionID != "" {
+		summary += " session " + run.SessionID
+	}
+	return summary
+}
+
+func newWorkOrderStatsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show work order counts and spend",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := openWorkOrderStore()
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			counts, err := store.CountByStatus(context.Background())
+			if err != nil {
+				return err
+			}
+			totals, err := store.UsageTotals(context.Background(), workorder.UsageFilter{})
+			if err != nil {
+				return err
+			}
+
+			writer := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(writer, "Status\tCount")
+			total := 0
+			for _, status := range workorder.ValidStatuses() {
+				fmt.Fprintf(writer, "%s\t%d\n", status, counts[status])
+				total += counts[status]
+			}
+			fmt.Fprintf(writer, "total\t%d\n", total)
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "\nRuns: %d\n", totals.Runs)
+			fmt.Fprintf(cmd.OutOrStdout(), "Usage: %s\n", formatUsage(totals.Usage))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+func formatUsage(usage session.Usage) string {
+	return fmt.Sprintf("%s in / %s out tokens, $%.2f", formatTokens(usage.InputTokens+usage.CacheReadTokens+usage.CacheWriteTokens), formatTokens(usage.OutputTokens), usage.CostUSD)
+}
+
+func formatTokens(count int64) string {
+	switch {
+	case count >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(count)/1_000_000)
+	case count >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(count)/1_000)
+	default:
+		return strconv.FormatInt(count, 10)
+	}
+}
+
 func openWorkOrderStore() (*workorder.Store, error) {
 	cwd, err := os.Getwd()
 	if err != nil {
@@ -314,20 +783,17 @@ func openWorkOrderStore() (*workorder.Store, error) {
 	return workorder.OpenStore(dbPath)
 }
 
-func loadCampMetadata() (string, string) {
-	cwd, err := os.Getwd()
-	if err != nil {
-		return "", ""
-	}
+func loadCamp() (string, *config.CampConfig) {
+	cwd := mustGetwd()
 	root, err := workorder.FindCampRoot(cwd)
 	if err != nil {
-		return "", ""
+		return cwd, nil
 	}
 	cfg, err := config.LoadCampConfig(filepath.Join(root, config.CampConfigFile))
 	if err != nil {
-		return "", ""
+		return root, nil
 	}
-	return cfg.Name, cfg.Description
+	return root, cfg
 }
 
 func truncateASCII(value string, width int) string {
@@ -350,3 +816,14 @@ func mustGetwd() string {
 	}
 	return cwd
 }
+
+// loadBeadIndex reads the exported beads for work order lookups. Missing
+// beads only mean titles and descriptions are not shown.
+func loadBeadIndex(startDir string) map[string]beads.Issue {
+	root, err := beads.FindRoot(startDir)
+	if err != nil {
+		return nil
+	}
+	index, _ := workorder.LoadBeadIndex(context.Background(), beads.NewJSONL(root))
+	return index
+}
@@ -1,8 +1,10 @@
 package config
 
 import (
+	"bytes"
 	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
@@ -12,15 +14,22 @@ const (
 	CurrentVersion       = 1
 	DefaultOperatorModel = "openai/gpt-5.2-codex"
 	DefaultAgentModel    = "openai/gpt-5.2-codex"
+	DefaultAgentTool     = "opencode"
 	DefaultPlanningTool  = "opencode"
 )
 
 type CampConfig struct {
-	Version     int            `yaml:"version"`
-	Name        string         `yaml:"name"`
-	Description string         `yaml:"description,omitempty"`
-	Operator    OperatorConfig `yaml:"operator,omitempty"`
-	Defaults    Defaults       `yaml:"defaults,omitempty"`
+	Version     int             `yaml:"version"`
+	Name        string          `yaml:"name"`
+	Description string          `yaml:"description,omitempty"`
+	Operator    OperatorConfig  `yaml:"operator,omitempty"`
+	Defaults    Defaults        `yaml:"defaults,omitempty"`
+	Budgets     Budgets         `yaml:"budgets,omitempty"`
+	Crew        []CrewMember    `yaml:"crew,omitempty"`
+	Roles       []RoleConfig    `yaml:"roles,omitempty"`
+	Daemon      DaemonConfig    `yaml:"daemon,omitempty"`
+	Lint        LintConfig      `yaml:"lint,omitempty"`
+	Dashboard   DashboardConfig `yaml:"dashboard,omitempty"`
 }
 
 type OperatorConfig struct {
@@ -31,6 +40,62 @@ type OperatorConfig struct {
 
 type Defaults struct {
 	AgentModel string `yaml:"agent_model,omitempty"`
+	AgentTool  string `yaml:"agent_tool,omitempty"` // "claude" or "opencode"
+}
+
+// Budgets are optional spending ceilings; zero means no limit.
+type Budgets struct {
+	RunUSD       float64 `yaml:"run_usd,omitempty"`        // stop a single run once it costs more
+	RunTokens    int64   `yaml:"run_tokens,omitempty"`     // stop a single run once it uses more tokens
+	WorkOrderUSD float64 `yaml:"work_order_usd,omitempty"` // refuse to launch once a work order has spent this
+	CampUSD      float64 `yaml:"camp_usd,omitempty"`       // refuse to launch once the camp has spent this
+}
+
+// CrewMember is a named agent profile that work orders can be assigned to.
+type CrewMember struct {
+	Name        string   `yaml:"name"`
+	Tool        string   `yaml:"tool,omitempty"`        // "claude" or "opencode" (default: defaults.agent_tool)
+	Model       string   `yaml:"model,omitempty"`       // default: defaults.agent_model
+	RolePrompt  string   `yaml:"role_prompt,omitempty"` // extra persona added to the Carnie role
+	Concurrency int      `yaml:"concurrency,omitempty"` // max work orders in progress at once (0 = unlimited)
+	Labels      []string `yaml:"labels,omitempty"`
+}
+
+// RoleConfig declares a camp role for `carnie prime`. Roles can also be
+// discovered from .carnie/roles/<name>.md without an entry here.
+type RoleConfig struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Inherits    string `yaml:"inherits,omitempty"` // built-in role whose workflow comes first
+	File        string `yaml:"file,omitempty"`     // prompt file relative to the camp root (default .carnie/roles/<name>.md)
+	Prompt      string `yaml:"prompt,omitempty"`   // inline prompt used instead of a file
+}
+
+// DaemonConfig tunes how `carnie daemon` dispatches ready work orders.
+type DaemonConfig struct {
+	PollInterval   time.Duration `yaml:"poll_interval,omitempty"`   // re-check ready work at least this often (default 30s)
+	MaxConcurrency int           `yaml:"max_concurrency,omitempty"` // max agent runs at once across the crew (default 1)
+	MaxRetries     int           `yaml:"max_retries,omitempty"`     // failed runs retried before blocking the order (default 3)
+	RetryBackoff   time.Duration `yaml:"retry_backoff,omitempty"`   // first retry delay, doubled per attempt (default 1m)
+	Lease          time.Duration `yaml:"lease,omitempty"`           // claim lease renewed while a run is active (default 15m)
+	AutoDone       bool          `yaml:"auto_done,omitempty"`       // mark orders done when a run exits cleanly (default: block them for review)
+}
+
+// LintConfig configures `carnie beads lint`.
+type LintConfig struct {
+	Rules           map[string]string `yaml:"rules,omitempty"`             // rule name -> off, info, warning or error
+	MaxEpicChildren int               `yaml:"max_epic_children,omitempty"` // epic-size threshold (default 7)
+}
+
+// DashboardConfig configures `carnie dashboard`.
+type DashboardConfig struct {
+	Views []SavedView `yaml:"views,omitempty"` // named searches, e.g. "owner:me p:<=1"
+}
+
+// SavedView is a named dashboard search.
+type SavedView struct {
+	Name  string `yaml:"name"`
+	Query string `yaml:"query"`
 }
 
 func NewCampConfig(name string) *CampConfig {
@@ -43,6 +108,7 @@ func NewCampConfig(name string) *CampConfig {
 		},
 		Defaults: Defaults{
 			AgentModel: DefaultAgentModel,
+			AgentTool:  DefaultAgentTool,
 		},
 	}
 }
@@ -73,3 +139,79 @@ func LoadCampConfig(path string) (*CampConfig, error) {
 
 	return &config, nil
 }
+
+func (c *CampConfig) CrewMember(name string) (CrewMember, bool) {
+	if c == nil {
+		return CrewMember{}, false
+	}
+	for _, member := range c.Crew {
+		if member.Name == name {
+			return member, true
+		}
+	}
+	return CrewMember{}, false
+}
+
+// SaveDashboardView adds view to dashboard.views in the camp config at path,
+// replacing a view with the same name. The file is edited in place so
+// comments and the order of other settings survive.
+func SaveDashboardView(path string, view SavedView) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return fmt.Errorf("parse config: %s is not a mapping", path)
+	}
+
+	var entry yaml.Node
+	if err := entry.Encode(view); err != nil {
+		return fmt.Errorf("encode view: %w", err)
+	}
+	dashboard := mappingValue(doc.Content[0], "dashboard", yaml.MappingNode)
+	views := mappingValue(dashboard, "views", yaml.SequenceNode)
+	replaced := false
+	for i, existing := range views.Content {
+		var saved SavedView
+		if existing.Decode(&saved) == nil && saved.Name == view.Name {
+			views.Content[i] = &entry
+			replaced = true
+		}
+	}
+	if !replaced {
+		views.Content = append(views.Content, &entry)
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("marshal config: %w", err)
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
+
+// mappingValue returns the value under key in mapping, adding an empty node
+// of kind when the key is missing or null.
+func mappingValue(mapping *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		value := mapping.Content[i+1]
+		if value.Kind != kind {
+			*value = yaml.Node{Kind: kind}
+		}
+		return value
+	}
+	value := &yaml.Node{Kind: kind}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+	return value
+}
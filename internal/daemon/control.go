@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Control commands accepted on the daemon socket.
+const (
+	CommandStatus = "status"
+	CommandPause  = "pause"
+	CommandResume = "resume"
+	CommandDrain  = "drain"
+)
+
+type request struct {
+	Command string `json:"command"`
+}
+
+type response struct {
+	OK     bool   `json:"ok"`
+	Error  string `json:"error,omitempty"`
+	Status Status `json:"status"`
+}
+
+func listen(root string) (net.Listener, error) {
+	path := SocketPath(root)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("create %s: %w", carnieDir, err)
+	}
+	// A socket left behind by a crashed daemon blocks Listen; the PID file
+	// check has already ruled out a live daemon.
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("remove stale control socket: %w", err)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listen on control socket: %w", err)
+	}
+	return listener, nil
+}
+
+func (d *Daemon) serve(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		go d.handle(conn)
+	}
+}
+
+func (d *Daemon) handle(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	var req request
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+		_ = json.NewEncoder(conn).Encode(response{Error: fmt.Sprintf("invalid request: %v", err)})
+		return
+	}
+
+	resp := response{OK: true}
+	switch req.Command {
+	case CommandStatus:
+	case CommandPause:
+		d.SetState(StatePaused)
+		d.logf("paused")
+	case CommandResume:
+		d.SetState(StateRunning)
+		d.logf("resumed")
+	case CommandDrain:
+		d.SetState(StateDraining)
+		d.logf("draining")
+	default:
+		resp = response{Error: fmt.Sprintf("unknown command %q", req.Command)}
+	}
+	resp.Status = d.Status()
+	_ = json.NewEncoder(conn).Encode(resp)
+}
+
+// Send issues a control command to the daemon of the camp at root and
+// returns its status after the command was applied.
+func Send(root string, command string) (Status, error) {
+	conn, err := net.DialTimeout("unix", SocketPath(root), 2*time.Second)
+	if err != nil {
+		return Status{}, ErrNotRunning
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if err := json.NewEncoder(conn).Encode(request{Command: command}); err != nil {
+		return Status{}, fmt.Errorf("send %s: %w", command, err)
+	}
+	var resp response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return Status{}, fmt.Errorf("read %s response: %w", command, err)
+	}
+	if !resp.OK {
+		return Status{}, errors.New(resp.Error)
+	}
+	return resp.Status, nil
+}
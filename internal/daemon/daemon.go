@@ -0,0 +1,546 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/runner"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+const (
+	DefaultPollInterval   = 30 * time.Second
+	DefaultMaxConcurrency = 1
+	DefaultMaxRetries     = 3
+	DefaultRetryBackoff   = time.Minute
+
+	// watchInterval is how often watched files are checked for changes.
+	watchInterval = time.Second
+)
+
+// State is the dispatch state of a running daemon.
+type State string
+
+const (
+	StateRunning  State = "running"
+	StatePaused   State = "paused"
+	StateDraining State = "draining"
+)
+
+// Daemon dispatches ready work orders to agent runs within crew capacity.
+type Daemon struct {
+	Root   string
+	Config *config.CampConfig
+	Store  *workorder.Store
+	Log    io.Writer
+
+	mu      sync.Mutex
+	state   State
+	started time.Time
+	active  map[int64]ActiveRun
+	retries map[int64]Retry
+	wake    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// ActiveRun is a work order the daemon currently has an agent working on.
+type ActiveRun struct {
+	WorkOrderID int64     `json:"work_order_id"`
+	Title       string    `json:"title"`
+	Crew        string    `json:"crew,omitempty"`
+	Agent       string    `json:"agent"`
+	StartedAt   time.Time `json:"started_at"`
+}
+
+// Retry tracks failed attempts for a work order and when it may run again.
+type Retry struct {
+	WorkOrderID int64     `json:"work_order_id"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+}
+
+// Status is the snapshot reported over the control socket.
+type Status struct {
+	PID       int         `json:"pid"`
+	State     State       `json:"state"`
+	StartedAt time.Time   `json:"started_at"`
+	Active    []ActiveRun `json:"active"`
+	Retries   []Retry     `json:"retries"`
+}
+
+// New creates a daemon for the camp rooted at root.
+func New(root string, cfg *config.CampConfig, store *workorder.Store, log io.Writer) *Daemon {
+	if cfg == nil {
+		cfg = &config.CampConfig{}
+	}
+	if log == nil {
+		log = io.Discard
+	}
+	return &Daemon{
+		Root:    root,
+		Config:  cfg,
+		Store:   store,
+		Log:     log,
+		state:   StateRunning,
+		active:  make(map[int64]ActiveRun),
+		retries: make(map[int64]Retry),
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Run writes the PID file, serves the control socket and dispatches work
+// until ctx is canceled or a drain completes. Active runs are stopped and
+// their claims released when ctx is canceled.
+func (d *Daemon) Run(ctx context.Context) error {
+	if err := writePIDFile(d.Root); err != nil {
+		return err
+	}
+	defer removePIDFile(d.Root)
+
+	listener, err := listen(d.Root)
+	if err != nil {
+		return err
+	}
+	defer listener.Close()
+	go d.serve(listener)
+
+	d.mu.Lock()
+	d.started = time.Now().UTC()
+	d.mu.Unlock()
+	d.logf("daemon started (pid %d)", os.Getpid())
+
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	pollInterval := d.pollInterval()
+	lastChange := fingerprint(d.watchedPaths())
+	lastDispatch := time.Time{}
+
+	for {
+		now := time.Now()
+		// Listed on every pass so files created later, such as a new WAL
+		// or issues.jsonl after the first sync, are watched too.
+		change := fingerprint(d.watchedPaths())
+		if change != lastChange || now.Sub(lastDispatch) >= pollInterval {
+			lastChange = change
+			lastDispatch = now
+			d.tick(ctx, now)
+		}
+
+		if d.drained() {
+			d.logf("drain complete, exiting")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			d.logf("shutting down, waiting for %d active runs", d.activeCount())
+			d.wg.Wait()
+			return nil
+		case <-d.wake:
+			lastDispatch = time.Time{}
+		case <-ticker.C:
+		}
+	}
+}
+
+// tick expires stale leases and launches runs for dispatchable work orders.
+func (d *Daemon) tick(ctx context.Context, now time.Time) {
+	expired, err := d.Store.ExpireLeases(ctx, now)
+	if err != nil {
+		d.logf("expire leases: %v", err)
+	}
+	for _, order := range expired {
+		d.logf("lease expired on work order %d, returned to ready", order.ID)
+	}
+
+	if d.currentState() != StateRunning {
+		return
+	}
+
+	status := workorder.StatusReady
+	ready, err := d.Store.List(ctx, workorder.ListOptions{Status: &status})
+	if err != nil {
+		d.logf("list ready work orders: %v", err)
+		return
+	}
+	load, err := d.Store.CrewLoad(ctx)
+	if err != nil {
+		d.logf("load crew capacity: %v", err)
+		return
+	}
+
+	d.mu.Lock()
+	assignments := planDispatch(ready, d.Config, load, len(d.active), d.retries, now)
+	d.mu.Unlock()
+
+	for _, assignment := range assignments {
+		d.launch(ctx, assignment)
+	}
+}
+
+type assignment struct {
+	Order workorder.WorkOrder
+	Crew  string
+}
+
+// planDispatch picks which ready orders to launch, oldest first, within the
+// daemon-wide concurrency limit and each crew member's concurrency. Orders
+// without a crew member are given to the least loaded member with room.
+func planDispatch(ready []workorder.WorkOrder, cfg *config.CampConfig, load map[string]int, active int, retries map[int64]Retry, now time.Time) []assignment {
+	orders := append([]workorder.WorkOrder(nil), ready...)
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+
+	limit := cfg.Daemon.MaxConcurrency
+	if limit <= 0 {
+		limit = DefaultMaxConcurrency
+	}
+
+	used := make(map[string]int, len(load))
+	for crew, count := range load {
+		used[crew] = count
+	}
+	hasRoom := func(member config.CrewMember) bool {
+		return member.Concurrency <= 0 || used[member.Name] < member.Concurrency
+	}
+
+	var result []assignment
+	for _, order := range orders {
+		if active+len(result) >= limit {
+			break
+		}
+		if retry, ok := retries[order.ID]; ok && now.Before(retry.NextAttempt) {
+			continue
+		}
+
+		crew := order.Crew
+		switch {
+		case crew != "":
+			member, ok := cfg.CrewMember(crew)
+			if !ok || !hasRoom(member) {
+				continue
+			}
+		case len(cfg.Crew) > 0:
+			best := -1
+			for i, member := range cfg.Crew {
+				if !hasRoom(member) {
+					continue
+				}
+				if best < 0 || used[member.Name] < used[cfg.Crew[best].Name] {
+					best = i
+				}
+			}
+			if best < 0 {
+				continue
+			}
+			crew = cfg.Crew[best].Name
+		}
+
+		if crew != "" {
+			used[crew]++
+		}
+		result = append(result, assignment{Order: order, Crew: crew})
+	}
+
+	return result
+}
+
+// launch claims the work order and runs an agent for it in the background.
+func (d *Daemon) launch(ctx context.Context, next assignment) {
+	order := next.Order
+	if next.Crew != "" && order.Crew != next.Crew {
+		assigned, err := d.Store.AssignCrew(ctx, order.ID, next.Crew)
+		if err != nil {
+			d.logf("assign work order %d to %s: %v", order.ID, next.Crew, err)
+			return
+		}
+		order = assigned
+	}
+
+	agent := agentName(next.Crew)
+	member, _ := d.Config.CrewMember(next.Crew)
+	if _, err := d.Store.ClaimWithin(ctx, order.ID, agent, d.lease(), member.Concurrency); err != nil {
+		d.logf("claim work order %d: %v", order.ID, err)
+		return
+	}
+
+	d.mu.Lock()
+	d.active[order.ID] = ActiveRun{
+		WorkOrderID: order.ID,
+		Title:       order.Title,
+		Crew:        next.Crew,
+		Agent:       agent,
+		StartedAt:   time.Now().UTC(),
+	}
+	d.mu.Unlock()
+	d.logf("dispatching work order %d to %s", order.ID, agent)
+
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+
+		run, err := d.execute(ctx, order.ID, agent)
+		d.finish(ctx, order, agent, run, err)
+	}()
+}
+
+// execute runs the agent for a work order the daemon has already claimed as
+// agent; the runner renews that claim's lease until the run finishes.
+func (d *Daemon) execute(ctx context.Context, orderID int64, agent string) (workorder.Run, error) {
+	agentRunner := &runner.Runner{
+		Store:  d.Store,
+		Root:   d.Root,
+		Config: d.Config,
+		Agent:  agent,
+		Lease:  d.lease(),
+		Stderr: d.Log,
+	}
+	plan, err := agentRunner.PlanRun(ctx, orderID, runner.Options{})
+	if err != nil {
+		return workorder.Run{}, err
+	}
+	run, err := agentRunner.Execute(ctx, plan)
+	if err == nil && run.ExitCode != nil && *run.ExitCode != 0 {
+		err = fmt.Errorf("agent exited with code %d", *run.ExitCode)
+	}
+	return run, err
+}
+
+// finish records the outcome of a run. A clean exit only says the agent
+// stopped, so an order it left in progress is blocked for review, or marked
+// done when daemon.auto_done is set. Failed runs are released and retried
+// with exponential backoff until max_retries, after which they are blocked.
+func (d *Daemon) finish(ctx context.Context, order workorder.WorkOrder, agent string, run workorder.Run, runErr error) {
+	cleanup := context.WithoutCancel(ctx)
+
+	d.mu.Lock()
+	delete(d.active, order.ID)
+	d.mu.Unlock()
+	defer d.signal()
+
+	if ctx.Err() != nil {
+		if _, err := d.Store.Release(cleanup, order.ID, agent, false); err != nil {
+			d.logf("release work order %d: %v", order.ID, err)
+		}
+		return
+	}
+
+	if runErr == nil {
+		d.mu.Lock()
+		delete(d.retries, order.ID)
+		d.mu.Unlock()
+
+		current, err := d.Store.Get(cleanup, order.ID)
+		if err != nil {
+			d.logf("reload work order %d: %v", order.ID, err)
+			return
+		}
+		if current.Status == workorder.StatusInProgress {
+			if d.Config.Daemon.AutoDone {
+				if _, err := d.Store.UpdateStatusBy(cleanup, order.ID, workorder.StatusDone, agent); err != nil {
+					d.logf("complete work order %d: %v", order.ID, err)
+					return
+				}
+			} else if err := d.holdForReview(cleanup, order.ID, agent); err != nil {
+				d.logf("block work order %d: %v", order.ID, err)
+				return
+			}
+		}
+		d.logf("work order %d finished (%s)", order.ID, formatRun(run))
+		return
+	}
+
+	d.mu.Lock()
+	retry := d.retries[order.ID]
+	retry.WorkOrderID = order.ID
+	retry.Attempts++
+	retry.LastError = runErr.Error()
+	retry.NextAttempt = time.Now().UTC().Add(backoff(d.retryBackoff(), retry.Attempts))
+	exhausted := retry.Attempts > d.maxRetries()
+	if exhausted {
+		delete(d.retries, order.ID)
+	} else {
+		d.retries[order.ID] = retry
+	}
+	d.mu.Unlock()
+
+	detail := fmt.Sprintf("attempt %d: %v", retry.Attempts, runErr)
+	if !exhausted {
+		detail += fmt.Sprintf("; retrying after %s", retry.NextAttempt.Format(time.RFC3339))
+	}
+	if _, err := d.Store.AddEvent(cleanup, workorder.EventInput{
+		WorkOrderID: order.ID,
+		Kind:        workorder.EventRunFailed,
+		Actor:       agent,
+		Detail:      detail,
+	}); err != nil {
+		d.logf("record failure for work order %d: %v", order.ID, err)
+	}
+
+	current, err := d.Store.Get(cleanup, order.ID)
+	if err != nil {
+		d.logf("reload work order %d: %v", order.ID, err)
+		return
+	}
+	if current.Assignee == agent {
+		if current, err = d.Store.Release(cleanup, order.ID, agent, false); err != nil {
+			d.logf("release work order %d: %v", order.ID, err)
+			return
+		}
+	}
+	if exhausted && workorder.CanTransition(current.Status, workorder.StatusBlocked) {
+		if _, err := d.Store.UpdateStatus(cleanup, order.ID, workorder.StatusBlocked); err != nil {
+			d.logf("block work order %d: %v", order.ID, err)
+			return
+		}
+		d.logf("work order %d blocked after %d failed attempts: %v", order.ID, retry.Attempts, runErr)
+		return
+	}
+	d.logf("work order %d failed (attempt %d): %v", order.ID, retry.Attempts, runErr)
+}
+
+// holdForReview blocks an order the agent left in progress after a clean
+// exit, so it is neither claimed done nor dispatched again on its own.
+func (d *Daemon) holdForReview(ctx context.Context, id int64, agent string) error {
+	if _, err := d.Store.UpdateStatusBy(ctx, id, workorder.StatusBlocked, agent); err != nil {
+		return err
+	}
+	_, err := d.Store.AddEvent(ctx, workorder.EventInput{
+		WorkOrderID: id,
+		Kind:        workorder.EventNote,
+		Actor:       agent,
+		Detail:      "run exited without finishing the work order; review it, then mark it done or ready",
+	})
+	return err
+}
+
+// backoff returns the delay before the given retry attempt (1-based).
+func backoff(base time.Duration, attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	delay := base
+	for i := 1; i < attempt && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+// SetState changes the dispatch state. Pausing stops new launches; draining
+// additionally exits the daemon once active runs finish.
+func (d *Daemon) SetState(state State) {
+	d.mu.Lock()
+	d.state = state
+	d.mu.Unlock()
+	d.signal()
+}
+
+// Status returns a snapshot of the daemon state.
+func (d *Daemon) Status() Status {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	status := Status{
+		PID:       os.Getpid(),
+		State:     d.state,
+		StartedAt: d.started,
+		Active:    make([]ActiveRun, 0, len(d.active)),
+		Retries:   make([]Retry, 0, len(d.retries)),
+	}
+	for _, run := range d.active {
+		status.Active = append(status.Active, run)
+	}
+	for _, retry := range d.retries {
+		status.Retries = append(status.Retries, retry)
+	}
+	sort.Slice(status.Active, func(i, j int) bool { return status.Active[i].WorkOrderID < status.Active[j].WorkOrderID })
+	sort.Slice(status.Retries, func(i, j int) bool { return status.Retries[i].WorkOrderID < status.Retries[j].WorkOrderID })
+	return status
+}
+
+func (d *Daemon) currentState() State {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.state
+}
+
+func (d *Daemon) activeCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.active)
+}
+
+func (d *Daemon) drained() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.state == StateDraining && len(d.active) == 0
+}
+
+func (d *Daemon) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Daemon) pollInterval() time.Duration {
+	if d.Config.Daemon.PollInterval > 0 {
+		return d.Config.Daemon.PollInterval
+	}
+	return DefaultPollInterval
+}
+
+func (d *Daemon) maxRetries() int {
+	if d.Config.Daemon.MaxRetries > 0 {
+		return d.Config.Daemon.MaxRetries
+	}
+	return DefaultMaxRetries
+}
+
+func (d *Daemon) retryBackoff() time.Duration {
+	if d.Config.Daemon.RetryBackoff > 0 {
+		return d.Config.Daemon.RetryBackoff
+	}
+	return DefaultRetryBackoff
+}
+
+func (d *Daemon) lease() time.Duration {
+	if d.Config.Daemon.Lease > 0 {
+		return d.Config.Daemon.Lease
+	}
+	return workorder.DefaultLeaseDuration
+}
+
+func (d *Daemon) logf(format string, args ...any) {
+	fmt.Fprintf(d.Log, "%s %s\n", time.Now().Format("2006-01-02 15:04:05"), fmt.Sprintf(format, args...))
+}
+
+func agentName(crew string) string {
+	if crew == "" {
+		return "daemon"
+	}
+	return "daemon/" + crew
+}
+
+func formatRun(run workorder.Run) string {
+	if run.ID == 0 {
+		return "no run recorded"
+	}
+	return fmt.Sprintf("run #%d, $%.2f", run.ID, run.Usage.CostUSD)
+}
+
+// ErrNotRunning is returned by control commands when no daemon is listening.
+var ErrNotRunning = errors.New("daemon is not running")
@@ -0,0 +1,187 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+func TestPlanDispatchRespectsCapacity(t *testing.T) {
+	now := time.Now()
+	orders := []workorder.WorkOrder{
+		{ID: 3, CreatedAt: now.Add(-1 * time.Minute)},
+		{ID: 1, CreatedAt: now.Add(-3 * time.Minute), Crew: "reviewer"},
+		{ID: 2, CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: 4, CreatedAt: now},
+	}
+	cfg := &config.CampConfig{
+		Crew: []config.CrewMember{
+			{Name: "reviewer", Concurrency: 1},
+			{Name: "builder", Concurrency: 2},
+		},
+		Daemon: config.DaemonConfig{MaxConcurrency: 5},
+	}
+
+	got := planDispatch(orders, cfg, map[string]int{"builder": 1}, 0, nil, now)
+
+	want := []assignment{
+		{Order: orders[1], Crew: "reviewer"},
+		{Order: orders[2], Crew: "builder"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d assignments, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i].Order.ID != want[i].Order.ID || got[i].Crew != want[i].Crew {
+			t.Fatalf("assignment %d: expected %d/%s, got %d/%s", i, want[i].Order.ID, want[i].Crew, got[i].Order.ID, got[i].Crew)
+		}
+	}
+}
+
+func TestPlanDispatchSkipsBackoffAndGlobalLimit(t *testing.T) {
+	now := time.Now()
+	orders := []workorder.WorkOrder{
+		{ID: 1, CreatedAt: now.Add(-2 * time.Minute)},
+		{ID: 2, CreatedAt: now.Add(-1 * time.Minute)},
+		{ID: 3, CreatedAt: now},
+	}
+	retries := map[int64]Retry{1: {WorkOrderID: 1, Attempts: 1, NextAttempt: now.Add(time.Minute)}}
+	cfg := &config.CampConfig{Daemon: config.DaemonConfig{MaxConcurrency: 2}}
+
+	got := planDispatch(orders, cfg, nil, 1, retries, now)
+	if len(got) != 1 || got[0].Order.ID != 2 {
+		t.Fatalf("expected only work order 2 to be dispatched, got %+v", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	cases := map[int]time.Duration{
+		1:  time.Minute,
+		2:  2 * time.Minute,
+		3:  4 * time.Minute,
+		20: time.Hour,
+	}
+	for attempt, expected := range cases {
+		if got := backoff(time.Minute, attempt); got != expected {
+			t.Fatalf("attempt %d: expected %s, got %s", attempt, expected, got)
+		}
+	}
+}
+
+func TestControlSocket(t *testing.T) {
+	root := t.TempDir()
+	if _, err := Send(root, CommandStatus); err != ErrNotRunning {
+		t.Fatalf("expected ErrNotRunning, got %v", err)
+	}
+
+	d := New(root, nil, nil, nil)
+	listener, err := listen(root)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	go d.serve(listener)
+
+	status, err := Send(root, CommandPause)
+	if err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	if status.State != StatePaused {
+		t.Fatalf("expected paused state, got %s", status.State)
+	}
+	if status, err = Send(root, CommandResume); err != nil || status.State != StateRunning {
+		t.Fatalf("expected running after resume, got %s (%v)", status.State, err)
+	}
+	if _, err := Send(root, "explode"); err == nil {
+		t.Fatal("expected unknown command to fail")
+	}
+}
+
+func TestWritePIDFileIsExclusive(t *testing.T) {
+	root := t.TempDir()
+	if err := writePIDFile(root); err != nil {
+		t.Fatalf("write pid file: %v", err)
+	}
+	if err := writePIDFile(root); err == nil || !strings.Contains(err.Error(), "already running") {
+		t.Fatalf("expected a second daemon to be refused, got %v", err)
+	}
+
+	exited := exec.Command("true")
+	if err := exited.Run(); err != nil {
+		t.Skipf("true unavailable: %v", err)
+	}
+	if err := os.WriteFile(PIDPath(root), []byte(strconv.Itoa(exited.Process.Pid)+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePIDFile(root); err != nil {
+		t.Fatalf("expected a stale pid file to be replaced, got %v", err)
+	}
+	if RunningPID(root) != os.Getpid() {
+		t.Fatalf("expected the pid file to name this process")
+	}
+
+	if err := os.WriteFile(PIDPath(root), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePIDFile(root); err == nil {
+		t.Fatal("expected a pid file still being written to be left alone")
+	}
+}
+
+func TestWatchedPathsPickUpNewBeadsFiles(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	d := &Daemon{Root: root}
+	before := fingerprint(d.watchedPaths())
+
+	if err := os.WriteFile(filepath.Join(root, ".beads", "issues.jsonl"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if after := fingerprint(d.watchedPaths()); after == before {
+		t.Fatalf("expected a new file in .beads to change the fingerprint, got %q", after)
+	}
+}
+
+func TestFinishLeavesCompletionToTheAgent(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	store, err := workorder.OpenStore(filepath.Join(root, "carniecamp.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	finish := func(cfg *config.CampConfig) workorder.WorkOrder {
+		t.Helper()
+		order, err := store.Create(ctx, workorder.CreateInput{Title: "Build it", Description: "Work", Status: workorder.StatusReady})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if order, err = store.Claim(ctx, order.ID, "daemon", time.Minute); err != nil {
+			t.Fatalf("claim: %v", err)
+		}
+		New(root, cfg, store, nil).finish(ctx, order, "daemon", workorder.Run{}, nil)
+		current, err := store.Get(ctx, order.ID)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		return current
+	}
+
+	if order := finish(nil); order.Status != workorder.StatusBlocked {
+		t.Fatalf("expected a clean exit to block the order for review, got %s", order.Status)
+	}
+	if order := finish(&config.CampConfig{Daemon: config.DaemonConfig{AutoDone: true}}); order.Status != workorder.StatusDone {
+		t.Fatalf("expected auto_done to mark the order done, got %s", order.Status)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package daemon
+
+import "syscall"
+
+func detachAttr() *syscall.SysProcAttr {
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build unix
+
+package daemon
+
+import "syscall"
+
+// detachAttr starts the daemon in its own session so it survives the
+// terminal that launched it.
+func detachAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setsid: true}
+}
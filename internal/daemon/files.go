@@ -0,0 +1,169 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const (
+	carnieDir  = ".carnie"
+	pidFile    = "daemon.pid"
+	socketFile = "daemon.sock"
+	// LogFile is where a detached daemon writes its output.
+	LogFile = "daemon.log"
+)
+
+// PIDPath returns the PID file location for the camp at root.
+func PIDPath(root string) string {
+	return filepath.Join(root, carnieDir, pidFile)
+}
+
+// SocketPath returns the control socket location for the camp at root.
+func SocketPath(root string) string {
+	return filepath.Join(root, carnieDir, socketFile)
+}
+
+// LogPath returns the detached daemon log location for the camp at root.
+func LogPath(root string) string {
+	return filepath.Join(root, carnieDir, LogFile)
+}
+
+// RunningPID returns the PID recorded in the PID file if that process is
+// still alive, or 0.
+func RunningPID(root string) int {
+	data, err := os.ReadFile(PIDPath(root))
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return 0
+	}
+	if err := process.Signal(syscall.Signal(0)); err != nil {
+		return 0
+	}
+	return pid
+}
+
+// writePIDFile creates the PID file exclusively, so two daemons starting at
+// once cannot both win. A file left by a daemon that is no longer running is
+// replaced; one that can't be read yet belongs to a daemon still starting.
+func writePIDFile(root string) error {
+	if err := os.MkdirAll(filepath.Join(root, carnieDir), 0755); err != nil {
+		return fmt.Errorf("create %s: %w", carnieDir, err)
+	}
+	path := PIDPath(root)
+	for attempt := 0; ; attempt++ {
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if err == nil {
+			_, writeErr := fmt.Fprintf(file, "%d\n", os.Getpid())
+			if err := errors.Join(writeErr, file.Close()); err != nil {
+				_ = os.Remove(path)
+				return fmt.Errorf("write pid file: %w", err)
+			}
+			return nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return fmt.Errorf("create pid file: %w", err)
+		}
+		if pid := RunningPID(root); pid != 0 {
+			return fmt.Errorf("daemon already running (pid %d)", pid)
+		}
+		if attempt > 0 || !stalePIDFile(path) {
+			return fmt.Errorf("%s exists; remove it if no daemon is running", path)
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("remove stale pid file: %w", err)
+		}
+	}
+}
+
+// stalePIDFile reports whether path holds a complete PID. Once RunningPID
+// has found that process gone, the file is left over from a crash.
+func stalePIDFile(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	return err == nil && pid > 0
+}
+
+func removePIDFile(root string) {
+	_ = os.Remove(PIDPath(root))
+}
+
+// watchedPaths lists the files whose changes should trigger a dispatch pass:
+// the work order database (and its WAL) and everything in .beads.
+func (d *Daemon) watchedPaths() []string {
+	db := filepath.Join(d.Root, carnieDir, "carniecamp.db")
+	paths := []string{db, db + "-wal"}
+
+	entries, err := os.ReadDir(filepath.Join(d.Root, ".beads"))
+	if err == nil {
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				paths = append(paths, filepath.Join(d.Root, ".beads", entry.Name()))
+			}
+		}
+	}
+	return paths
+}
+
+// fingerprint summarizes the names, modification times and sizes of paths so
+// changes, including files appearing, can be detected by polling.
+func fingerprint(paths []string) string {
+	var builder strings.Builder
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(&builder, "%s:-;", path)
+			continue
+		}
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(&builder, "%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+	}
+	return builder.String()
+}
+
+// StartDetached re-executes the current binary with args in the background,
+// appending its output to the daemon log, and returns the child PID.
+func StartDetached(root string, args []string) (int, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("locate carnie executable: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, carnieDir), 0755); err != nil {
+		return 0, fmt.Errorf("create %s: %w", carnieDir, err)
+	}
+	logFile, err := os.OpenFile(LogPath(root), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("open daemon log: %w", err)
+	}
+	defer logFile.Close()
+
+	command := exec.Command(executable, args...)
+	command.Dir = root
+	command.Stdout = logFile
+	command.Stderr = logFile
+	command.SysProcAttr = detachAttr()
+	if err := command.Start(); err != nil {
+		return 0, fmt.Errorf("start daemon: %w", err)
+	}
+	pid := command.Process.Pid
+	_ = command.Process.Release()
+	return pid, nil
+}
@@ -0,0 +1,34 @@
+package dashboard
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// actionMsg reports the outcome of a change made from the dashboard. A
+// successful action shows Notice and reloads the data.
+type actionMsg struct {
+	Notice string
+	Err    error
+}
+
+type clearNoticeMsg struct {
+	Seq int
+}
+
+const noticeDuration = 4 * time.Second
+
+func (m Model) withNotice(text string, isErr bool) Model {
+	m.notice = text
+	m.noticeErr = isErr
+	m.noticeSeq++
+	return m
+}
+
+func (m Model) clearNoticeCmd() tea.Cmd {
+	seq := m.noticeSeq
+	return tea.Tick(noticeDuration, func(time.Time) tea.Msg {
+		return clearNoticeMsg{Seq: seq}
+	})
+}
@@ -0,0 +1,110 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// actionIssue is the issue bead actions apply to: the selected task, board
+// card or epic.
+func (m Model) actionIssue() *Issue {
+	switch m.activeView {
+	case ViewTasks:
+		return m.selectedIssue()
+	case ViewBoard:
+		return m.selectedBoardIssue()
+	case ViewEpics:
+		return m.selectedEpicIssue()
+	}
+	return nil
+}
+
+// updateBeadActionKey handles the keys that change the selected issue.
+func (m Model) updateBeadActionKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.activeView != ViewTasks && m.activeView != ViewBoard && m.activeView != ViewEpics {
+		return m, nil, false
+	}
+	key := msg.String()
+	switch key {
+	case "c", "x", "p", "a", "e":
+	default:
+		return m, nil, false
+	}
+	selected := m.actionIssue()
+	if selected == nil {
+		return m, nil, true
+	}
+	id := selected.ID
+
+	switch key {
+	case "c":
+		status := beads.StatusInProgress
+		return m, m.beadActionCmd(fmt.Sprintf("Claimed %s", id), func(ctx context.Context, client beads.Client) error {
+			return client.Update(ctx, id, beads.UpdateInput{Status: &status})
+		}), true
+	case "x":
+		m.input = newPromptInput("Why is it done?")
+		m.inputLabel = fmt.Sprintf("Close %s", id)
+		m.inputSubmit = func(m Model, reason string) (Model, tea.Cmd) {
+			return m, m.beadActionCmd(fmt.Sprintf("Closed %s", id), func(ctx context.Context, client beads.Client) error {
+				return client.Close(ctx, id, reason)
+			})
+		}
+		m.inputActive = true
+	case "p":
+		m.input = newPromptInput("0-4")
+		m.input.SetValue(strconv.Itoa(selected.Priority))
+		m.inputLabel = fmt.Sprintf("Priority for %s", id)
+		m.inputSubmit = func(m Model, value string) (Model, tea.Cmd) {
+			priority, err := strconv.Atoi(value)
+			if err != nil || priority < 0 || priority > 4 {
+				m = m.withNotice(fmt.Sprintf("priority must be 0-4, got %q", value), true)
+				return m, m.clearNoticeCmd()
+			}
+			return m, m.beadActionCmd(fmt.Sprintf("Set %s to P%d", id, priority), func(ctx context.Context, client beads.Client) error {
+				return client.Update(ctx, id, beads.UpdateInput{Priority: &priority})
+			})
+		}
+		m.inputActive = true
+	case "a":
+		var items []pickerItem
+		for _, issue := range m.allIssues() {
+			if issue.ID != id && issue.Status != beads.StatusClosed {
+				items = append(items, pickerItem{ID: issue.ID, Label: issue.Title})
+			}
+		}
+		m = m.openPicker(fmt.Sprintf("%s depends on", id), items, func(m Model, dependsOn string) (Model, tea.Cmd) {
+			return m, m.beadActionCmd(fmt.Sprintf("%s now depends on %s", id, dependsOn), func(ctx context.Context, client beads.Client) error {
+				return client.AddDep(ctx, id, dependsOn, beads.DepBlocks)
+			})
+		})
+	case "e":
+		m = m.openEditForm(*selected)
+	}
+	return m, nil, true
+}
+
+// beadActionCmd runs action against the beads client and reports notice on
+// success; the resulting actionMsg reloads the lists.
+func (m Model) beadActionCmd(notice string, action func(ctx context.Context, client beads.Client) error) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		if err := action(context.Background(), client); err != nil {
+			return actionMsg{Err: err}
+		}
+		return actionMsg{Notice: notice}
+	}
+}
+
+// allIssues returns every loaded issue, in board order.
+func (m Model) allIssues() []Issue {
+	var issues []Issue
+	for _, column := range m.board {
+		issues = append(issues, column.Issues...)
+	}
+	return issues
+}
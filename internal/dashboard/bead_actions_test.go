@@ -0,0 +1,105 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// typeText presses each rune of text.
+func typeText(t *testing.T, m Model, text string) Model {
+	t.Helper()
+	for _, r := range text {
+		m, _ = press(t, m, string(r))
+	}
+	return m
+}
+
+// runAction runs the command an action returned and applies its result.
+func runAction(t *testing.T, m Model, key string) Model {
+	t.Helper()
+	m, cmd := press(t, m, key)
+	if cmd == nil {
+		t.Fatalf("%s: expected an action command", key)
+	}
+	msg, ok := cmd().(actionMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("%s: action failed: %+v", key, msg)
+	}
+	updated, _ := m.Update(msg)
+	updated, _ = updated.(Model).Update(m.loadDataCmd("")())
+	return updated.(Model)
+}
+
+func TestBeadActions(t *testing.T) {
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Task", Description: "Old", Status: beads.StatusOpen, Priority: 2, IssueType: "task"},
+		beads.Issue{ID: "cn-2", Title: "Schema", Status: beads.StatusOpen, Priority: 3, IssueType: "task"},
+	)
+	model := boardModel(t, client)
+	show := func() beads.Issue {
+		issue, err := client.Show(t.Context(), "cn-1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return issue
+	}
+
+	model = runAction(t, model, "c")
+	if show().Status != beads.StatusInProgress || model.notice != "Claimed cn-1" {
+		t.Fatalf("expected cn-1 claimed, got %s (%q)", show().Status, model.notice)
+	}
+	if selected := model.selectedBoardIssue(); selected == nil || selected.ID != "cn-1" {
+		t.Fatal("expected the claimed issue to stay selected")
+	}
+
+	model, _ = press(t, model, "p")
+	model, _ = press(t, model, "backspace")
+	model = typeText(t, model, "9")
+	model, _ = press(t, model, "enter")
+	if !model.noticeErr || show().Priority != 2 {
+		t.Fatal("expected an out of range priority to be rejected")
+	}
+	model, _ = press(t, model, "p")
+	model, _ = press(t, model, "backspace")
+	model = typeText(t, model, "0")
+	model = runAction(t, model, "enter")
+	if show().Priority != 0 {
+		t.Fatalf("expected P0, got P%d", show().Priority)
+	}
+
+	model, _ = press(t, model, "a")
+	if !model.pickerActive {
+		t.Fatal("expected the dependency picker")
+	}
+	model = typeText(t, model, "schema")
+	if matches := model.pickerMatches(); len(matches) != 1 || matches[0].ID != "cn-2" {
+		t.Fatalf("expected the picker to match cn-2, got %+v", matches)
+	}
+	model = runAction(t, model, "enter")
+	if deps := show().Dependencies; len(deps) != 1 || deps[0].DependsOnID != "cn-2" || deps[0].Type != beads.DepBlocks {
+		t.Fatalf("expected cn-1 to depend on cn-2, got %+v", deps)
+	}
+
+	model, _ = press(t, model, "e")
+	if !model.formActive {
+		t.Fatal("expected the edit form")
+	}
+	model = typeText(t, model, " two")
+	model, _ = press(t, model, "tab")
+	model = typeText(t, model, " notes")
+	model = runAction(t, model, "ctrl+s")
+	if issue := show(); issue.Title != "Task two" || issue.Description != "Old notes" {
+		t.Fatalf("expected the edits saved, got %q / %q", issue.Title, issue.Description)
+	}
+
+	model, _ = press(t, model, "x")
+	model = typeText(t, model, "shipped")
+	model = runAction(t, model, "enter")
+	if issue := show(); issue.Status != beads.StatusClosed || issue.CloseReason != "shipped" {
+		t.Fatalf("expected cn-1 closed as shipped, got %s %q", issue.Status, issue.CloseReason)
+	}
+	if selected := model.selectedBoardIssue(); selected == nil || selected.ID != "cn-1" || model.boardColumn != 3 {
+		t.Fatal("expected the selection to follow the closed issue")
+	}
+}
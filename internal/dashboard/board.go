@@ -0,0 +1,283 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// boardStatuses are the Kanban columns, in workflow order. Moving a card
+// right advances it one status.
+var boardStatuses = []string{
+	beads.StatusOpen,
+	beads.StatusInProgress,
+	beads.StatusBlocked,
+	beads.StatusClosed,
+}
+
+// boardTypes are the issue type filters f cycles through; "" shows every type.
+var boardTypes = []string{"", "epic", "feature", "task"}
+
+const (
+	boardMinColumnWidth = 24
+	boardColumnGap      = 1
+	boardCardLines      = 2
+)
+
+type boardColumn struct {
+	Status string
+	Issues []Issue
+}
+
+func buildBoard(data dataState) []boardColumn {
+	byStatus := map[string][]Issue{
+		beads.StatusOpen:       data.Ready,
+		beads.StatusInProgress: data.InProgress,
+		beads.StatusBlocked:    data.Blocked,
+		beads.StatusClosed:     data.Closed,
+	}
+	columns := make([]boardColumn, 0, len(boardStatuses))
+	for _, status := range boardStatuses {
+		columns = append(columns, boardColumn{Status: status, Issues: byStatus[status]})
+	}
+	return columns
+}
+
+// boardIssues returns the cards in column index that pass the type filter.
+func (m Model) boardIssues(index int) []Issue {
+	if index < 0 || index >= len(m.board) {
+		return nil
+	}
+	if m.boardType == "" && m.filter.Empty() {
+		return m.board[index].Issues
+	}
+	var issues []Issue
+	for _, issue := range m.board[index].Issues {
+		if m.boardType != "" && issue.IssueType != m.boardType {
+			continue
+		}
+		if m.filter.Match(issue, m.search.Me) {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+func (m Model) selectedBoardIssue() *Issue {
+	issues := m.boardIssues(m.boardColumn)
+	cursor := m.boardCursor[m.boardColumn]
+	if cursor < 0 || cursor >= len(issues) {
+		return nil
+	}
+	issue := issues[cursor]
+	return &issue
+}
+
+// setBoard replaces the board's columns, following the selected card to
+// its new column when its status changed.
+func (m *Model) setBoard(columns []boardColumn) {
+	selectedID := ""
+	if selected := m.selectedBoardIssue(); selected != nil {
+		selectedID = selected.ID
+	}
+	m.board = columns
+	if selectedID != "" && m.selectBoardIssue(selectedID) {
+		return
+	}
+	m.clampBoardCursors()
+}
+
+func (m *Model) selectBoardIssue(id string) bool {
+	for col := range m.board {
+		for i, issue := range m.boardIssues(col) {
+			if issue.ID == id {
+				m.boardColumn = col
+				m.boardCursor[col] = i
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m *Model) clampBoardCursors() {
+	for col := range m.boardCursor {
+		count := len(m.boardIssues(col))
+		if m.boardCursor[col] >= count {
+			m.boardCursor[col] = maxInt(0, count-1)
+		}
+	}
+}
+
+func (m Model) updateBoardKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "j", "down":
+		if m.boardCursor[m.boardColumn] < len(m.boardIssues(m.boardColumn))-1 {
+			m.boardCursor[m.boardColumn]++
+		}
+		return m, nil, true
+	case "k", "up":
+		if m.boardCursor[m.boardColumn] > 0 {
+			m.boardCursor[m.boardColumn]--
+		}
+		return m, nil, true
+	case "left":
+		if m.boardColumn > 0 {
+			m.boardColumn--
+		}
+		return m, nil, true
+	case "right", "l":
+		if m.boardColumn < len(boardStatuses)-1 {
+			m.boardColumn++
+		}
+		return m, nil, true
+	case "f":
+		for i, issueType := range boardTypes {
+			if issueType == m.boardType {
+				m.boardType = boardTypes[(i+1)%len(boardTypes)]
+				break
+			}
+		}
+		m.clampBoardCursors()
+		return m, nil, true
+	case "shift+left":
+		return m.moveSelectedCard(-1)
+	case "shift+right":
+		return m.moveSelectedCard(1)
+	}
+	return m, nil, false
+}
+
+// moveSelectedCard asks to move the selected card delta columns and, once
+// confirmed, sets its status through the beads client.
+func (m Model) moveSelectedCard(delta int) (Model, tea.Cmd, bool) {
+	selected := m.selectedBoardIssue()
+	target := m.boardColumn + delta
+	if selected == nil || target < 0 || target >= len(boardStatuses) {
+		return m, nil, true
+	}
+
+	id, status := selected.ID, boardStatuses[target]
+	m = m.confirm(fmt.Sprintf("Move %s to %s?", id, status), func(m Model) (Model, tea.Cmd) {
+		client := m.client
+		return m, func() tea.Msg {
+			if err := client.Update(context.Background(), id, beads.UpdateInput{Status: &status}); err != nil {
+				return actionMsg{Err: fmt.Errorf("move %s: %w", id, err)}
+			}
+			return actionMsg{Notice: fmt.Sprintf("Moved %s to %s", id, status)}
+		}
+	})
+	return m, nil, true
+}
+
+func renderBoardView(m Model, styles dashboardStyles) string {
+	width := m.width
+	height := m.height - 2
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	visible := (width + boardColumnGap) / (boardMinColumnWidth + boardColumnGap)
+	visible = maxInt(1, minInt(visible, len(boardStatuses)))
+	start := 0
+	if m.boardColumn >= visible {
+		start = m.boardColumn - visible + 1
+	}
+
+	filter := m.boardType
+	if filter == "" {
+		filter = "all"
+	}
+	header := fmt.Sprintf("Type: %s", filter)
+	if visible < len(boardStatuses) {
+		header += fmt.Sprintf("  Columns %d-%d of %d", start+1, start+visible, len(boardStatuses))
+	}
+
+	columnWidth := (width - boardColumnGap*(visible-1)) / visible
+	spare := width - boardColumnGap*(visible-1) - columnWidth*visible
+	columns := make([]string, 0, visible)
+	for col := start; col < start+visible; col++ {
+		colWidth := columnWidth
+		if col == start+visible-1 {
+			colWidth += spare
+		}
+		columns = append(columns, renderBoardColumn(m, col, colWidth, height-1, styles))
+	}
+	board := lipgloss.JoinHorizontal(lipgloss.Top, joinWithGap(boardColumnGap, columns)...)
+	return lipgloss.JoinVertical(lipgloss.Left, styles.subheader.Render(truncateASCII(header, width)), board)
+}
+
+func renderBoardColumn(m Model, col int, width int, height int, styles dashboardStyles) string {
+	innerWidth := maxInt(1, width-2)
+	active := col == m.boardColumn
+	borderStyle := styles.paneBorder
+	titleStyle := styles.columnTitleDim
+	if active {
+		borderStyle = styles.paneBorderActive
+		titleStyle = styles.columnTitle
+	}
+
+	issues := m.boardIssues(col)
+	rows := []string{
+		renderPaneTopRule(innerWidth, borderStyle),
+		renderPaneHeaderLine(fmt.Sprintf("%s (%d)", boardColumnTitle(boardStatuses[col]), len(issues)), innerWidth, titleStyle, borderStyle),
+	}
+
+	var lines []string
+	if len(issues) == 0 {
+		lines = append(lines, styles.dimText.Render("(none)"))
+	}
+	cursorLine := 0
+	for i, issue := range issues {
+		style := styles.drawerItem
+		if active && i == m.boardCursor[col] {
+			style = styles.drawerItemSelected
+			cursorLine = len(lines)
+		}
+		meta := fmt.Sprintf("P%d %s %s", issue.Priority, issue.IssueType, issue.ID)
+		lines = append(lines,
+			style.Render(padASCII(truncateASCII(meta, innerWidth), innerWidth)),
+			style.Render(padASCII(truncateASCII("  "+issue.Title, innerWidth), innerWidth)),
+		)
+	}
+
+	listHeight := maxInt(1, height-len(rows)-1)
+	start := 0
+	if cursorLine+boardCardLines > listHeight {
+		start = minInt(cursorLine, cursorLine+boardCardLines-listHeight)
+	}
+	for i := start; i < len(lines) && i < start+listHeight; i++ {
+		rows = append(rows, renderPaneRawRow(lines[i], innerWidth, borderStyle))
+	}
+	for len(rows) < height-1 {
+		rows = append(rows, renderPaneRawRow("", innerWidth, borderStyle))
+	}
+	rows = append(rows, renderPaneBottomRule(innerWidth, borderStyle))
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func boardColumnTitle(status string) string {
+	switch status {
+	case beads.StatusOpen:
+		return "Open"
+	case beads.StatusInProgress:
+		return "In Progress"
+	case beads.StatusBlocked:
+		return "Blocked"
+	case beads.StatusClosed:
+		return "Closed"
+	default:
+		return status
+	}
+}
+
+func padASCII(value string, width int) string {
+	if len(value) >= width {
+		return value
+	}
+	return value + strings.Repeat(" ", width-len(value))
+}
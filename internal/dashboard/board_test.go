@@ -0,0 +1,76 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+func boardModel(t *testing.T, client *beads.Fake) Model {
+	t.Helper()
+	model := NewModel(client, 0, 0)
+	updated, _ := model.Update(model.loadDataCmd("")())
+	model = updated.(Model)
+	model.activeView = ViewBoard
+	return model
+}
+
+func TestBoardColumnsAndTypeFilter(t *testing.T) {
+	model := boardModel(t, beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Epic", Status: beads.StatusOpen, IssueType: "epic"},
+		beads.Issue{ID: "cn-2", Title: "Task", Status: beads.StatusOpen, IssueType: "task"},
+		beads.Issue{ID: "cn-3", Title: "Busy", Status: beads.StatusInProgress, IssueType: "task"},
+		beads.Issue{ID: "cn-4", Title: "Stuck", Status: beads.StatusBlocked, IssueType: "feature"},
+		beads.Issue{ID: "cn-5", Title: "Done", Status: beads.StatusClosed, IssueType: "task"},
+	))
+
+	counts := []int{2, 1, 1, 1}
+	for col, want := range counts {
+		if got := len(model.boardIssues(col)); got != want {
+			t.Fatalf("column %s: expected %d cards, got %d", boardStatuses[col], want, got)
+		}
+	}
+
+	model, _ = press(t, model, "f")
+	if model.boardType != "epic" || len(model.boardIssues(0)) != 1 || len(model.boardIssues(1)) != 0 {
+		t.Fatalf("expected only epics, got type %q", model.boardType)
+	}
+	for range boardTypes[1:] {
+		model, _ = press(t, model, "f")
+	}
+	if model.boardType != "" {
+		t.Fatalf("expected the filter to cycle back to all, got %q", model.boardType)
+	}
+}
+
+func TestBoardMoveCardConfirms(t *testing.T) {
+	client := beads.NewFake(beads.Issue{ID: "cn-1", Title: "Task", Status: beads.StatusOpen, IssueType: "task"})
+	model := boardModel(t, client)
+
+	model, _ = press(t, model, "shift+right")
+	if !model.confirmActive {
+		t.Fatal("expected a confirmation before moving")
+	}
+	model, cmd := press(t, model, "n")
+	if model.confirmActive || cmd != nil {
+		t.Fatal("expected n to cancel the move")
+	}
+
+	model, _ = press(t, model, "shift+right")
+	model, cmd = press(t, model, "y")
+	if cmd == nil {
+		t.Fatal("expected an update command")
+	}
+	if msg := cmd().(actionMsg); msg.Err != nil {
+		t.Fatalf("move: %v", msg.Err)
+	}
+	if issue, _ := client.Show(t.Context(), "cn-1"); issue.Status != beads.StatusInProgress {
+		t.Fatalf("expected in_progress, got %s", issue.Status)
+	}
+
+	updated, _ := model.Update(model.loadDataCmd("")())
+	model = updated.(Model)
+	if model.boardColumn != 1 || model.selectedBoardIssue().ID != "cn-1" {
+		t.Fatalf("expected the selection to follow the card, got column %d", model.boardColumn)
+	}
+}
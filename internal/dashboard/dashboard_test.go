@@ -1,34 +1,88 @@
 package dashboard
 
-import "testing"
-
-func TestParseBdIssues(t *testing.T) {
-	input := []byte(`[
-		{
-			"id": "cn-1",
-			"title": "Test issue",
-			"description": "Something to do",
-			"status": "open",
-			"priority": 2,
-			"issue_type": "task",
-			"owner": "tester@example.com",
-			"updated_at": "2026-02-01T10:00:00Z",
-			"created_at": "2026-01-31T10:00:00Z"
-		}
-	]`)
-
-	issues, err := parseBdIssues(input)
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+func TestFetchDashboardData(t *testing.T) {
+	updated := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Low priority", Status: beads.StatusOpen, Priority: 3, IssueType: "task"},
+		beads.Issue{ID: "cn-2", Title: "Urgent", Status: beads.StatusOpen, Priority: 0, IssueType: "task", UpdatedAt: updated},
+		beads.Issue{ID: "cn-3", Title: "Doing", Status: beads.StatusInProgress, Priority: 2, IssueType: "task"},
+		beads.Issue{ID: "cn-4", Title: "Deleted", Status: beads.StatusTombstone, IssueType: "task"},
+	)
+
+	data, err := fetchDashboardData(context.Background(), client, 0)
 	if err != nil {
-		t.Fatalf("parseBdIssues error: %v", err)
+		t.Fatalf("fetchDashboardData error: %v", err)
+	}
+	if len(data.Ready) != 2 || data.Ready[0].ID != "cn-2" {
+		t.Fatalf("expected ready issues ordered by priority, got %+v", data.Ready)
+	}
+	if data.Ready[0].UpdatedAt != updated.Format(time.RFC3339) {
+		t.Fatalf("expected formatted updated time, got %s", data.Ready[0].UpdatedAt)
+	}
+	if len(data.InProgress) != 1 || len(data.Closed) != 0 {
+		t.Fatalf("unexpected columns: %+v", data)
+	}
+	if data.Status.Summary.TotalIssues != 3 || data.Status.Summary.TombstoneIssues != 1 {
+		t.Fatalf("unexpected summary %+v", data.Status.Summary)
+	}
+
+	limited, err := fetchDashboardData(context.Background(), client, 1)
+	if err != nil {
+		t.Fatalf("fetchDashboardData error: %v", err)
+	}
+	if len(limited.Ready) != 1 {
+		t.Fatalf("expected limit to apply, got %d ready issues", len(limited.Ready))
+	}
+}
+
+type versionedFake struct {
+	*beads.Fake
+	version string
+}
+
+func (v versionedFake) Version() string {
+	return v.version
+}
+
+func TestLoadDashboardDataSkipsUnchangedData(t *testing.T) {
+	client := versionedFake{Fake: beads.NewFake(beads.Issue{ID: "cn-1", Title: "Task", Status: beads.StatusOpen}), version: "v1"}
+
+	model := NewModel(client, 0, 0)
+
+	msg := model.loadDataCmd("v1")().(dataMsg)
+	if !msg.Unchanged {
+		t.Fatalf("expected unchanged data to be skipped, got %+v", msg)
 	}
-	if len(issues) != 1 {
-		t.Fatalf("expected 1 issue, got %d", len(issues))
+
+	msg = model.loadDataCmd("v0")().(dataMsg)
+	if msg.Unchanged || msg.Version != "v1" || len(msg.Data.Ready) != 1 {
+		t.Fatalf("expected a reload at version v1, got %+v", msg)
+	}
+
+	msg = model.loadDataCmd("")().(dataMsg)
+	if msg.Unchanged {
+		t.Fatal("expected an empty version to force a reload")
 	}
-	issue := issues[0]
-	if issue.ID != "cn-1" {
-		t.Fatalf("expected id cn-1, got %s", issue.ID)
+}
+
+func TestFilesChangedReloadsFromLastVersion(t *testing.T) {
+	client := versionedFake{Fake: beads.NewFake(beads.Issue{ID: "cn-1", Title: "Task", Status: beads.StatusOpen}), version: "v1"}
+	model := NewModel(client, 0, 0)
+
+	updated, _ := model.Update(model.loadDataCmd("")())
+	_, cmd := updated.(Model).Update(filesChangedMsg{})
+	if cmd == nil {
+		t.Fatal("expected a reload command")
 	}
-	if issue.Priority != 2 {
-		t.Fatalf("expected priority 2, got %d", issue.Priority)
+	if msg := cmd().(dataMsg); !msg.Unchanged {
+		t.Fatalf("expected the reload to skip unchanged beads, got %+v", msg)
 	}
 }
@@ -1,98 +1,81 @@
 package dashboard
 
 import (
-	"bufio"
-	"encoding/json"
+	"context"
 	"fmt"
-	"os"
-	"os/exec"
-	"path/filepath"
 	"sort"
-	"strconv"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/rikurb8/carnie/internal/bd"
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
 )
 
-func loadDashboardDataCmd(limit int) tea.Cmd {
+// loadDataCmd loads the dashboard data. Beads from clients that report a
+// data version are only re-read when it differs from version; pass "" to
+// force a reload. Work orders are cheap to list and always reloaded.
+func (m Model) loadDataCmd(version string) tea.Cmd {
 	return func() tea.Msg {
-		data, err := fetchDashboardData(limit)
-		return dataMsg{Data: data, Err: err}
+		return m.loadData(context.Background(), version)
 	}
 }
 
-func fetchDashboardData(limit int) (dataState, error) {
-	statusOutput, err := bd.RunJSON("status", "--json")
-	if err != nil {
-		return dataState{}, err
+func (m Model) loadData(ctx context.Context, version string) dataMsg {
+	var msg dataMsg
+	if versioned, ok := m.client.(beads.Versioned); ok {
+		msg.Version = versioned.Version()
+		msg.Unchanged = version != "" && msg.Version == version
 	}
-
-	var status bd.Status
-	if err := json.Unmarshal(statusOutput, &status); err != nil {
-		return dataState{}, fmt.Errorf("parse bd status: %w", err)
+	if !msg.Unchanged {
+		data, err := fetchDashboardData(ctx, m.client, m.limit)
+		if err != nil {
+			return dataMsg{Err: err}
+		}
+		msg.Data = data
 	}
-
-	ready, inProgress, blocked, closed, err := fetchIssuesFromBeads(limit)
-	if err != nil {
-		return dataState{}, err
+	if m.workOrders.Store != nil {
+		orders, err := m.workOrders.Store.List(ctx, workorder.ListOptions{})
+		if err != nil {
+			return dataMsg{Err: err}
+		}
+		msg.Data.WorkOrders = orders
 	}
-
-	return dataState{
-		Status:     status,
-		Ready:      ready,
-		InProgress: inProgress,
-		Blocked:    blocked,
-		Closed:     closed,
-	}, nil
+	return msg
 }
 
-func fetchIssuesFromBeads(limit int) ([]Issue, []Issue, []Issue, []Issue, error) {
-	cwd, err := os.Getwd()
+func fetchDashboardData(ctx context.Context, client beads.Client, limit int) (dataState, error) {
+	status, err := client.Status(ctx)
 	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("get working directory: %w", err)
-	}
-	root, err := findBeadsRoot(cwd)
-	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("find beads: %w", err)
-	}
-	if err := exportBeadsJSONL(root); err != nil {
-		return nil, nil, nil, nil, err
+		return dataState{}, err
 	}
-	issues, err := loadIssues(root)
+
+	issues, err := client.List(ctx, beads.ListOptions{})
 	if err != nil {
-		return nil, nil, nil, nil, fmt.Errorf("load beads issues: %w", err)
+		return dataState{}, fmt.Errorf("load beads issues: %w", err)
 	}
 
-	readyIssues := filterIssuesByStatus(issues, "open")
-	inProgressIssues := filterIssuesByStatus(issues, "in_progress")
-	blockedIssues := filterIssuesByStatus(issues, "blocked")
-	closedIssues := filterIssuesByStatus(issues, "closed")
+	readyIssues := filterIssuesByStatus(issues, beads.StatusOpen)
+	inProgressIssues := filterIssuesByStatus(issues, beads.StatusInProgress)
+	blockedIssues := filterIssuesByStatus(issues, beads.StatusBlocked)
+	closedIssues := filterIssuesByStatus(issues, beads.StatusClosed)
 
 	sort.SliceStable(readyIssues, func(i, j int) bool { return readyIssues[i].Priority < readyIssues[j].Priority })
 	sort.SliceStable(inProgressIssues, func(i, j int) bool { return inProgressIssues[i].Priority < inProgressIssues[j].Priority })
 	sort.SliceStable(blockedIssues, func(i, j int) bool { return blockedIssues[i].Priority < blockedIssues[j].Priority })
 	sort.SliceStable(closedIssues, func(i, j int) bool { return closedIssues[i].UpdatedAt.After(closedIssues[j].UpdatedAt) })
 
-	readyIssues = applyIssueLimit(readyIssues, limit)
-	inProgressIssues = applyIssueLimit(inProgressIssues, limit)
-	blockedIssues = applyIssueLimit(blockedIssues, limit)
-	closedIssues = applyIssueLimit(closedIssues, limit)
-
-	return mapBeadsIssues(readyIssues), mapBeadsIssues(inProgressIssues), mapBeadsIssues(blockedIssues), mapBeadsIssues(closedIssues), nil
-}
-
-func exportBeadsJSONL(root string) error {
-	output := filepath.Join(root, beadsDir, beadsIssuesFile)
-	command := exec.Command("bd", "export", "-o", output, "-q")
-	if err := command.Run(); err != nil {
-		return fmt.Errorf("bd export failed: %w", err)
-	}
-	return nil
+	return dataState{
+		Status:     status,
+		Issues:     issues,
+		Ready:      mapBeadsIssues(applyIssueLimit(readyIssues, limit)),
+		InProgress: mapBeadsIssues(applyIssueLimit(inProgressIssues, limit)),
+		Blocked:    mapBeadsIssues(applyIssueLimit(blockedIssues, limit)),
+		Closed:     mapBeadsIssues(applyIssueLimit(closedIssues, limit)),
+	}, nil
 }
 
-func filterIssuesByStatus(issues []beadIssue, status string) []beadIssue {
-	filtered := make([]beadIssue, 0, len(issues))
+func filterIssuesByStatus(issues []beads.Issue, status string) []beads.Issue {
+	filtered := make([]beads.Issue, 0, len(issues))
 	for _, issue := range issues {
 		if issue.Status != status {
 			continue
@@ -102,14 +85,14 @@ func filterIssuesByStatus(issues []beadIssue, status string) []beadIssue {
 	return filtered
 }
 
-func applyIssueLimit(issues []beadIssue, limit int) []beadIssue {
+func applyIssueLimit(issues []beads.Issue, limit int) []beads.Issue {
 	if limit <= 0 || len(issues) <= limit {
 		return issues
 	}
 	return issues[:limit]
 }
 
-func mapBeadsIssues(issues []beadIssue) []Issue {
+func mapBeadsIssues(issues []beads.Issue) []Issue {
 	output := make([]Issue, 0, len(issues))
 	for _, issue := range issues {
 		deps := make([]Dependency, 0, len(issue.Dependencies))
@@ -124,6 +107,7 @@ func mapBeadsIssues(issues []beadIssue) []Issue {
 			Priority:     issue.Priority,
 			IssueType:    issue.IssueType,
 			Owner:        issue.Owner,
+			Assignee:     issue.Assignee,
 			UpdatedAt:    issue.UpdatedAt.Format(time.RFC3339),
 			CreatedAt:    issue.CreatedAt.Format(time.RFC3339),
 			Dependencies: deps,
@@ -132,25 +116,6 @@ func mapBeadsIssues(issues []beadIssue) []Issue {
 	return output
 }
 
-func fetchIssues(args []string, limit int) ([]Issue, error) {
-	if limit > 0 {
-		args = append(args, "--limit", strconv.Itoa(limit))
-	}
-	output, err := bd.RunJSON(args...)
-	if err != nil {
-		return nil, err
-	}
-	return parseBdIssues(output)
-}
-
-func parseBdIssues(data []byte) ([]Issue, error) {
-	var issues []Issue
-	if err := json.Unmarshal(data, &issues); err != nil {
-		return nil, fmt.Errorf("parse bd issues: %w", err)
-	}
-	return issues, nil
-}
-
 func orderIssuesWithParents(issues []Issue) ([]Issue, map[string]string, map[string]int) {
 	ordered := make([]Issue, 0, len(issues))
 	parentByID := make(map[string]string)
@@ -253,84 +218,6 @@ func containsID(ids []string, id string) bool {
 	return false
 }
 
-const (
-	beadsDir        = ".beads"
-	beadsIssuesFile = "issues.jsonl"
-)
-
-type beadIssue struct {
-	ID           string           `json:"id"`
-	Title        string           `json:"title"`
-	Description  string           `json:"description"`
-	Status       string           `json:"status"`
-	Priority     int              `json:"priority"`
-	IssueType    string           `json:"issue_type"`
-	Owner        string           `json:"owner"`
-	CreatedAt    time.Time        `json:"created_at"`
-	UpdatedAt    time.Time        `json:"updated_at"`
-	Dependencies []beadDependency `json:"dependencies,omitempty"`
-}
-
-type beadDependency struct {
-	IssueID     string    `json:"issue_id"`
-	DependsOnID string    `json:"depends_on_id"`
-	Type        string    `json:"type"`
-	CreatedAt   time.Time `json:"created_at"`
-}
-
-func loadIssues(rootDir string) ([]beadIssue, error) {
-	path := filepath.Join(rootDir, beadsDir, beadsIssuesFile)
-	return loadIssuesFromFile(path)
-}
-
-func loadIssuesFromFile(path string) ([]beadIssue, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return nil, fmt.Errorf("open issues file: %w", err)
-	}
-	defer file.Close()
-
-	var issues []beadIssue
-	scanner := bufio.NewScanner(file)
-
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-
-		var issue beadIssue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			return nil, fmt.Errorf("parse issue at line %d: %w", lineNum, err)
-		}
-		issues = append(issues, issue)
-	}
-
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("read issues file: %w", err)
-	}
-
-	return issues, nil
-}
-
-func findBeadsRoot(startDir string) (string, error) {
-	dir := startDir
-	for {
-		beadsPath := filepath.Join(dir, beadsDir)
-		if info, err := os.Stat(beadsPath); err == nil && info.IsDir() {
-			return dir, nil
-		}
-
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			return "", fmt.Errorf("no %s directory found", beadsDir)
-		}
-		dir = parent
-	}
-}
-
 func buildDrawerEntries(column issueColumn, collapsed map[string]bool) []drawerEntry {
 	if len(column.Issues) == 0 {
 		return nil
@@ -0,0 +1,357 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// detailMarkdown describes issue as markdown: its description, the issues
+// it depends on and blocks, its children, linked work orders and when it was
+// created, updated and closed.
+func (m Model) detailMarkdown(issue Issue) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s %s\n\n", issue.ID, issue.Title)
+	meta := []string{"**Status** " + issue.Status, fmt.Sprintf("**Priority** P%d", issue.Priority)}
+	if issue.IssueType != "" {
+		meta = append(meta, "**Type** "+issue.IssueType)
+	}
+	if issue.Owner != "" {
+		meta = append(meta, "**Owner** "+issue.Owner)
+	}
+	if issue.Assignee != "" && issue.Assignee != issue.Owner {
+		meta = append(meta, "**Assignee** "+issue.Assignee)
+	}
+	b.WriteString(strings.Join(meta, " · ") + "\n\n")
+
+	b.WriteString("## Description\n\n")
+	if description := strings.TrimSpace(issue.Description); description != "" {
+		b.WriteString(description + "\n\n")
+	} else {
+		b.WriteString("_No description._\n\n")
+	}
+
+	var blockedBy, blocks, children []string
+	if m.graph != nil {
+		blockedBy = m.graph.Blocks[issue.ID]
+		for _, other := range m.graph.Issues {
+			for _, blocker := range m.graph.Blocks[other.ID] {
+				if blocker == issue.ID {
+					blocks = append(blocks, other.ID)
+				}
+			}
+		}
+		children = m.graph.Children[issue.ID]
+	}
+	b.WriteString("## Dependencies\n\n")
+	b.WriteString(m.detailIssueList("Blocked by", blockedBy))
+	b.WriteString(m.detailIssueList("Blocks", blocks))
+
+	fmt.Fprintf(&b, "## Child Tasks (%d)\n\n", len(children))
+	b.WriteString(m.detailIssueList("", children))
+
+	b.WriteString("## Work Orders\n\n")
+	linked := 0
+	for _, order := range m.orders {
+		if order.BeadID != issue.ID {
+			continue
+		}
+		linked++
+		line := fmt.Sprintf("- #%d [%s] %s", order.ID, order.Status, order.Title)
+		if order.Assignee != "" {
+			line += " (" + order.Assignee + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+	if linked == 0 {
+		b.WriteString("_None._\n")
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Timestamps\n\n")
+	if issue.CreatedAt != "" {
+		b.WriteString("- Created " + formatTimestamp(issue.CreatedAt) + "\n")
+	}
+	if issue.UpdatedAt != "" {
+		b.WriteString("- Updated " + formatTimestamp(issue.UpdatedAt) + "\n")
+	}
+	if m.graph != nil {
+		if full, ok := m.graph.ByID[issue.ID]; ok && full.ClosedAt != nil {
+			closed := "- Closed " + full.ClosedAt.Format("Jan 02 15:04")
+			if full.CloseReason != "" {
+				closed += ": " + full.CloseReason
+			}
+			b.WriteString(closed + "\n")
+		}
+	}
+	return b.String()
+}
+
+// detailIssueList lists ids with their status and title under an optional
+// subheading.
+func (m Model) detailIssueList(label string, ids []string) string {
+	var b strings.Builder
+	if label != "" {
+		fmt.Fprintf(&b, "### %s\n\n", label)
+	}
+	if len(ids) == 0 {
+		b.WriteString("_None._\n\n")
+		return b.String()
+	}
+	sorted := append([]string{}, ids...)
+	sort.Strings(sorted)
+	for _, id := range sorted {
+		status, title := "", m.beadTitles[id]
+		if m.graph != nil {
+			if issue, ok := m.graph.ByID[id]; ok {
+				status, title = issue.Status, issue.Title
+			}
+		}
+		fmt.Fprintf(&b, "- `%s` [%s] %s\n", id, status, title)
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// detailSize is the viewport size of the detail panel, or zeros when the
+// terminal is too narrow to show it next to the list.
+func (m Model) detailSize() (int, int) {
+	drawerWidth, bodyHeight, _, _ := drawerLayout(m.width, m.height)
+	gap := 2
+	if m.width <= 0 || drawerWidth+gap >= m.width {
+		return 0, 0
+	}
+	return m.width - drawerWidth - gap, maxInt(0, bodyHeight-1)
+}
+
+// syncDetail sizes the detail viewport and fills it with the selected issue,
+// scrolling back to the top when the selection changed.
+func (m *Model) syncDetail() {
+	width, height := m.detailSize()
+	m.detail.Width, m.detail.Height = width, height
+	content := "Select an issue to see details."
+	id := ""
+	if selected := m.selectedIssue(); selected != nil {
+		id = selected.ID
+		content = renderMarkdown(m.detailMarkdown(*selected), width, newDashboardStyles())
+	}
+	if id != m.detailID {
+		m.detailID = id
+		m.detail.GotoTop()
+	}
+	m.detail.SetContent(content)
+}
+
+// updateDetailKey toggles focus between the Tasks list and the detail panel
+// with enter; while the detail is focused the movement keys scroll it.
+func (m Model) updateDetailKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.activeView != ViewTasks {
+		return m, nil, false
+	}
+	if width, _ := m.detailSize(); width == 0 {
+		m.detailFocus = false
+		return m, nil, false
+	}
+	key := msg.String()
+	if key == "enter" || (key == "esc" && m.detailFocus) {
+		m.detailFocus = key == "enter" && !m.detailFocus
+		return m, nil, true
+	}
+	if !m.detailFocus {
+		return m, nil, false
+	}
+	m.syncDetail()
+	switch key {
+	case "j", "down":
+		m.detail.LineDown(1)
+	case "k", "up":
+		m.detail.LineUp(1)
+	case "pgdown", " ", "ctrl+f":
+		m.detail.ViewDown()
+	case "pgup", "ctrl+b":
+		m.detail.ViewUp()
+	case "ctrl+d":
+		m.detail.HalfViewDown()
+	case "ctrl+u":
+		m.detail.HalfViewUp()
+	case "home":
+		m.detail.GotoTop()
+	case "end", "G":
+		m.detail.GotoBottom()
+	default:
+		return m, nil, false
+	}
+	return m, nil, true
+}
+
+func renderDetailPanel(m Model, width int, height int, styles dashboardStyles) string {
+	m.syncDetail()
+	m.detail.Width = width
+	title := "Issue Details"
+	if m.detailFocus {
+		title = "Issue Details (enter: back to list)"
+	}
+	if m.detail.TotalLineCount() > m.detail.Height {
+		title += fmt.Sprintf("  %d%%", int(m.detail.ScrollPercent()*100))
+	}
+	return renderPanel(title, strings.Split(m.detail.View(), "\n"), width, height, styles)
+}
+
+// renderMarkdown renders the markdown the dashboard needs: headings, bullet
+// and numbered lists, block quotes, fenced code and **bold**, _italic_ and
+// `code` spans. Paragraphs and list items wrap to width; code is cut off.
+func renderMarkdown(markdown string, width int, styles dashboardStyles) string {
+	if width <= 0 {
+		width = 80
+	}
+	var out []string
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) == 0 {
+			return
+		}
+		for _, line := range wrapLines(strings.Join(paragraph, " "), width) {
+			out = append(out, renderInline(line, styles))
+		}
+		paragraph = nil
+	}
+
+	inCode := false
+	for _, raw := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(raw)
+		if strings.HasPrefix(trimmed, "```") {
+			flush()
+			inCode = !inCode
+			continue
+		}
+		if inCode {
+			out = append(out, styles.mdCode.Render(truncateASCII("  "+strings.ReplaceAll(raw, "\t", "    "), width)))
+			continue
+		}
+		switch {
+		case trimmed == "":
+			flush()
+			if len(out) > 0 && out[len(out)-1] != "" {
+				out = append(out, "")
+			}
+		case headingLevel(trimmed) > 0:
+			flush()
+			level := headingLevel(trimmed)
+			text := strings.TrimSpace(trimmed[level:])
+			style := styles.panelTitle
+			if level > 1 {
+				style = styles.mdHeading
+			}
+			for _, line := range wrapLines(text, width) {
+				out = append(out, style.Render(line))
+			}
+		case strings.HasPrefix(trimmed, "> "):
+			flush()
+			for _, line := range wrapLines(strings.TrimPrefix(trimmed, "> "), width-2) {
+				out = append(out, styles.dimText.Render("│ "+line))
+			}
+		case listMarker(trimmed) != "":
+			flush()
+			marker := listMarker(trimmed)
+			indent := strings.Repeat(" ", (len(raw)-len(strings.TrimLeft(raw, " \t")))/2*2)
+			bullet := marker
+			if marker == "- " || marker == "* " || marker == "+ " {
+				bullet = "• "
+			}
+			text := strings.TrimPrefix(trimmed, marker)
+			hang := strings.Repeat(" ", len(indent)+lipgloss.Width(bullet))
+			for i, line := range wrapLines(text, width-len(hang)) {
+				prefix := indent + bullet
+				if i > 0 {
+					prefix = hang
+				}
+				out = append(out, prefix+renderInline(line, styles))
+			}
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+	for len(out) > 0 && out[len(out)-1] == "" {
+		out = out[:len(out)-1]
+	}
+	return strings.Join(out, "\n")
+}
+
+// headingLevel counts the #s that start an ATX heading, or returns 0.
+func headingLevel(line string) int {
+	level := len(line) - len(strings.TrimLeft(line, "#"))
+	if level == 0 || level > 6 || (len(line) > level && line[level] != ' ') {
+		return 0
+	}
+	return level
+}
+
+// listMarker returns the bullet or number that starts a list item, or "".
+func listMarker(line string) string {
+	for _, bullet := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, bullet) {
+			return bullet
+		}
+	}
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && strings.HasPrefix(line[digits:], ". ") {
+		return line[:digits+2]
+	}
+	return ""
+}
+
+// renderInline styles **bold**, _italic_ and `code` spans. Unclosed markers
+// are left as they are, and _ only counts at word boundaries so snake_case
+// survives.
+func renderInline(line string, styles dashboardStyles) string {
+	spans := []struct {
+		marker string
+		style  lipgloss.Style
+	}{
+		{"`", styles.mdCode},
+		{"**", lipgloss.NewStyle().Bold(true)},
+		{"_", lipgloss.NewStyle().Italic(true)},
+	}
+	var b strings.Builder
+	for i := 0; i < len(line); {
+		matched := false
+		for _, span := range spans {
+			if !strings.HasPrefix(line[i:], span.marker) {
+				continue
+			}
+			start := i + len(span.marker)
+			end := strings.Index(line[start:], span.marker)
+			if end <= 0 {
+				continue
+			}
+			close := start + end + len(span.marker)
+			if span.marker == "_" && (isWordByte(line, i-1) || isWordByte(line, close)) {
+				continue
+			}
+			b.WriteString(span.style.Render(line[start : start+end]))
+			i = close
+			matched = true
+			break
+		}
+		if !matched {
+			b.WriteByte(line[i])
+			i++
+		}
+	}
+	return b.String()
+}
+
+func isWordByte(line string, i int) bool {
+	if i < 0 || i >= len(line) {
+		return false
+	}
+	c := line[i]
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
@@ -0,0 +1,97 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	styles := newDashboardStyles()
+	out := renderMarkdown("# Title\n\nSome **bold** text about in_progress\nwork.\n\n- one\n  - nested\n2. two\n\n```\ncode line\n```\n#5 is not a heading", 50, styles)
+	plain := stripANSI(out)
+	want := []string{
+		"Title",
+		"",
+		"Some bold text about in_progress work.",
+		"",
+		"• one",
+		"  • nested",
+		"2. two",
+		"",
+		"  code line",
+		"#5 is not a heading",
+	}
+	if got := strings.Split(plain, "\n"); strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected rendering:\n%s", plain)
+	}
+
+	wrapped := strings.Split(stripANSI(renderMarkdown("- "+strings.Repeat("word ", 10), 20, styles)), "\n")
+	if len(wrapped) < 3 || !strings.HasPrefix(wrapped[1], "  word") {
+		t.Fatalf("expected list items to wrap with a hanging indent, got %q", wrapped)
+	}
+}
+
+func TestDetailPanelScrollsWhenFocused(t *testing.T) {
+	blocks := []beads.Dependency{{IssueID: "cn-2", DependsOnID: "cn-1", Type: beads.DepBlocks}}
+	model := boardModel(t, beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Login", Description: strings.Repeat("A long paragraph about logging in.\n\n", 20), Status: beads.StatusOpen, IssueType: "feature"},
+		beads.Issue{ID: "cn-2", Title: "Signup", Status: beads.StatusOpen, Priority: 2, IssueType: "feature", Dependencies: blocks},
+	))
+	updated, _ := model.Update(tea.WindowSizeMsg{Width: 120, Height: 20})
+	model = updated.(Model)
+	model.activeView = ViewTasks
+
+	details := model.detailMarkdown(*model.selectedIssue())
+	for _, section := range []string{"## Description", "## Dependencies", "### Blocks", "`cn-2` [open] Signup", "## Child Tasks (0)", "## Work Orders", "## Timestamps"} {
+		if !strings.Contains(details, section) {
+			t.Fatalf("expected %q in the details:\n%s", section, details)
+		}
+	}
+
+	model, _ = press(t, model, "enter")
+	if !model.detailFocus {
+		t.Fatal("expected enter to focus the detail panel")
+	}
+	model, _ = press(t, model, "j")
+	model, _ = press(t, model, "j")
+	if model.detail.YOffset != 2 || model.selectedIssue().ID != "cn-1" {
+		t.Fatalf("expected j to scroll the detail, got offset %d on %s", model.detail.YOffset, model.selectedIssue().ID)
+	}
+	if !strings.Contains(renderDetailPanel(model, 80, 18, newDashboardStyles()), "%") {
+		t.Fatal("expected the panel title to show the scroll position")
+	}
+
+	model, _ = press(t, model, "esc")
+	model, _ = press(t, model, "j")
+	if model.detailFocus || model.selectedIssue().ID != "cn-2" {
+		t.Fatal("expected esc to return j/k to the list")
+	}
+	model.syncDetail()
+	if model.detail.YOffset != 0 {
+		t.Fatalf("expected a new selection to start at the top, got %d", model.detail.YOffset)
+	}
+}
+
+// stripANSI drops escape sequences and trailing padding from each line.
+func stripANSI(value string) string {
+	lines := strings.Split(value, "\n")
+	for i, line := range lines {
+		var b strings.Builder
+		escape := false
+		for _, r := range line {
+			switch {
+			case escape:
+				escape = !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z')
+			case r == '\x1b':
+				escape = true
+			default:
+				b.WriteRune(r)
+			}
+		}
+		lines[i] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.Join(lines, "\n")
+}
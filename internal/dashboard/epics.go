@@ -0,0 +1,297 @@
+package dashboard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// epicLines is how many lines an epic takes in the Epics list.
+const epicLines = 4
+
+// epicNode is one row of a drilled-in epic's hierarchy.
+type epicNode struct {
+	Rollup beads.Rollup
+	Depth  int
+}
+
+// epics returns the epics with their rollups, open ones first by priority
+// and closed ones last.
+func (m Model) epics() []beads.Rollup {
+	if m.graph == nil {
+		return nil
+	}
+	var rollups []beads.Rollup
+	for _, issue := range m.graph.Issues {
+		if issue.IssueType != "epic" {
+			continue
+		}
+		if rollup, ok := m.graph.Rollup(issue.ID); ok {
+			rollups = append(rollups, rollup)
+		}
+	}
+	sort.SliceStable(rollups, func(i, j int) bool {
+		a, b := rollups[i].Issue, rollups[j].Issue
+		if a.IsClosed() != b.IsClosed() {
+			return !a.IsClosed()
+		}
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		return a.ID < b.ID
+	})
+	return rollups
+}
+
+// epicTree flattens the hierarchy below the drilled-in epic, depth first.
+func (m Model) epicTree() []epicNode {
+	if m.graph == nil || m.epicRoot == "" {
+		return nil
+	}
+	root, ok := m.graph.Rollup(m.epicRoot)
+	if !ok {
+		return nil
+	}
+	var nodes []epicNode
+	var walk func(rollup beads.Rollup, depth int)
+	walk = func(rollup beads.Rollup, depth int) {
+		nodes = append(nodes, epicNode{Rollup: rollup, Depth: depth})
+		for _, child := range rollup.Children {
+			walk(child, depth+1)
+		}
+	}
+	walk(root, 0)
+	return nodes
+}
+
+// selectedEpicIssue is the selected epic, or the selected node of the
+// drilled-in hierarchy.
+func (m Model) selectedEpicIssue() *Issue {
+	var issue beads.Issue
+	if m.epicRoot != "" {
+		nodes := m.epicTree()
+		if m.epicNodeCursor < 0 || m.epicNodeCursor >= len(nodes) {
+			return nil
+		}
+		issue = nodes[m.epicNodeCursor].Rollup.Issue
+	} else {
+		epics := m.epics()
+		if m.epicCursor < 0 || m.epicCursor >= len(epics) {
+			return nil
+		}
+		issue = epics[m.epicCursor].Issue
+	}
+	mapped := mapBeadsIssues([]beads.Issue{issue})
+	return &mapped[0]
+}
+
+func (m Model) updateEpicsKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	count := len(m.epics())
+	cursor := &m.epicCursor
+	if m.epicRoot != "" {
+		count = len(m.epicTree())
+		cursor = &m.epicNodeCursor
+	}
+	switch msg.String() {
+	case "j", "down":
+		if *cursor < count-1 {
+			*cursor++
+		}
+		return m, nil, true
+	case "k", "up":
+		if *cursor > 0 {
+			*cursor--
+		}
+		return m, nil, true
+	case "enter":
+		if m.epicRoot == "" && m.epicCursor < count {
+			m.epicRoot = m.epics()[m.epicCursor].Issue.ID
+			m.epicNodeCursor = 0
+		}
+		return m, nil, true
+	case "esc", "backspace":
+		if m.epicRoot == "" {
+			return m, nil, false
+		}
+		m.epicRoot = ""
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// clampEpicCursors keeps the cursors on an epic after a reload and leaves
+// the hierarchy when its epic is gone.
+func (m *Model) clampEpicCursors() {
+	if m.epicRoot != "" && (m.graph == nil || m.graph.ByID[m.epicRoot].ID == "") {
+		m.epicRoot = ""
+	}
+	m.epicCursor = minInt(m.epicCursor, maxInt(0, len(m.epics())-1))
+	m.epicNodeCursor = minInt(m.epicNodeCursor, maxInt(0, len(m.epicTree())-1))
+}
+
+// epicForecast describes when the rollup's remaining issues should be done,
+// at the rate its descendants were closed over the last two weeks.
+func epicForecast(graph *beads.Graph, rollup beads.Rollup, now time.Time) string {
+	remaining := rollup.Counts.Remaining()
+	if rollup.Counts.Total() == 0 {
+		return "no children"
+	}
+	if remaining == 0 {
+		return "all closed"
+	}
+	rate := graph.ClosureRate(graph.Descendants(rollup.Issue.ID), now, beads.DefaultClosureWindow)
+	eta, ok := beads.EstimateCompletion(remaining, rate, now)
+	if !ok {
+		return "ETA unknown (nothing closed in 14d)"
+	}
+	return fmt.Sprintf("ETA %s (%.1f/day)", eta.Format("Jan 02"), rate)
+}
+
+// rollupCounts summarizes a rollup's features and tasks, then what is in
+// flight.
+func rollupCounts(rollup beads.Rollup) string {
+	var parts []string
+	for _, issueType := range []string{"feature", "task"} {
+		if counts, ok := rollup.ByType[issueType]; ok {
+			parts = append(parts, fmt.Sprintf("%ss %d/%d", issueType, counts.Closed, counts.Total()))
+		}
+	}
+	others := rollup.Counts.Total()
+	for _, issueType := range []string{"feature", "task"} {
+		others -= rollup.ByType[issueType].Total()
+	}
+	if others > 0 {
+		parts = append(parts, fmt.Sprintf("other %d", others))
+	}
+	if rollup.Counts.InProgress > 0 {
+		parts = append(parts, fmt.Sprintf("%d wip", rollup.Counts.InProgress))
+	}
+	if rollup.Counts.Blocked > 0 {
+		parts = append(parts, fmt.Sprintf("%d blocked", rollup.Counts.Blocked))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// progressBar draws fraction of width cells filled.
+func progressBar(fraction float64, width int, styles dashboardStyles) string {
+	if width <= 0 {
+		return ""
+	}
+	filled := int(fraction*float64(width) + 0.5)
+	filled = maxInt(0, minInt(filled, width))
+	return styles.progressFilled.Render(strings.Repeat("█", filled)) + styles.progressEmpty.Render(strings.Repeat("░", width-filled))
+}
+
+func renderEpicsView(m Model, styles dashboardStyles) string {
+	width := m.width
+	height := m.height - 2
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+	if m.epicRoot != "" {
+		return renderEpicTree(m, width, height, styles)
+	}
+
+	epics := m.epics()
+	open := 0
+	for _, epic := range epics {
+		if !epic.Issue.IsClosed() {
+			open++
+		}
+	}
+	header := fmt.Sprintf("Epics  %d open, %d closed  %d eligible for closure", open, len(epics)-open, m.summary.EpicsEligibleForClosure)
+	rows := []string{styles.subheader.Render(truncateASCII(header, width))}
+	if len(epics) == 0 {
+		rows = append(rows, styles.dimText.Render(truncateASCII("No epics yet.", width)))
+	}
+
+	now := time.Now()
+	barWidth := minInt(30, maxInt(10, width/4))
+	var lines []string
+	cursorLine := 0
+	for i, epic := range epics {
+		selected := i == m.epicCursor
+		if selected {
+			cursorLine = len(lines)
+		}
+		marker := "  "
+		titleStyle := styles.drawerItem
+		if selected {
+			marker = "▸ "
+			titleStyle = styles.drawerItemSelected
+		}
+		badge := ""
+		if !epic.Issue.IsClosed() && m.graph.EligibleForClosure(epic.Issue.ID) {
+			badge = styles.badgeReady.Render("ELIGIBLE FOR CLOSURE")
+		} else if epic.Issue.IsClosed() {
+			badge = styles.badgeClosed.Render("CLOSED")
+		}
+		title := truncateASCII(fmt.Sprintf("%s%s P%d %s", marker, epic.Issue.ID, epic.Issue.Priority, epic.Issue.Title), maxInt(1, width-lipgloss.Width(badge)-1))
+		lines = append(lines, titleStyle.Render(title)+strings.Repeat(" ", maxInt(1, width-lipgloss.Width(title)-lipgloss.Width(badge)))+badge)
+
+		progress := fmt.Sprintf(" %3.0f%%  %d/%d closed  %s", epic.Progress()*100, epic.Counts.Closed, epic.Counts.Total(), epicForecast(m.graph, epic, now))
+		lines = append(lines, "  "+progressBar(epic.Progress(), barWidth, styles)+styles.item.Render(truncateASCII(progress, maxInt(0, width-barWidth-2))))
+		lines = append(lines, styles.dimText.Render(truncateASCII("  "+rollupCounts(epic), width)))
+		lines = append(lines, "")
+	}
+
+	listHeight := maxInt(1, height-len(rows))
+	start := 0
+	if cursorLine+epicLines > listHeight {
+		start = minInt(cursorLine, cursorLine+epicLines-listHeight)
+	}
+	for i := start; i < len(lines) && len(rows) < height; i++ {
+		rows = append(rows, lines[i])
+	}
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func renderEpicTree(m Model, width int, height int, styles dashboardStyles) string {
+	nodes := m.epicTree()
+	if len(nodes) == 0 {
+		return renderPanel("Epic", []string{styles.dimText.Render("Epic not found.")}, width, height, styles)
+	}
+	root := nodes[0].Rollup
+	header := fmt.Sprintf("%s %s  %.0f%% of %d closed  %s", root.Issue.ID, root.Issue.Title, root.Progress()*100, root.Counts.Total(), epicForecast(m.graph, root, time.Now()))
+	rows := []string{styles.subheader.Render(truncateASCII(header, width))}
+
+	barWidth := 12
+	// The bar column sits at the right: "[bar] 100% 10/10".
+	right := barWidth + 14
+	var lines []string
+	for i, node := range nodes {
+		issue := node.Rollup.Issue
+		label := fmt.Sprintf("%s%s [%s] %s", strings.Repeat("  ", node.Depth), issue.ID, issue.Status, issue.Title)
+		label = truncateASCII(label, maxInt(1, width-right-1))
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(graphStatusColors[issue.Status]))
+		if i == m.epicNodeCursor {
+			style = styles.drawerItemSelected
+		}
+		bar := ""
+		if total := node.Rollup.Counts.Total(); total > 0 {
+			bar = progressBar(node.Rollup.Progress(), barWidth, styles) + fmt.Sprintf(" %3.0f%% %d/%d", node.Rollup.Progress()*100, node.Rollup.Counts.Closed, total)
+		}
+		lines = append(lines, style.Render(label)+strings.Repeat(" ", maxInt(1, width-lipgloss.Width(label)-right))+bar)
+	}
+
+	listHeight := maxInt(1, height-len(rows)-1)
+	start := 0
+	if m.epicNodeCursor >= listHeight {
+		start = m.epicNodeCursor - listHeight + 1
+	}
+	for i := start; i < len(lines) && i < start+listHeight; i++ {
+		rows = append(rows, lines[i])
+	}
+	for len(rows) < height-1 {
+		rows = append(rows, "")
+	}
+	if m.epicNodeCursor < len(nodes) {
+		rows = append(rows, styles.dimText.Render(truncateASCII(rollupCounts(nodes[m.epicNodeCursor].Rollup), width)))
+	}
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
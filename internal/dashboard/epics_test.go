@@ -0,0 +1,61 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+func TestEpicsViewRollsUpAndDrillsIn(t *testing.T) {
+	parent := func(child string, epic string) []beads.Dependency {
+		return []beads.Dependency{{IssueID: child, DependsOnID: epic, Type: beads.DepParentChild}}
+	}
+	closedAt := time.Now().Add(-24 * time.Hour)
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Auth", Status: beads.StatusOpen, Priority: 2, IssueType: "epic"},
+		beads.Issue{ID: "cn-2", Title: "Login", Status: beads.StatusInProgress, IssueType: "feature", Dependencies: parent("cn-2", "cn-1")},
+		beads.Issue{ID: "cn-3", Title: "Form", Status: beads.StatusClosed, ClosedAt: &closedAt, IssueType: "task", Dependencies: parent("cn-3", "cn-2")},
+		beads.Issue{ID: "cn-4", Title: "Docs", Status: beads.StatusOpen, Priority: 1, IssueType: "epic"},
+		beads.Issue{ID: "cn-5", Title: "Guide", Status: beads.StatusClosed, ClosedAt: &closedAt, IssueType: "task", Dependencies: parent("cn-5", "cn-4")},
+	)
+	model := boardModel(t, client)
+	model, _ = press(t, model, "6")
+
+	epics := model.epics()
+	if len(epics) != 2 || epics[0].Issue.ID != "cn-4" {
+		t.Fatalf("expected epics by priority, got %+v", epics)
+	}
+	if got := rollupCounts(epics[1]); got != "features 0/1 · tasks 1/1 · 1 wip" {
+		t.Fatalf("unexpected rollup %q", got)
+	}
+	if got := epicForecast(model.graph, epics[1], time.Now()); !strings.HasPrefix(got, "ETA ") {
+		t.Fatalf("expected an ETA from the recent closure, got %q", got)
+	}
+	if model.summary.EpicsEligibleForClosure != 1 {
+		t.Fatalf("expected cn-4 to be eligible for closure, got %d", model.summary.EpicsEligibleForClosure)
+	}
+
+	model, _ = press(t, model, "j")
+	model, _ = press(t, model, "enter")
+	if nodes := model.epicTree(); model.epicRoot != "cn-1" || len(nodes) != 3 || nodes[2].Depth != 2 {
+		t.Fatalf("expected the cn-1 hierarchy, got %q %+v", model.epicRoot, nodes)
+	}
+	model, _ = press(t, model, "j")
+	if selected := model.actionIssue(); selected == nil || selected.ID != "cn-2" {
+		t.Fatalf("expected actions to target cn-2, got %+v", selected)
+	}
+	model, _ = press(t, model, "esc")
+	if model.epicRoot != "" {
+		t.Fatal("expected esc to return to the epics list")
+	}
+
+	model, _ = press(t, model, "k")
+	model, _ = press(t, model, "x")
+	model = typeText(t, model, "done")
+	model = runAction(t, model, "enter")
+	if issue, _ := client.Show(t.Context(), "cn-4"); issue.Status != beads.StatusClosed {
+		t.Fatalf("expected x to close the eligible epic, got %s", issue.Status)
+	}
+}
@@ -0,0 +1,195 @@
+package dashboard
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Filter is a parsed search query. Free text terms are fuzzy matched; the
+// structured filters are key:value tokens:
+//
+//	type:task       issue type (comma separated for any of several)
+//	status:blocked  status; in-progress and wip mean in_progress
+//	p:<=1           priority, with <, <=, >, >= or = (P1 works too)
+//	owner:me        owner or assignee; me is the dashboard user
+//
+// Every term and filter must match.
+type Filter struct {
+	Terms      []string
+	Types      []string
+	Statuses   []string
+	Owners     []string
+	Priorities []priorityBound
+}
+
+type priorityBound struct {
+	Op    string
+	Value int
+}
+
+func (b priorityBound) match(priority int) bool {
+	switch b.Op {
+	case "<":
+		return priority < b.Value
+	case "<=":
+		return priority <= b.Value
+	case ">":
+		return priority > b.Value
+	case ">=":
+		return priority >= b.Value
+	default:
+		return priority == b.Value
+	}
+}
+
+// ParseFilter parses a search query. Unknown keys and malformed priorities
+// are errors so typos do not silently match everything.
+func ParseFilter(query string) (Filter, error) {
+	var filter Filter
+	for _, token := range strings.Fields(query) {
+		key, value, ok := strings.Cut(token, ":")
+		if !ok || key == "" {
+			filter.Terms = append(filter.Terms, strings.ToLower(token))
+			continue
+		}
+		if value == "" {
+			return Filter{}, fmt.Errorf("filter %s needs a value", token)
+		}
+		values := strings.Split(strings.ToLower(value), ",")
+		switch strings.ToLower(key) {
+		case "type", "t":
+			filter.Types = append(filter.Types, values...)
+		case "status", "s":
+			for _, status := range values {
+				filter.Statuses = append(filter.Statuses, normalizeStatus(status))
+			}
+		case "owner", "o":
+			filter.Owners = append(filter.Owners, values...)
+		case "p", "priority":
+			bound, err := parsePriorityBound(value)
+			if err != nil {
+				return Filter{}, err
+			}
+			filter.Priorities = append(filter.Priorities, bound)
+		default:
+			return Filter{}, fmt.Errorf("unknown filter %q (use type, status, p or owner)", key+":")
+		}
+	}
+	return filter, nil
+}
+
+func normalizeStatus(status string) string {
+	switch status {
+	case "wip", "in-progress", "inprogress":
+		return "in_progress"
+	case "done":
+		return "closed"
+	default:
+		return status
+	}
+}
+
+func parsePriorityBound(value string) (priorityBound, error) {
+	op := ""
+	for _, candidate := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, candidate) {
+			op = candidate
+			break
+		}
+	}
+	number := strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(value, op), "P"), "p")
+	priority, err := strconv.Atoi(number)
+	if err != nil {
+		return priorityBound{}, fmt.Errorf("invalid priority filter p:%s (e.g. p:1 or p:<=1)", value)
+	}
+	if op == "" {
+		op = "="
+	}
+	return priorityBound{Op: op, Value: priority}, nil
+}
+
+// Empty reports whether the filter matches every issue.
+func (f Filter) Empty() bool {
+	return len(f.Terms) == 0 && len(f.Types) == 0 && len(f.Statuses) == 0 && len(f.Owners) == 0 && len(f.Priorities) == 0
+}
+
+// Match reports whether issue passes the filter. me is who owner:me means.
+func (f Filter) Match(issue Issue, me string) bool {
+	if len(f.Types) > 0 && !containsFold(f.Types, issue.IssueType) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !containsFold(f.Statuses, issue.Status) {
+		return false
+	}
+	for _, bound := range f.Priorities {
+		if !bound.match(issue.Priority) {
+			return false
+		}
+	}
+	if len(f.Owners) > 0 {
+		matched := false
+		for _, owner := range f.Owners {
+			if owner == "me" {
+				owner = me
+			}
+			if owner != "" && (strings.EqualFold(owner, issue.Owner) || strings.EqualFold(owner, issue.Assignee)) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for _, term := range f.Terms {
+		if !matchTerm(term, issue) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchTerm fuzzy matches term against the ID and title (its letters in
+// order, not necessarily adjacent) or finds it in the description.
+func matchTerm(term string, issue Issue) bool {
+	return fuzzyMatch(term, strings.ToLower(issue.ID)) ||
+		fuzzyMatch(term, strings.ToLower(issue.Title)) ||
+		strings.Contains(strings.ToLower(issue.Description), term)
+}
+
+func fuzzyMatch(pattern string, text string) bool {
+	remaining := []rune(pattern)
+	for _, r := range text {
+		if len(remaining) == 0 {
+			break
+		}
+		if r == remaining[0] {
+			remaining = remaining[1:]
+		}
+	}
+	return len(remaining) == 0
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// filterIssues returns the issues that pass filter.
+func filterIssues(issues []Issue, filter Filter, me string) []Issue {
+	if filter.Empty() {
+		return issues
+	}
+	var matched []Issue
+	for _, issue := range issues {
+		if filter.Match(issue, me) {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,134 @@
+package dashboard
+
+import (
+	"testing"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter("Login type:task,bug s:wip p:<=P1 owner:me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(filter.Terms) != 1 || filter.Terms[0] != "login" {
+		t.Fatalf("expected the term login, got %v", filter.Terms)
+	}
+	if len(filter.Types) != 2 || filter.Statuses[0] != "in_progress" || filter.Owners[0] != "me" {
+		t.Fatalf("unexpected filter %+v", filter)
+	}
+	if filter.Priorities[0] != (priorityBound{Op: "<=", Value: 1}) {
+		t.Fatalf("expected p<=1, got %+v", filter.Priorities)
+	}
+
+	for _, query := range []string{"typ:task", "status:", "p:high"} {
+		if _, err := ParseFilter(query); err == nil {
+			t.Fatalf("%q: expected an error", query)
+		}
+	}
+	if filter, err := ParseFilter("   "); err != nil || !filter.Empty() {
+		t.Fatalf("expected a blank query to be empty, got %+v (%v)", filter, err)
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	issue := Issue{
+		ID:          "cn-42",
+		Title:       "Fix login redirect",
+		Description: "Users land on the wrong page after OAuth.",
+		Status:      beads.StatusBlocked,
+		IssueType:   "task",
+		Priority:    1,
+		Assignee:    "ada",
+	}
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"", true},
+		{"fix login", true},
+		{"flr", true},
+		{"rfl", false},
+		{"cn42", true},
+		{"oauth", true},
+		{"type:task", true},
+		{"type:epic", false},
+		{"status:blocked", true},
+		{"status:open", false},
+		{"p:<=1", true},
+		{"p:<1", false},
+		{"p:1", true},
+		{"p:>=2", false},
+		{"owner:me", true},
+		{"owner:bob", false},
+		{"login type:task p:<=1 owner:me status:blocked", true},
+		{"login type:feature", false},
+	}
+	for _, test := range tests {
+		filter, err := ParseFilter(test.query)
+		if err != nil {
+			t.Fatalf("%q: %v", test.query, err)
+		}
+		if got := filter.Match(issue, "ada"); got != test.want {
+			t.Errorf("%q: expected match %v, got %v", test.query, test.want, got)
+		}
+	}
+
+	filter, _ := ParseFilter("owner:me")
+	if filter.Match(issue, "") {
+		t.Fatal("expected owner:me to match nothing without an identity")
+	}
+}
+
+func TestSearchFiltersBoardAndSavesViews(t *testing.T) {
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Login page", Status: beads.StatusOpen, Priority: 1, IssueType: "task"},
+		beads.Issue{ID: "cn-2", Title: "Schema", Status: beads.StatusOpen, Priority: 3, IssueType: "task"},
+	)
+	var saved []SavedView
+	model := boardModel(t, client).WithSearch(Search{
+		Me: "ada",
+		SaveView: func(view SavedView) error {
+			saved = append(saved, view)
+			return nil
+		},
+	})
+
+	model, _ = press(t, model, "/")
+	model = typeText(t, model, "p:<=1")
+	model, _ = press(t, model, "enter")
+	if issues := model.boardIssues(0); len(issues) != 1 || issues[0].ID != "cn-1" {
+		t.Fatalf("expected only cn-1 on the board, got %+v", issues)
+	}
+	if model.filterLabel() != "/ p:<=1" {
+		t.Fatalf("expected the navbar to show the search, got %q", model.filterLabel())
+	}
+
+	model, _ = press(t, model, "V")
+	model = typeText(t, model, "urgent")
+	model, _ = press(t, model, "enter")
+	if len(saved) != 1 || saved[0] != (SavedView{Name: "urgent", Query: "p:<=1"}) {
+		t.Fatalf("expected the view to be saved, got %+v", saved)
+	}
+	if model.filterLabel() != "[urgent] p:<=1" {
+		t.Fatalf("expected the view name in the navbar, got %q", model.filterLabel())
+	}
+
+	model, _ = press(t, model, "esc")
+	if len(model.boardIssues(0)) != 2 || model.filterLabel() != "" {
+		t.Fatal("expected esc to clear the search")
+	}
+
+	model, _ = press(t, model, "v")
+	model, _ = press(t, model, "enter")
+	if len(model.boardIssues(0)) != 1 || model.filterView != "urgent" {
+		t.Fatalf("expected the saved view to apply, got %q", model.filterLabel())
+	}
+
+	model, _ = press(t, model, "/")
+	model = typeText(t, model, " bogus:1")
+	model, _ = press(t, model, "enter")
+	if !model.noticeErr || model.filterView != "urgent" {
+		t.Fatal("expected an invalid search to keep the current one")
+	}
+}
@@ -0,0 +1,233 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+const pickerRows = 8
+
+// picker chooses one of a list of items by typing part of its ID or label.
+type picker struct {
+	label  string
+	items  []pickerItem
+	input  textinput.Model
+	cursor int
+	submit func(m Model, id string) (Model, tea.Cmd)
+}
+
+type pickerItem struct {
+	ID    string
+	Label string
+}
+
+// editForm edits an issue's title and description.
+type editForm struct {
+	issue       Issue
+	title       textinput.Model
+	description textarea.Model
+	focus       int
+}
+
+func (m Model) openPicker(label string, items []pickerItem, submit func(m Model, id string) (Model, tea.Cmd)) Model {
+	m.picker = picker{
+		label:  label,
+		items:  items,
+		input:  newPromptInput("Type to filter"),
+		submit: submit,
+	}
+	m.pickerActive = true
+	return m
+}
+
+// pickerMatches returns the items whose ID or label contains the typed text.
+func (m Model) pickerMatches() []pickerItem {
+	query := strings.ToLower(strings.TrimSpace(m.picker.input.Value()))
+	var matches []pickerItem
+	for _, item := range m.picker.items {
+		if query != "" && !strings.Contains(strings.ToLower(item.ID+" "+item.Label), query) {
+			continue
+		}
+		matches = append(matches, item)
+	}
+	return matches
+}
+
+// updatePicker handles keys while the picker is open: up/down choose,
+// enter picks, esc cancels and everything else edits the filter.
+func (m Model) updatePicker(msg tea.KeyMsg) (Model, tea.Cmd) {
+	matches := m.pickerMatches()
+	switch msg.String() {
+	case "esc":
+		m.pickerActive = false
+		return m, nil
+	case "up", "ctrl+p":
+		if m.picker.cursor > 0 {
+			m.picker.cursor--
+		}
+		return m, nil
+	case "down", "ctrl+n":
+		if m.picker.cursor < len(matches)-1 {
+			m.picker.cursor++
+		}
+		return m, nil
+	case "enter":
+		if m.picker.cursor >= len(matches) {
+			return m, nil
+		}
+		m.pickerActive = false
+		return m.picker.submit(m, matches[m.picker.cursor].ID)
+	}
+	var cmd tea.Cmd
+	m.picker.input, cmd = m.picker.input.Update(msg)
+	m.picker.cursor = 0
+	return m, cmd
+}
+
+func renderPickerOverlay(m Model, styles dashboardStyles) string {
+	dialogWidth := minInt(m.width-4, 70)
+	if dialogWidth < 20 {
+		dialogWidth = m.width
+	}
+	textWidth := maxInt(1, dialogWidth-6)
+	m.picker.input.Width = maxInt(1, dialogWidth-8)
+	lines := []string{
+		styles.helpTitle.Render(truncateASCII(m.picker.label, textWidth)),
+		"",
+		m.picker.input.View(),
+		"",
+	}
+
+	matches := m.pickerMatches()
+	if len(matches) == 0 {
+		lines = append(lines, styles.dimText.Render("No matches."))
+	}
+	start := 0
+	if m.picker.cursor >= pickerRows {
+		start = m.picker.cursor - pickerRows + 1
+	}
+	for i := start; i < len(matches) && i < start+pickerRows; i++ {
+		line := truncateASCII(fmt.Sprintf("%s %s", matches[i].ID, matches[i].Label), textWidth)
+		if i == m.picker.cursor {
+			lines = append(lines, styles.itemSelected.Render(line))
+		} else {
+			lines = append(lines, styles.helpText.Render(line))
+		}
+	}
+	lines = append(lines, "", styles.dimText.Render("up/down choose  enter pick  esc cancel"))
+
+	box := styles.helpBox.Width(dialogWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func (m Model) openEditForm(issue Issue) Model {
+	title := newPromptInput("Title")
+	title.CharLimit = 0
+	title.SetValue(issue.Title)
+
+	description := textarea.New()
+	description.Placeholder = "Description"
+	description.ShowLineNumbers = false
+	description.CharLimit = 0
+	description.SetValue(issue.Description)
+	description.Blur()
+
+	m.form = editForm{issue: issue, title: title, description: description}
+	m.formActive = true
+	return m
+}
+
+// updateForm handles keys while the edit form is open: tab switches
+// fields, ctrl+s saves the changed fields and esc cancels.
+func (m Model) updateForm(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.formActive = false
+		return m, nil
+	case "tab", "shift+tab":
+		m.form.focus = 1 - m.form.focus
+		if m.form.focus == 0 {
+			m.form.description.Blur()
+			return m, m.form.title.Focus()
+		}
+		m.form.title.Blur()
+		return m, m.form.description.Focus()
+	case "ctrl+s":
+		return m.submitForm()
+	}
+
+	var cmd tea.Cmd
+	if m.form.focus == 0 {
+		if msg.String() == "enter" {
+			return m.submitForm()
+		}
+		m.form.title, cmd = m.form.title.Update(msg)
+	} else {
+		m.form.description, cmd = m.form.description.Update(msg)
+	}
+	return m, cmd
+}
+
+func (m Model) submitForm() (Model, tea.Cmd) {
+	issue := m.form.issue
+	title := strings.TrimSpace(m.form.title.Value())
+	description := m.form.description.Value()
+	if title == "" {
+		m = m.withNotice("title is required", true)
+		return m, m.clearNoticeCmd()
+	}
+	m.formActive = false
+
+	var input beads.UpdateInput
+	if title != issue.Title {
+		input.Title = &title
+	}
+	if description != issue.Description {
+		input.Description = &description
+	}
+	if input.Title == nil && input.Description == nil {
+		return m, nil
+	}
+	id := issue.ID
+	return m, m.beadActionCmd(fmt.Sprintf("Updated %s", id), func(ctx context.Context, client beads.Client) error {
+		return client.Update(ctx, id, input)
+	})
+}
+
+func renderFormOverlay(m Model, styles dashboardStyles) string {
+	dialogWidth := minInt(m.width-4, 80)
+	if dialogWidth < 20 {
+		dialogWidth = m.width
+	}
+	textWidth := maxInt(1, dialogWidth-6)
+	m.form.title.Width = maxInt(1, dialogWidth-8)
+	m.form.description.SetWidth(textWidth)
+	m.form.description.SetHeight(maxInt(3, minInt(10, m.height-14)))
+
+	label := func(text string, focused bool) string {
+		if focused {
+			return styles.helpTitle.Render(text)
+		}
+		return styles.dimText.Render(text)
+	}
+	lines := []string{
+		styles.helpTitle.Render(truncateASCII(fmt.Sprintf("Edit %s", m.form.issue.ID), textWidth)),
+		"",
+		label("Title", m.form.focus == 0),
+		m.form.title.View(),
+		"",
+		label("Description", m.form.focus == 1),
+		m.form.description.View(),
+		"",
+		styles.dimText.Render(truncateASCII("tab switch field  ctrl+s save  esc cancel", textWidth)),
+	}
+	box := styles.helpBox.Width(dialogWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
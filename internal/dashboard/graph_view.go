@@ -0,0 +1,131 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+const maxGraphDepth = 6
+
+// graphStatusColors colors graph nodes by status.
+var graphStatusColors = map[string]string{
+	beads.StatusOpen:       "70",
+	beads.StatusInProgress: "33",
+	beads.StatusBlocked:    "160",
+	beads.StatusClosed:     "243",
+}
+
+// openGraph shows the dependency graph around id.
+func (m Model) openGraph(id string) Model {
+	m.graphRoot = id
+	m.graphCursor = 0
+	m.activeView = ViewGraph
+	if hood, err := m.neighborhood(); err == nil {
+		for i, node := range hood.Nodes {
+			if node.ID == id {
+				m.graphCursor = i
+			}
+		}
+	}
+	return m
+}
+
+func (m Model) neighborhood() (beads.Neighborhood, error) {
+	if m.graph == nil || m.graphRoot == "" {
+		return beads.Neighborhood{}, fmt.Errorf("select an issue and press g to see its graph")
+	}
+	return m.graph.Neighborhood(m.graphRoot, m.graphDepth)
+}
+
+func (m Model) updateGraphKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	hood, err := m.neighborhood()
+	if err != nil {
+		return m, nil, false
+	}
+	switch msg.String() {
+	case "j", "down":
+		if m.graphCursor < len(hood.Nodes)-1 {
+			m.graphCursor++
+		}
+		return m, nil, true
+	case "k", "up":
+		if m.graphCursor > 0 {
+			m.graphCursor--
+		}
+		return m, nil, true
+	case "enter":
+		if m.graphCursor < len(hood.Nodes) {
+			m = m.openGraph(hood.Nodes[m.graphCursor].ID)
+		}
+		return m, nil, true
+	case "+", "=":
+		m.graphDepth = minInt(m.graphDepth+1, maxGraphDepth)
+		return m.openGraph(m.graphRoot), nil, true
+	case "-":
+		m.graphDepth = maxInt(m.graphDepth-1, 1)
+		return m.openGraph(m.graphRoot), nil, true
+	}
+	return m, nil, false
+}
+
+func renderGraphView(m Model, styles dashboardStyles) string {
+	width := m.width
+	height := m.height - 2
+	if width <= 0 || height <= 0 {
+		return ""
+	}
+
+	hood, err := m.neighborhood()
+	if err != nil {
+		return renderPanel("Graph", []string{styles.dimText.Render(truncateASCII(err.Error(), width))}, width, height, styles)
+	}
+	cursorID := ""
+	if m.graphCursor < len(hood.Nodes) {
+		cursorID = hood.Nodes[m.graphCursor].ID
+	}
+
+	paint := func(node beads.GraphNode, line string) string {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(graphStatusColors[node.Status]))
+		if node.ID == cursorID {
+			style = style.Bold(true).Reverse(true)
+		}
+		return style.Render(line)
+	}
+	lines := strings.Split(strings.TrimRight(beads.FormatASCII(hood, width, paint), "\n"), "\n")
+
+	header := fmt.Sprintf("Graph around %s  depth %d  %d issues, %d links", hood.Root, hood.Depth, len(hood.Nodes), len(hood.Edges))
+	footer := ""
+	if node, ok := hood.Node(cursorID); ok {
+		footer = fmt.Sprintf("%s P%d %s [%s] %s", node.ID, node.Priority, node.Type, node.Status, node.Title)
+	}
+
+	graphHeight := maxInt(1, height-2)
+	start := 0
+	if cursorLine := graphNodeLine(lines, cursorID); cursorLine+4 > graphHeight {
+		start = minInt(cursorLine, cursorLine+4-graphHeight)
+	}
+	rows := []string{styles.subheader.Render(truncateASCII(header, width))}
+	for i := start; i < len(lines) && i < start+graphHeight; i++ {
+		rows = append(rows, lines[i])
+	}
+	for len(rows) < height-1 {
+		rows = append(rows, "")
+	}
+	rows = append(rows, styles.dimText.Render(truncateASCII(footer, width)))
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+// graphNodeLine returns the first line showing id's box header, counted from
+// the top border.
+func graphNodeLine(lines []string, id string) int {
+	for i, line := range lines {
+		if strings.Contains(line, id+" [") {
+			return maxInt(0, i-1)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,46 @@
+package dashboard
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+func TestGraphViewFollowsSelection(t *testing.T) {
+	blocks := func(issueID string, dependsOn string) []beads.Dependency {
+		return []beads.Dependency{{IssueID: issueID, DependsOnID: dependsOn, Type: beads.DepBlocks}}
+	}
+	model := boardModel(t, beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "API", Status: beads.StatusOpen, IssueType: "task"},
+		beads.Issue{ID: "cn-2", Title: "UI", Status: beads.StatusOpen, Priority: 1, IssueType: "task", Dependencies: blocks("cn-2", "cn-1")},
+		beads.Issue{ID: "cn-3", Title: "Docs", Status: beads.StatusBlocked, IssueType: "task", Dependencies: blocks("cn-3", "cn-2")},
+	))
+
+	model, _ = press(t, model, "g")
+	if model.activeView != ViewGraph || model.graphRoot != "cn-1" {
+		t.Fatalf("expected the graph of cn-1, got view %d root %q", model.activeView, model.graphRoot)
+	}
+	hood, _ := model.neighborhood()
+	if len(hood.Nodes) != 3 {
+		t.Fatalf("expected cn-1 and two dependents at depth 2, got %+v", hood.Nodes)
+	}
+
+	model, _ = press(t, model, "-")
+	if hood, _ = model.neighborhood(); model.graphDepth != 1 || len(hood.Nodes) != 2 {
+		t.Fatalf("expected depth 1 to drop cn-3, got %+v", hood.Nodes)
+	}
+
+	model, _ = press(t, model, "j")
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	model = updated.(Model)
+	if model.graphRoot != "cn-2" {
+		t.Fatalf("expected enter to recenter on cn-2, got %q", model.graphRoot)
+	}
+
+	updated, _ = model.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+	if view := updated.(Model).View(); !strings.Contains(view, "Graph around cn-2") {
+		t.Fatalf("expected the graph header in the view:\n%s", view)
+	}
+}
@@ -0,0 +1,98 @@
+package dashboard
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func newPromptInput(placeholder string) textinput.Model {
+	input := textinput.New()
+	input.Placeholder = placeholder
+	input.CharLimit = 200
+	input.Focus()
+	return input
+}
+
+// updateInput handles keys while the prompt is open: enter submits the
+// value (when non-empty, unless inputAllowEmpty is set), esc cancels and
+// everything else edits the text.
+func (m Model) updateInput(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.inputActive = false
+		m.inputAllowEmpty = false
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.input.Value())
+		if value == "" && !m.inputAllowEmpty {
+			return m, nil
+		}
+		m.inputActive = false
+		m.inputAllowEmpty = false
+		if m.inputSubmit == nil {
+			return m, nil
+		}
+		return m.inputSubmit(m, value)
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// confirm asks a yes/no question before running action.
+func (m Model) confirm(label string, action func(m Model) (Model, tea.Cmd)) Model {
+	m.confirmActive = true
+	m.confirmLabel = label
+	m.confirmAction = action
+	return m
+}
+
+// updateConfirm handles keys while a confirmation is open: y or enter runs
+// the action, n or esc cancels and other keys are ignored.
+func (m Model) updateConfirm(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.confirmActive = false
+		if m.confirmAction == nil {
+			return m, nil
+		}
+		return m.confirmAction(m)
+	case "n", "esc":
+		m.confirmActive = false
+	}
+	return m, nil
+}
+
+func renderInputOverlay(m Model, styles dashboardStyles) string {
+	dialogWidth := minInt(m.width-4, 60)
+	if dialogWidth < 20 {
+		dialogWidth = m.width
+	}
+	m.input.Width = maxInt(1, dialogWidth-8)
+	lines := []string{
+		styles.helpTitle.Render(truncateASCII(m.inputLabel, dialogWidth-6)),
+		"",
+		m.input.View(),
+		"",
+		styles.dimText.Render("enter confirm  esc cancel"),
+	}
+	box := styles.helpBox.Width(dialogWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func renderConfirmOverlay(m Model, styles dashboardStyles) string {
+	dialogWidth := minInt(m.width-4, 60)
+	if dialogWidth < 20 {
+		dialogWidth = m.width
+	}
+	lines := []string{
+		styles.helpTitle.Render(truncateASCII(m.confirmLabel, dialogWidth-6)),
+		"",
+		styles.dimText.Render("y confirm  n cancel"),
+	}
+	box := styles.helpBox.Width(dialogWidth).Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -5,8 +5,12 @@ import (
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/rikurb8/carnie/internal/bd"
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
 )
 
 type Issue struct {
@@ -17,6 +21,7 @@ type Issue struct {
 	Priority     int          `json:"priority"`
 	IssueType    string       `json:"issue_type"`
 	Owner        string       `json:"owner"`
+	Assignee     string       `json:"assignee,omitempty"`
 	UpdatedAt    string       `json:"updated_at"`
 	CreatedAt    string       `json:"created_at"`
 	Dependencies []Dependency `json:"dependencies,omitempty"`
@@ -29,20 +34,28 @@ type Dependency struct {
 }
 
 type dataState struct {
-	Status     bd.Status
+	Status     beads.Status
+	Issues     []beads.Issue
 	Ready      []Issue
 	InProgress []Issue
 	Blocked    []Issue
 	Closed     []Issue
+	WorkOrders []workorder.WorkOrder
 }
 
 type dataMsg struct {
-	Data dataState
-	Err  error
+	Data      dataState
+	Version   string
+	Unchanged bool
+	Err       error
 }
 
 type tickMsg struct{}
 
+// filesChangedMsg is sent by Watch when beads or work order data changed on
+// disk.
+type filesChangedMsg struct{}
+
 type issueColumn struct {
 	Title      string
 	Issues     []Issue
@@ -55,9 +68,14 @@ type ViewType int
 const (
 	ViewHome ViewType = iota
 	ViewTasks
+	ViewWorkOrders
+	ViewBoard
+	ViewGraph
+	ViewEpics
 )
 
 type Model struct {
+	client          beads.Client
 	width           int
 	height          int
 	columns         []issueColumn
@@ -66,13 +84,54 @@ type Model struct {
 	refresh         time.Duration
 	limit           int
 	lastUpdated     time.Time
-	summary         bd.StatusSummary
+	data            dataState
+	dataVersion     string
+	summary         beads.StatusSummary
 	errMessage      string
 	showHelp        bool
 	collapsed       map[string]bool
 	featureChildren map[string][]Issue
 	lists           []list.Model
 	homeSpinners    []spinner.Model
+	workOrders      WorkOrders
+	orders          []workorder.WorkOrder
+	orderCursor     int
+	orderEventsID   int64
+	orderEvents     []workorder.Event
+	beadTitles      map[string]string
+	board           []boardColumn
+	boardColumn     int
+	boardCursor     []int
+	boardType       string
+	graph           *beads.Graph
+	graphRoot       string
+	graphDepth      int
+	graphCursor     int
+	epicCursor      int
+	epicRoot        string
+	epicNodeCursor  int
+	notice          string
+	noticeErr       bool
+	noticeSeq       int
+	input           textinput.Model
+	inputActive     bool
+	inputLabel      string
+	inputSubmit     func(m Model, value string) (Model, tea.Cmd)
+	inputAllowEmpty bool
+	confirmActive   bool
+	confirmLabel    string
+	confirmAction   func(m Model) (Model, tea.Cmd)
+	picker          picker
+	pickerActive    bool
+	form            editForm
+	formActive      bool
+	search          Search
+	filter          Filter
+	filterQuery     string
+	filterView      string
+	detail          viewport.Model
+	detailFocus     bool
+	detailID        string
 }
 
 type drawerEntry struct {
@@ -80,7 +139,7 @@ type drawerEntry struct {
 	Level int
 }
 
-func NewModel(refresh time.Duration, limit int) Model {
+func NewModel(client beads.Client, refresh time.Duration, limit int) Model {
 	styles := newDashboardStyles()
 	delegate := newDrawerDelegate(styles, 1)
 	futureList := newDrawerList(delegate)
@@ -104,10 +163,16 @@ func NewModel(refresh time.Duration, limit int) Model {
 	}
 
 	return Model{
+		client:          client,
 		refresh:         refresh,
 		limit:           limit,
 		collapsed:       map[string]bool{},
 		featureChildren: map[string][]Issue{},
+		beadTitles:      map[string]string{},
+		board:           buildBoard(dataState{}),
+		boardCursor:     make([]int, len(boardStatuses)),
+		graphDepth:      beads.DefaultGraphDepth,
+		detail:          viewport.New(0, 0),
 		columns: []issueColumn{
 			{Title: "Open Features"},
 		},
@@ -21,12 +21,24 @@ func (m Model) View() string {
 
 	navbar := renderDashboardNavbar(inner, styles)
 	body := renderDashboardBody(inner, styles)
-	footer := renderDashboardFooter()
+	footer := renderDashboardFooter(m.activeView)
 
 	output := lipgloss.JoinVertical(lipgloss.Left, navbar, body)
 	if m.showHelp {
 		output = renderHelpOverlay(output, inner, styles)
 	}
+	if m.inputActive {
+		output = renderInputOverlay(inner, styles)
+	}
+	if m.confirmActive {
+		output = renderConfirmOverlay(inner, styles)
+	}
+	if m.pickerActive {
+		output = renderPickerOverlay(inner, styles)
+	}
+	if m.formActive {
+		output = renderFormOverlay(inner, styles)
+	}
 	if m.width > 2 && m.height > 2 {
 		return renderTentFrame(output, footer, styles, m.width, m.height)
 	}
@@ -43,24 +55,55 @@ func renderDashboardNavbar(m Model, styles dashboardStyles) string {
 	}
 
 	// View tabs
-	homeTab := "1 Home"
-	tasksTab := "2 Tasks"
-	if m.activeView == ViewHome {
-		homeTab = styles.viewTabActive.Render(homeTab)
-		tasksTab = styles.viewTabInactive.Render(tasksTab)
-	} else {
-		homeTab = styles.viewTabInactive.Render(homeTab)
-		tasksTab = styles.viewTabActive.Render(tasksTab)
+	tabs := []struct {
+		view  ViewType
+		label string
+	}{
+		{ViewHome, "1 Home"},
+		{ViewTasks, "2 Tasks"},
+		{ViewWorkOrders, "3 Work Orders"},
+		{ViewBoard, "4 Board"},
+		{ViewGraph, "5 Graph"},
+		{ViewEpics, "6 Epics"},
+	}
+	renderTabs := func(compact bool) string {
+		rendered := make([]string, 0, len(tabs))
+		for _, tab := range tabs {
+			if tab.view == m.activeView {
+				rendered = append(rendered, styles.viewTabActive.Render(tab.label))
+			} else if compact {
+				rendered = append(rendered, styles.viewTabInactive.Render(tab.label[:1]))
+			} else {
+				rendered = append(rendered, styles.viewTabInactive.Render(tab.label))
+			}
+		}
+		return strings.Join(rendered, " ")
+	}
+	viewTabs := renderTabs(false)
+	// Narrow terminals keep the active tab's name and number the others.
+	if lipgloss.Width(viewTabs) > width*2/3 {
+		viewTabs = renderTabs(true)
+	}
+	if label := m.filterLabel(); label != "" {
+		if room := width*2/3 - lipgloss.Width(viewTabs) - 2; room > 4 {
+			viewTabs += "  " + styles.navbarFilter.Render(truncateASCII(label, room))
+		}
 	}
-	viewTabs := homeTab + " " + tasksTab
 
 	updated := "Stand by..."
-	if m.errMessage != "" {
+	if m.notice != "" {
+		updated = m.notice
+	} else if m.errMessage != "" {
 		updated = m.errMessage
 	} else if !m.lastUpdated.IsZero() {
 		updated = fmt.Sprintf("Updated %s", m.lastUpdated.Format("15:04:05"))
 	}
-	rightSub := styles.navbarMeta.Render(updated)
+	updated = truncateASCII(updated, width-lipgloss.Width(viewTabs)-1)
+	metaStyle := styles.navbarMeta
+	if m.notice != "" && m.noticeErr {
+		metaStyle = styles.navbarError
+	}
+	rightSub := metaStyle.Render(updated)
 	line := renderNavbarLine(width, viewTabs, rightSub, styles.navbarBar)
 
 	return line
@@ -101,14 +144,9 @@ func renderDashboardStats(m Model, styles dashboardStyles) string {
 		return styles.dimText.Render("Loading beads data...")
 	}
 	status := m.summary
-	tags := []string{
-		styles.tag.Render(fmt.Sprintf("Total %d", status.TotalIssues)),
-		styles.tag.Render(fmt.Sprintf("Open %d", status.OpenIssues)),
-		styles.tag.Render(fmt.Sprintf("Ready %d", status.ReadyIssues)),
-		styles.tag.Render(fmt.Sprintf("In Progress %d", status.InProgressIssues)),
-		styles.tag.Render(fmt.Sprintf("Blocked %d", status.BlockedIssues)),
-		styles.tag.Render(fmt.Sprintf("Deferred %d", status.DeferredIssues)),
-		styles.tag.Render(fmt.Sprintf("Closed %d", status.ClosedIssues)),
+	var tags []string
+	for _, count := range summaryCounts(status) {
+		tags = append(tags, styles.tag.Render(count))
 	}
 	statsLine := styles.subheader.Render(lipgloss.JoinHorizontal(lipgloss.Left, tags...))
 	return lipgloss.JoinVertical(lipgloss.Left, statsLine)
@@ -118,6 +156,18 @@ func renderDashboardBody(m Model, styles dashboardStyles) string {
 	if m.activeView == ViewHome {
 		return renderHomeView(m, styles)
 	}
+	if m.activeView == ViewWorkOrders {
+		return renderWorkOrdersView(m, styles)
+	}
+	if m.activeView == ViewBoard {
+		return renderBoardView(m, styles)
+	}
+	if m.activeView == ViewGraph {
+		return renderGraphView(m, styles)
+	}
+	if m.activeView == ViewEpics {
+		return renderEpicsView(m, styles)
+	}
 	stats := renderDashboardStats(m, styles)
 	tasks := renderMasterDetail(m, styles)
 	return lipgloss.JoinVertical(lipgloss.Left, stats, tasks)
@@ -360,52 +410,6 @@ func renderPaneRawRow(text string, innerWidth int, borderStyle lipgloss.Style) s
 	return borderStyle.Render("│") + content + borderStyle.Render("│")
 }
 
-func renderDetailPanel(m Model, width int, height int, styles dashboardStyles) string {
-	selected := m.selectedIssue()
-	lines := []string{}
-	if selected == nil {
-		lines = append(lines, styles.dimText.Render(truncateASCII("Select an issue to see details.", width)))
-		return renderPanel("Issue Details", lines, width, height, styles)
-	}
-
-	lines = append(lines, styles.panelTitle.Render(truncateASCII(fmt.Sprintf("%s %s", selected.ID, selected.Title), width)))
-	lines = append(lines, styles.dimText.Render(truncateASCII(fmt.Sprintf("Status: %s", selected.Status), width)))
-	lines = append(lines, styles.dimText.Render(truncateASCII(fmt.Sprintf("Priority: P%d", selected.Priority), width)))
-	if selected.Owner != "" {
-		lines = append(lines, styles.dimText.Render(truncateASCII(fmt.Sprintf("Owner: %s", selected.Owner), width)))
-	}
-	if selected.UpdatedAt != "" {
-		lines = append(lines, styles.dimText.Render(truncateASCII(fmt.Sprintf("Updated: %s", formatTimestamp(selected.UpdatedAt)), width)))
-	}
-	lines = append(lines, "")
-	if selected.Description != "" {
-		lines = append(lines, styles.panelTitle.Render(truncateASCII("Notes", width)))
-		for _, line := range wrapLines(selected.Description, width) {
-			lines = append(lines, styles.item.Render(truncateASCII(line, width)))
-		}
-	}
-
-	children := m.featureChildren[selected.ID]
-	lines = append(lines, "")
-	if len(children) == 0 {
-		lines = append(lines, styles.panelTitle.Render(truncateASCII("Child Tasks", width)))
-		lines = append(lines, styles.dimText.Render(truncateASCII("(none)", width)))
-	} else {
-		header := fmt.Sprintf("Child Tasks (%d)", len(children))
-		lines = append(lines, styles.panelTitle.Render(truncateASCII(header, width)))
-		for _, child := range children {
-			status := mapStatusBadge(child.Status)
-			if status == "" {
-				status = strings.ToUpper(child.Status)
-			}
-			line := fmt.Sprintf("P%d %s %s %s", child.Priority, status, child.ID, child.Title)
-			lines = append(lines, styles.item.Render(truncateASCII(line, width)))
-		}
-	}
-
-	return renderPanel("Issue Details", lines, width, height, styles)
-}
-
 func renderIssuePreviewLines(title string, issues []Issue, limit int, width int, styles dashboardStyles) []string {
 	lines := []string{styles.panelTitle.Render(truncateASCII(title, width))}
 	if len(issues) == 0 {
@@ -446,8 +450,18 @@ func renderPanel(title string, lines []string, width int, height int, styles das
 	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
 }
 
-func renderDashboardFooter() string {
-	return "1/2 views  h/? help  tab switch  j/k move  left/right collapse  r refresh  q quit"
+func renderDashboardFooter(view ViewType) string {
+	switch view {
+	case ViewWorkOrders:
+		return "1-6 views  j/k move  s start  d done  b block  p copy prompt  r refresh  q quit"
+	case ViewBoard:
+		return "1-6 views  arrows move  shift+left/right move card  f type filter  / search  v/V views  c/x/p/a/e actions  g graph  r refresh  q quit"
+	case ViewGraph:
+		return "1-6 views  j/k select  enter recenter  +/- depth  r refresh  q quit"
+	case ViewEpics:
+		return "1-6 views  j/k move  enter drill in  esc back  c/x/p/a/e actions  g graph  r refresh  q quit"
+	}
+	return "1-6 views  h/? help  tab switch  j/k move  enter detail  left/right collapse  / search  v/V views  c/x/p/a/e actions  g graph  r refresh  q quit"
 }
 
 func renderHelpOverlay(base string, m Model, styles dashboardStyles) string {
@@ -467,10 +481,24 @@ func renderHelpOverlay(base string, m Model, styles dashboardStyles) string {
 
 	keysLine := fmt.Sprintf("%-6s %s", "Keys:", "q quit  tab switch section  j/k move  left/right collapse  r refresh  h/? close")
 	tipsLine := fmt.Sprintf("%-6s %s", "Tips:", "Use left/right to fold epics; tab switches Future/Completed; j/k moves selection.")
+	ordersLine := fmt.Sprintf("%-6s %s", "Orders:", "In view 3, s starts, d completes and b blocks (with a reason) the selected work order; p copies its prompt.")
+	boardLine := fmt.Sprintf("%-6s %s", "Board:", "In view 4, shift+left/right moves the selected card to the neighbouring status after confirming; f cycles the type filter.")
+	actionsLine := fmt.Sprintf("%-6s %s", "Beads:", "On the selected task or card: c claims, x closes with a reason, p sets priority, a adds a dependency, e edits title and description.")
+	graphLine := fmt.Sprintf("%-6s %s", "Graph:", "g on a task or card shows its blockers and dependents in view 5; enter recenters on the selected node and +/- change the depth.")
+	detailLine := fmt.Sprintf("%-6s %s", "Detail:", "In view 2, enter focuses the detail panel so j/k, pgup/pgdown and home/end scroll it; enter or esc returns to the list.")
+	epicsLine := fmt.Sprintf("%-6s %s", "Epics:", "View 6 shows each epic's progress and ETA from the last 14 days' closures; enter drills into its hierarchy, esc goes back, x closes an eligible epic.")
+	searchLine := fmt.Sprintf("%-6s %s", "Search:", "In views 2 and 4, / searches, e.g. login type:task p:<=1 owner:me status:blocked; esc clears. V saves the search as a named view, v picks one.")
 	help := []string{
 		styles.helpTitle.Render("Dashboard Help"),
 		styles.helpText.Render(keysLine),
 		styles.helpText.Render(tipsLine),
+		styles.helpText.Render(ordersLine),
+		styles.helpText.Render(boardLine),
+		styles.helpText.Render(actionsLine),
+		styles.helpText.Render(graphLine),
+		styles.helpText.Render(detailLine),
+		styles.helpText.Render(searchLine),
+		styles.helpText.Render(epicsLine),
 		"",
 	}
 
@@ -0,0 +1,151 @@
+package dashboard
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// SavedView is a named search.
+type SavedView struct {
+	Name  string
+	Query string
+}
+
+// Search configures the dashboard's search. Me is who owner:me matches;
+// SaveView persists a named view, e.g. to camp.yml.
+type Search struct {
+	Me       string
+	Views    []SavedView
+	SaveView func(view SavedView) error
+}
+
+// WithSearch sets who owner:me refers to and the saved views.
+func (m Model) WithSearch(search Search) Model {
+	m.search = search
+	return m
+}
+
+// setQuery applies a search query to the Tasks and Board views; an empty
+// query clears the search.
+func (m Model) setQuery(query string, view string) (Model, error) {
+	filter, err := ParseFilter(query)
+	if err != nil {
+		return m, err
+	}
+	m.filter = filter
+	m.filterQuery = strings.Join(strings.Fields(query), " ")
+	m.filterView = view
+	if filter.Empty() {
+		m.filterQuery, m.filterView = "", ""
+	}
+	m.updateColumns(m.data)
+	drawerWidth, _, _, _ := drawerLayout(m.width, m.height)
+	m.refreshDrawerLists(true, maxInt(1, drawerWidth-2))
+	m.clampBoardCursors()
+	return m, nil
+}
+
+func (m Model) updateSearchKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	if m.activeView != ViewTasks && m.activeView != ViewBoard {
+		return m, nil, false
+	}
+	switch msg.String() {
+	case "/":
+		m.input = newPromptInput("text type:task p:<=1 owner:me status:blocked")
+		m.input.SetValue(m.filterQuery)
+		m.input.CursorEnd()
+		m.inputLabel = "Search (empty clears)"
+		m.inputAllowEmpty = true
+		m.inputSubmit = func(m Model, query string) (Model, tea.Cmd) {
+			updated, err := m.setQuery(query, "")
+			if err != nil {
+				m = m.withNotice(err.Error(), true)
+				return m, m.clearNoticeCmd()
+			}
+			return updated, nil
+		}
+		m.inputActive = true
+		return m, nil, true
+	case "esc":
+		if m.filter.Empty() {
+			return m, nil, false
+		}
+		m, _ = m.setQuery("", "")
+		return m, nil, true
+	case "v":
+		if len(m.search.Views) == 0 {
+			m = m.withNotice("No saved views; search with / and save with V", true)
+			return m, m.clearNoticeCmd(), true
+		}
+		items := make([]pickerItem, 0, len(m.search.Views))
+		for _, view := range m.search.Views {
+			items = append(items, pickerItem{ID: view.Name, Label: view.Query})
+		}
+		m = m.openPicker("Saved views", items, func(m Model, name string) (Model, tea.Cmd) {
+			for _, view := range m.search.Views {
+				if view.Name != name {
+					continue
+				}
+				updated, err := m.setQuery(view.Query, view.Name)
+				if err != nil {
+					m = m.withNotice(fmt.Sprintf("view %s: %v", name, err), true)
+					return m, m.clearNoticeCmd()
+				}
+				return updated, nil
+			}
+			return m, nil
+		})
+		return m, nil, true
+	case "V":
+		if m.filter.Empty() {
+			m = m.withNotice("Search with / before saving a view", true)
+			return m, m.clearNoticeCmd(), true
+		}
+		if m.search.SaveView == nil {
+			m = m.withNotice("Views can only be saved inside a camp", true)
+			return m, m.clearNoticeCmd(), true
+		}
+		m.input = newPromptInput("name")
+		m.input.SetValue(m.filterView)
+		m.input.CursorEnd()
+		m.inputLabel = fmt.Sprintf("Save %q as view", m.filterQuery)
+		m.inputSubmit = func(m Model, name string) (Model, tea.Cmd) {
+			return m.saveView(SavedView{Name: name, Query: m.filterQuery})
+		}
+		m.inputActive = true
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// saveView persists view and adds it to the views list, replacing a view
+// with the same name.
+func (m Model) saveView(view SavedView) (Model, tea.Cmd) {
+	if err := m.search.SaveView(view); err != nil {
+		m = m.withNotice(fmt.Sprintf("save view: %v", err), true)
+		return m, m.clearNoticeCmd()
+	}
+	views := make([]SavedView, 0, len(m.search.Views)+1)
+	for _, existing := range m.search.Views {
+		if existing.Name != view.Name {
+			views = append(views, existing)
+		}
+	}
+	m.search.Views = append(views, view)
+	m.filterView = view.Name
+	m = m.withNotice(fmt.Sprintf("Saved view %s", view.Name), false)
+	return m, m.clearNoticeCmd()
+}
+
+// filterLabel describes the active search for the navbar.
+func (m Model) filterLabel() string {
+	if m.filterQuery == "" {
+		return ""
+	}
+	if m.filterView != "" {
+		return fmt.Sprintf("[%s] %s", m.filterView, m.filterQuery)
+	}
+	return "/ " + m.filterQuery
+}
@@ -0,0 +1,275 @@
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// SnapshotFormats are the formats RenderSnapshot supports.
+var SnapshotFormats = []string{"text", "markdown", "html", "json"}
+
+// snapshotWorkOrders caps the work orders in a snapshot.
+const snapshotWorkOrders = 10
+
+// Snapshot is what the dashboard shows at one moment: the status summary,
+// the open features with their tasks, the blocked issues and the most
+// relevant work orders, in the dashboard's order.
+type Snapshot struct {
+	GeneratedAt time.Time           `json:"generated_at"`
+	Summary     beads.StatusSummary `json:"summary"`
+	Features    []SnapshotFeature   `json:"features"`
+	Blocked     []Issue             `json:"blocked"`
+	WorkOrders  []SnapshotWorkOrder `json:"work_orders"`
+}
+
+// SnapshotFeature is an open feature and its child tasks.
+type SnapshotFeature struct {
+	Issue
+	Children []Issue `json:"children,omitempty"`
+}
+
+// SnapshotWorkOrder is a work order as listed in a snapshot.
+type SnapshotWorkOrder struct {
+	ID        int64     `json:"id"`
+	Title     string    `json:"title"`
+	BeadID    string    `json:"bead_id,omitempty"`
+	Status    string    `json:"status"`
+	Assignee  string    `json:"assignee,omitempty"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Snapshot loads the data the dashboard would show, without a terminal.
+func (m Model) Snapshot(ctx context.Context, now time.Time) (Snapshot, error) {
+	msg := m.loadData(ctx, "")
+	if msg.Err != nil {
+		return Snapshot{}, msg.Err
+	}
+	data := msg.Data
+
+	all := append([]Issue{}, data.Ready...)
+	all = append(all, data.InProgress...)
+	all = append(all, data.Blocked...)
+	all = append(all, data.Closed...)
+	children := buildFeatureChildren(all)
+
+	snapshot := Snapshot{
+		GeneratedAt: now,
+		Summary:     data.Status.Summary,
+		Blocked:     data.Blocked,
+	}
+	for _, feature := range filterOpenFeatures(data) {
+		snapshot.Features = append(snapshot.Features, SnapshotFeature{Issue: feature, Children: children[feature.ID]})
+	}
+	for _, order := range groupWorkOrders(data.WorkOrders) {
+		if len(snapshot.WorkOrders) == snapshotWorkOrders {
+			break
+		}
+		// Like the Work Orders view, prefer the bead's current title.
+		title := beadTitle(all, order.BeadID)
+		if title == "" {
+			title = order.Title
+		}
+		snapshot.WorkOrders = append(snapshot.WorkOrders, SnapshotWorkOrder{
+			ID:        order.ID,
+			Title:     title,
+			BeadID:    order.BeadID,
+			Status:    string(order.Status),
+			Assignee:  order.Assignee,
+			UpdatedAt: order.UpdatedAt,
+		})
+	}
+	return snapshot, nil
+}
+
+func beadTitle(issues []Issue, id string) string {
+	for _, issue := range issues {
+		if issue.ID == id {
+			return issue.Title
+		}
+	}
+	return ""
+}
+
+// summaryCounts labels the status summary the way the Tasks view does.
+func summaryCounts(status beads.StatusSummary) []string {
+	return []string{
+		fmt.Sprintf("Total %d", status.TotalIssues),
+		fmt.Sprintf("Open %d", status.OpenIssues),
+		fmt.Sprintf("Ready %d", status.ReadyIssues),
+		fmt.Sprintf("In Progress %d", status.InProgressIssues),
+		fmt.Sprintf("Blocked %d", status.BlockedIssues),
+		fmt.Sprintf("Deferred %d", status.DeferredIssues),
+		fmt.Sprintf("Closed %d", status.ClosedIssues),
+	}
+}
+
+// RenderSnapshot renders snapshot as plain text, markdown, a standalone HTML
+// page or JSON.
+func RenderSnapshot(snapshot Snapshot, format string) (string, error) {
+	switch format {
+	case "text":
+		return snapshotText(snapshot), nil
+	case "markdown":
+		return snapshotMarkdown(snapshot), nil
+	case "html":
+		return snapshotHTML(snapshot)
+	case "json":
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("encode snapshot: %w", err)
+		}
+		return string(data) + "\n", nil
+	default:
+		return "", fmt.Errorf("unknown snapshot format %q (use %s)", format, strings.Join(SnapshotFormats, ", "))
+	}
+}
+
+func snapshotText(s Snapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Carnie dashboard  %s\n", s.GeneratedAt.Format("2006-01-02 15:04"))
+	b.WriteString(strings.Join(summaryCounts(s.Summary), "  ") + "\n")
+
+	fmt.Fprintf(&b, "\nOpen Features (%d)\n", len(s.Features))
+	for _, feature := range s.Features {
+		fmt.Fprintf(&b, "  P%d %s %s [%s]\n", feature.Priority, feature.ID, feature.Title, feature.Status)
+		for _, child := range feature.Children {
+			fmt.Fprintf(&b, "      P%d %s %s [%s]\n", child.Priority, child.ID, child.Title, child.Status)
+		}
+	}
+	if len(s.Features) == 0 {
+		b.WriteString("  (none)\n")
+	}
+
+	fmt.Fprintf(&b, "\nBlocked (%d)\n", len(s.Blocked))
+	for _, issue := range s.Blocked {
+		fmt.Fprintf(&b, "  P%d %s %s\n", issue.Priority, issue.ID, issue.Title)
+	}
+	if len(s.Blocked) == 0 {
+		b.WriteString("  (none)\n")
+	}
+
+	fmt.Fprintf(&b, "\nWork Orders (%d)\n", len(s.WorkOrders))
+	for _, order := range s.WorkOrders {
+		fmt.Fprintf(&b, "  #%d [%s] %s%s\n", order.ID, order.Status, order.Title, snapshotAssignee(order))
+	}
+	if len(s.WorkOrders) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	return b.String()
+}
+
+func snapshotMarkdown(s Snapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## Carnie status, %s\n\n", s.GeneratedAt.Format("2006-01-02 15:04"))
+	b.WriteString(strings.Join(summaryCounts(s.Summary), " · ") + "\n")
+
+	fmt.Fprintf(&b, "\n### Open features (%d)\n\n", len(s.Features))
+	for _, feature := range s.Features {
+		fmt.Fprintf(&b, "- **%s** %s `%s` P%d\n", feature.ID, markdownEscape(feature.Title), feature.Status, feature.Priority)
+		for _, child := range feature.Children {
+			check := " "
+			if child.Status == beads.StatusClosed {
+				check = "x"
+			}
+			fmt.Fprintf(&b, "  - [%s] %s %s `%s`\n", check, child.ID, markdownEscape(child.Title), child.Status)
+		}
+	}
+	if len(s.Features) == 0 {
+		b.WriteString("_None._\n")
+	}
+
+	fmt.Fprintf(&b, "\n### Blocked (%d)\n\n", len(s.Blocked))
+	for _, issue := range s.Blocked {
+		fmt.Fprintf(&b, "- **%s** %s P%d\n", issue.ID, markdownEscape(issue.Title), issue.Priority)
+	}
+	if len(s.Blocked) == 0 {
+		b.WriteString("_None._\n")
+	}
+
+	fmt.Fprintf(&b, "\n### Work orders (%d)\n\n", len(s.WorkOrders))
+	if len(s.WorkOrders) == 0 {
+		b.WriteString("_None._\n")
+		return b.String()
+	}
+	b.WriteString("| # | Status | Title | Bead | Assignee |\n|---|---|---|---|---|\n")
+	for _, order := range s.WorkOrders {
+		fmt.Fprintf(&b, "| %d | %s | %s | %s | %s |\n", order.ID, order.Status, markdownEscape(order.Title), order.BeadID, order.Assignee)
+	}
+	return b.String()
+}
+
+// markdownEscape escapes the characters that would change how a title
+// renders in markdown.
+func markdownEscape(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune("\\`*_[]<>|#", r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func snapshotAssignee(order SnapshotWorkOrder) string {
+	if order.Assignee == "" {
+		return ""
+	}
+	return " (" + order.Assignee + ")"
+}
+
+var snapshotPage = template.Must(template.New("snapshot").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Carnie status {{.GeneratedAt.Format "2006-01-02 15:04"}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem; color: #222; }
+h1 { font-size: 1.4rem; }
+h2 { font-size: 1.1rem; margin-top: 1.5rem; }
+.counts span { display: inline-block; margin-right: 1rem; padding: 0.2rem 0.5rem; background: #f2f2f2; border-radius: 4px; }
+table { border-collapse: collapse; }
+td, th { text-align: left; padding: 0.2rem 0.8rem 0.2rem 0; }
+.status { font-family: monospace; }
+.children td:first-child { padding-left: 1.5rem; }
+</style>
+</head>
+<body>
+<h1>Carnie status, {{.GeneratedAt.Format "2006-01-02 15:04"}}</h1>
+<p class="counts">{{range .Counts}}<span>{{.}}</span>{{end}}</p>
+<h2>Open features ({{len .Features}})</h2>
+{{if .Features}}<table>
+{{range .Features}}<tr><td><strong>{{.ID}}</strong> {{.Title}}</td><td class="status">{{.Status}}</td><td>P{{.Priority}}</td></tr>
+{{range .Children}}<tr class="children"><td>{{.ID}} {{.Title}}</td><td class="status">{{.Status}}</td><td>P{{.Priority}}</td></tr>
+{{end}}{{end}}</table>{{else}}<p>None.</p>{{end}}
+<h2>Blocked ({{len .Blocked}})</h2>
+{{if .Blocked}}<ul>
+{{range .Blocked}}<li><strong>{{.ID}}</strong> {{.Title}} (P{{.Priority}})</li>
+{{end}}</ul>{{else}}<p>None.</p>{{end}}
+<h2>Work orders ({{len .WorkOrders}})</h2>
+{{if .WorkOrders}}<table>
+<tr><th>#</th><th>Status</th><th>Title</th><th>Bead</th><th>Assignee</th></tr>
+{{range .WorkOrders}}<tr><td>{{.ID}}</td><td class="status">{{.Status}}</td><td>{{.Title}}</td><td>{{.BeadID}}</td><td>{{.Assignee}}</td></tr>
+{{end}}</table>{{else}}<p>None.</p>{{end}}
+</body>
+</html>
+`))
+
+func snapshotHTML(s Snapshot) (string, error) {
+	var b bytes.Buffer
+	data := struct {
+		Snapshot
+		Counts []string
+	}{s, summaryCounts(s.Summary)}
+	if err := snapshotPage.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("render snapshot: %w", err)
+	}
+	return b.String(), nil
+}
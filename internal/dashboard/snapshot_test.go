@@ -0,0 +1,66 @@
+package dashboard
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+func TestSnapshotFormats(t *testing.T) {
+	ctx := context.Background()
+	store, err := workorder.OpenStore(filepath.Join(t.TempDir(), "carniecamp.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+	if _, err := store.Create(ctx, workorder.CreateInput{Title: "Order", Description: "Do it", BeadID: "cn-2", Status: workorder.StatusReady}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	parent := []beads.Dependency{{IssueID: "cn-2", DependsOnID: "cn-1", Type: beads.DepParentChild}}
+	model := NewModel(beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Login <v2>", Status: beads.StatusOpen, Priority: 1, IssueType: "feature"},
+		beads.Issue{ID: "cn-2", Title: "Form", Status: beads.StatusClosed, IssueType: "task", Dependencies: parent},
+		beads.Issue{ID: "cn-3", Title: "API", Status: beads.StatusBlocked, Priority: 2, IssueType: "task"},
+		beads.Issue{ID: "cn-4", Title: "Later", Status: beads.StatusOpen, Priority: 3, IssueType: "feature"},
+	), 0, 0).WithWorkOrders(WorkOrders{Store: store})
+
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	snapshot, err := model.Snapshot(ctx, now)
+	if err != nil {
+		t.Fatalf("snapshot: %v", err)
+	}
+	if len(snapshot.Features) != 2 || snapshot.Features[0].ID != "cn-1" || len(snapshot.Features[0].Children) != 1 {
+		t.Fatalf("expected features in dashboard order with children, got %+v", snapshot.Features)
+	}
+	if len(snapshot.Blocked) != 1 || len(snapshot.WorkOrders) != 1 || snapshot.WorkOrders[0].Title != "Form" {
+		t.Fatalf("unexpected blocked issues or work orders: %+v %+v", snapshot.Blocked, snapshot.WorkOrders)
+	}
+
+	text, _ := RenderSnapshot(snapshot, "text")
+	if !strings.Contains(text, "Total 4  Open 2") || !strings.Contains(text, "      P0 cn-2 Form [closed]") {
+		t.Fatalf("unexpected text snapshot:\n%s", text)
+	}
+	markdown, _ := RenderSnapshot(snapshot, "markdown")
+	if !strings.Contains(markdown, "- **cn-1** Login \\<v2\\> `open` P1") || !strings.Contains(markdown, "  - [x] cn-2 Form `closed`") {
+		t.Fatalf("unexpected markdown snapshot:\n%s", markdown)
+	}
+	html, _ := RenderSnapshot(snapshot, "html")
+	if !strings.Contains(html, "Login &lt;v2&gt;") || !strings.Contains(html, "Carnie status, 2026-03-01 09:30") {
+		t.Fatalf("expected escaped html, got:\n%s", html)
+	}
+	raw, _ := RenderSnapshot(snapshot, "json")
+	var decoded Snapshot
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil || decoded.Features[0].Children[0].ID != "cn-2" {
+		t.Fatalf("expected json to round trip, got %v: %s", err, raw)
+	}
+	if _, err := RenderSnapshot(snapshot, "xml"); err == nil {
+		t.Fatal("expected an unknown format to fail")
+	}
+}
@@ -34,6 +34,12 @@ type dashboardStyles struct {
 	navbarBar          lipgloss.Style
 	navbarTitle        lipgloss.Style
 	navbarMeta         lipgloss.Style
+	navbarError        lipgloss.Style
+	navbarFilter       lipgloss.Style
+	mdHeading          lipgloss.Style
+	mdCode             lipgloss.Style
+	progressFilled     lipgloss.Style
+	progressEmpty      lipgloss.Style
 	navbarSub          lipgloss.Style
 	viewTabActive      lipgloss.Style
 	viewTabInactive    lipgloss.Style
@@ -88,6 +94,12 @@ func newDashboardStyles() dashboardStyles {
 		navbarBar:          lipgloss.NewStyle().Background(lipgloss.Color("124")).Foreground(lipgloss.Color("230")).Bold(true),
 		navbarTitle:        lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Bold(true),
 		navbarMeta:         lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Bold(true),
+		navbarError:        lipgloss.NewStyle().Foreground(lipgloss.Color("231")).Background(lipgloss.Color("160")).Bold(true),
+		navbarFilter:       lipgloss.NewStyle().Foreground(lipgloss.Color("222")).Background(lipgloss.Color("236")).Bold(true),
+		mdHeading:          lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Bold(true),
+		mdCode:             lipgloss.NewStyle().Foreground(lipgloss.Color("180")).Background(lipgloss.Color("236")),
+		progressFilled:     lipgloss.NewStyle().Foreground(lipgloss.Color("70")),
+		progressEmpty:      lipgloss.NewStyle().Foreground(lipgloss.Color("238")),
 		navbarSub:          lipgloss.NewStyle().Foreground(lipgloss.Color("229")),
 		viewTabActive:      lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("196")).Bold(true).Padding(0, 1),
 		viewTabInactive:    lipgloss.NewStyle().Foreground(lipgloss.Color("229")).Padding(0, 1),
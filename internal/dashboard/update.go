@@ -5,10 +5,11 @@ import (
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rikurb8/carnie/internal/beads"
 )
 
 func (m Model) Init() tea.Cmd {
-	cmds := []tea.Cmd{loadDashboardDataCmd(m.limit), m.tickCmd()}
+	cmds := []tea.Cmd{m.loadDataCmd(""), m.tickCmd()}
 	for i := range m.homeSpinners {
 		cmds = append(cmds, m.homeSpinners[i].Tick)
 	}
@@ -23,6 +24,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.applyDrawerLayout()
 		return m, nil
 	case tea.KeyMsg:
+		if m.inputActive {
+			return m.updateInput(typed)
+		}
+		if m.confirmActive {
+			return m.updateConfirm(typed)
+		}
+		if m.pickerActive {
+			return m.updatePicker(typed)
+		}
+		if m.formActive {
+			return m.updateForm(typed)
+		}
 		if m.showHelp {
 			switch typed.String() {
 			case "h", "esc":
@@ -33,6 +46,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.activeView == ViewWorkOrders {
+			if updated, cmd, handled := m.updateWorkOrdersKey(typed); handled {
+				return updated, cmd
+			}
+		}
+		if m.activeView == ViewBoard {
+			if updated, cmd, handled := m.updateBoardKey(typed); handled {
+				return updated, cmd
+			}
+		}
+		if m.activeView == ViewGraph {
+			if updated, cmd, handled := m.updateGraphKey(typed); handled {
+				return updated, cmd
+			}
+		}
+		if m.activeView == ViewEpics {
+			if updated, cmd, handled := m.updateEpicsKey(typed); handled {
+				return updated, cmd
+			}
+		}
+		if updated, cmd, handled := m.updateDetailKey(typed); handled {
+			return updated, cmd
+		}
+		if updated, cmd, handled := m.updateSearchKey(typed); handled {
+			return updated, cmd
+		}
+		if updated, cmd, handled := m.updateBeadActionKey(typed); handled {
+			return updated, cmd
+		}
+
 		switch typed.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
@@ -58,13 +101,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.activeColumn = (m.activeColumn - 1 + len(m.columns)) % len(m.columns)
 			return m, nil
 		case "r":
-			return m, loadDashboardDataCmd(m.limit)
+			return m, m.loadDataCmd("")
 		case "1":
 			m.activeView = ViewHome
 			return m, nil
 		case "2":
 			m.activeView = ViewTasks
 			return m, nil
+		case "3":
+			m.activeView = ViewWorkOrders
+			return m, m.loadWorkOrderEventsCmd()
+		case "4":
+			m.activeView = ViewBoard
+			return m, nil
+		case "5":
+			if m.graphRoot == "" {
+				if selected := m.selectedIssue(); selected != nil {
+					return m.openGraph(selected.ID), nil
+				}
+			}
+			m.activeView = ViewGraph
+			return m, nil
+		case "6":
+			m.activeView = ViewEpics
+			return m, nil
+		case "g":
+			if selected := m.actionIssue(); selected != nil {
+				return m.openGraph(selected.ID), nil
+			}
 		}
 
 		if len(m.lists) > 0 {
@@ -74,7 +138,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 	case tickMsg:
-		return m, tea.Batch(loadDashboardDataCmd(m.limit), m.tickCmd())
+		return m, tea.Batch(m.loadDataCmd(m.dataVersion), m.tickCmd())
+	case filesChangedMsg:
+		return m, m.loadDataCmd(m.dataVersion)
 	case dataMsg:
 		if typed.Err != nil {
 			m.errMessage = typed.Err.Error()
@@ -82,11 +148,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.errMessage = ""
 		m.lastUpdated = time.Now()
+		m.setWorkOrders(typed.Data.WorkOrders)
+		var eventsCmd tea.Cmd
+		if m.activeView == ViewWorkOrders {
+			eventsCmd = m.loadWorkOrderEventsCmd()
+		}
+		if typed.Unchanged {
+			return m, eventsCmd
+		}
+		m.dataVersion = typed.Version
 		m.summary = typed.Data.Status.Summary
-		m.updateColumns(typed.Data)
+		m.data = typed.Data
+		m.updateColumns(m.data)
 		drawerWidth, _, _, _ := drawerLayout(m.width, m.height)
 		innerWidth := maxInt(1, drawerWidth-2)
 		m.refreshDrawerLists(true, innerWidth)
+		return m, eventsCmd
+	case workOrderEventsMsg:
+		if typed.Err != nil {
+			m = m.withNotice(typed.Err.Error(), true)
+			return m, m.clearNoticeCmd()
+		}
+		m.orderEventsID = typed.ID
+		m.orderEvents = typed.Events
+		return m, nil
+	case actionMsg:
+		if typed.Err != nil {
+			m = m.withNotice(typed.Err.Error(), true)
+			return m, m.clearNoticeCmd()
+		}
+		m = m.withNotice(typed.Notice, false)
+		return m, tea.Batch(m.clearNoticeCmd(), m.loadDataCmd(""))
+	case clearNoticeMsg:
+		if typed.Seq == m.noticeSeq {
+			m.notice = ""
+		}
 		return m, nil
 	case spinner.TickMsg:
 		var cmds []tea.Cmd
@@ -134,17 +230,31 @@ func (m *Model) updateColumns(data dataState) {
 	allIssues = append(allIssues, data.Blocked...)
 	allIssues = append(allIssues, data.Closed...)
 	m.featureChildren = buildFeatureChildren(allIssues)
+	m.setBoard(buildBoard(data))
+	m.graph = beads.NewGraph(data.Issues)
+	m.clampEpicCursors()
+	m.beadTitles = make(map[string]string, len(allIssues))
+	for _, issue := range allIssues {
+		m.beadTitles[issue.ID] = issue.Title
+	}
 
-	openFeatures := filterOpenFeatures(data)
-	orderedFeatures, featureParents, featureLevels := orderIssuesWithParents(openFeatures)
+	// A search lists every matching issue instead of the open features.
+	title := "Open Features"
+	listed := filterOpenFeatures(data)
+	if !m.filter.Empty() {
+		title = "Search Results"
+		listed = filterIssues(allIssues, m.filter, m.search.Me)
+	}
+	orderedFeatures, featureParents, featureLevels := orderIssuesWithParents(listed)
 
 	if len(m.columns) == 0 {
-		m.columns = []issueColumn{{Title: "Open Features"}}
+		m.columns = []issueColumn{{Title: title}}
 	}
 	if m.activeColumn >= len(m.columns) {
 		m.activeColumn = 0
 	}
 	if len(m.columns) > 0 {
+		m.columns[0].Title = title
 		m.columns[0].Issues = orderedFeatures
 		m.columns[0].ParentByID = featureParents
 		m.columns[0].LevelByID = featureLevels
@@ -0,0 +1,112 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce groups the bursts of writes a single bd or workorder command
+// makes into one reload.
+const watchDebounce = 250 * time.Millisecond
+
+// Watch watches dirs (the .beads directory and the camp's .carnie directory)
+// and tells program to reload shortly after a database or issues file in them
+// changes. The reload starts from the model's last known data version, so
+// beads are only re-read when they changed. Missing directories are skipped.
+// It returns an error when nothing can be watched, in which case the
+// dashboard keeps polling on its refresh interval. Watching stops when ctx is
+// done.
+func Watch(ctx context.Context, program *tea.Program, dirs ...string) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("start file watcher: %w", err)
+	}
+
+	watched := 0
+	for _, dir := range dirs {
+		if err := watcher.Add(dir); err == nil {
+			watched++
+		}
+	}
+	if watched == 0 {
+		_ = watcher.Close()
+		return fmt.Errorf("no directories to watch")
+	}
+
+	changes := make(chan string)
+	go func() {
+		defer close(changes)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Op == fsnotify.Chmod || !isDataFile(event.Name) {
+					continue
+				}
+				select {
+				case changes <- event.Name:
+				case <-ctx.Done():
+					return
+				}
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	go func() {
+		defer watcher.Close()
+		debounceChanges(changes, watchDebounce, func() {
+			program.Send(filesChangedMsg{})
+		})
+	}()
+	return nil
+}
+
+// debounceChanges calls notify once changes has been quiet for delay after
+// one or more changes, until changes is closed.
+func debounceChanges(changes <-chan string, delay time.Duration, notify func()) {
+	timer := time.NewTimer(delay)
+	timer.Stop()
+	pending := false
+	for {
+		select {
+		case _, ok := <-changes:
+			if !ok {
+				timer.Stop()
+				return
+			}
+			timer.Reset(delay)
+			pending = true
+		case <-timer.C:
+			if pending {
+				pending = false
+				notify()
+			}
+		}
+	}
+}
+
+// isDataFile reports whether path holds beads or work order data, as
+// opposed to logs, locks and sockets that change without affecting it.
+func isDataFile(path string) bool {
+	name := filepath.Base(path)
+	for _, suffix := range []string{".db", ".db-wal", ".jsonl"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
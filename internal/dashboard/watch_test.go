@@ -0,0 +1,54 @@
+package dashboard
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDebounceChangesGroupsBursts(t *testing.T) {
+	changes := make(chan string)
+	notified := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		debounceChanges(changes, 20*time.Millisecond, func() { notified <- struct{}{} })
+		close(done)
+	}()
+
+	for i := 0; i < 5; i++ {
+		changes <- "issues.jsonl"
+	}
+	select {
+	case <-notified:
+	case <-time.After(time.Second):
+		t.Fatal("expected a notification after the burst")
+	}
+
+	changes <- "beads.db-wal"
+	select {
+	case <-notified:
+	case <-time.After(time.Second):
+		t.Fatal("expected a notification for the later change")
+	}
+
+	close(changes)
+	<-done
+	if len(notified) != 0 {
+		t.Fatalf("expected one notification per burst, got %d extra", len(notified))
+	}
+}
+
+func TestIsDataFile(t *testing.T) {
+	for path, want := range map[string]bool{
+		"/repo/.beads/issues.jsonl":       true,
+		"/repo/.beads/beads.db-wal":       true,
+		"/repo/.carnie/carniecamp.db":     true,
+		"/repo/.beads/daemon.log":         false,
+		"/repo/.beads/bd.sock":            false,
+		"/repo/.carnie/carniecamp.db-shm": false,
+		"/repo/.carnie/daemon.pid":        false,
+	} {
+		if got := isDataFile(path); got != want {
+			t.Fatalf("isDataFile(%s) = %v, want %v", path, got, want)
+		}
+	}
+}
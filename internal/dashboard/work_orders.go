@@ -0,0 +1,364 @@
+package dashboard
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+// WorkOrderStore is the part of the work order store the dashboard uses.
+type WorkOrderStore interface {
+	List(ctx context.Context, opts workorder.ListOptions) ([]workorder.WorkOrder, error)
+	ListEvents(ctx context.Context, workOrderID int64) ([]workorder.Event, error)
+	UpdateStatus(ctx context.Context, id int64, next workorder.Status) (workorder.WorkOrder, error)
+	AddEvent(ctx context.Context, input workorder.EventInput) (workorder.Event, error)
+}
+
+// WorkOrders connects the dashboard to the camp's work orders. Actor is
+// recorded on events the dashboard adds; Prompt renders an order's prompt
+// for copying.
+type WorkOrders struct {
+	Store  WorkOrderStore
+	Actor  string
+	Prompt func(order workorder.WorkOrder) (string, error)
+}
+
+// WithWorkOrders enables the Work Orders view.
+func (m Model) WithWorkOrders(orders WorkOrders) Model {
+	m.workOrders = orders
+	return m
+}
+
+// workOrderGroups is the display order of the status groups.
+var workOrderGroups = []workorder.Status{
+	workorder.StatusInProgress,
+	workorder.StatusReady,
+	workorder.StatusBlocked,
+	workorder.StatusDraft,
+	workorder.StatusDone,
+	workorder.StatusCanceled,
+}
+
+type workOrderEventsMsg struct {
+	ID     int64
+	Events []workorder.Event
+	Err    error
+}
+
+// groupWorkOrders orders work orders by status group, keeping the store's
+// most-recently-updated-first order within each group.
+func groupWorkOrders(orders []workorder.WorkOrder) []workorder.WorkOrder {
+	grouped := make([]workorder.WorkOrder, 0, len(orders))
+	for _, status := range workOrderGroups {
+		for _, order := range orders {
+			if order.Status == status {
+				grouped = append(grouped, order)
+			}
+		}
+	}
+	return grouped
+}
+
+func (m Model) selectedWorkOrder() *workorder.WorkOrder {
+	if m.orderCursor < 0 || m.orderCursor >= len(m.orders) {
+		return nil
+	}
+	order := m.orders[m.orderCursor]
+	return &order
+}
+
+// setWorkOrders replaces the listed orders, keeping the selected order
+// selected when it is still present.
+func (m *Model) setWorkOrders(orders []workorder.WorkOrder) {
+	selectedID := int64(0)
+	if selected := m.selectedWorkOrder(); selected != nil {
+		selectedID = selected.ID
+	}
+	m.orders = groupWorkOrders(orders)
+	for i, order := range m.orders {
+		if order.ID == selectedID {
+			m.orderCursor = i
+			return
+		}
+	}
+	if m.orderCursor >= len(m.orders) {
+		m.orderCursor = maxInt(0, len(m.orders)-1)
+	}
+}
+
+func (m Model) loadWorkOrderEventsCmd() tea.Cmd {
+	selected := m.selectedWorkOrder()
+	if selected == nil || m.workOrders.Store == nil {
+		return nil
+	}
+	store, id := m.workOrders.Store, selected.ID
+	return func() tea.Msg {
+		events, err := store.ListEvents(context.Background(), id)
+		return workOrderEventsMsg{ID: id, Events: events, Err: err}
+	}
+}
+
+func (m Model) updateWorkOrdersKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.String() {
+	case "j", "down":
+		if m.orderCursor < len(m.orders)-1 {
+			m.orderCursor++
+		}
+		return m, m.loadWorkOrderEventsCmd(), true
+	case "k", "up":
+		if m.orderCursor > 0 {
+			m.orderCursor--
+		}
+		return m, m.loadWorkOrderEventsCmd(), true
+	case "s":
+		return m.transitionSelected(workorder.StatusInProgress, "")
+	case "d":
+		return m.transitionSelected(workorder.StatusDone, "")
+	case "b":
+		selected := m.selectedWorkOrder()
+		if selected == nil {
+			return m, nil, true
+		}
+		if _, err := workorder.Transition(*selected, workorder.StatusBlocked, time.Now()); err != nil {
+			return m.withNotice(err.Error(), true), m.clearNoticeCmd(), true
+		}
+		m.input = newPromptInput("Why is it blocked?")
+		m.inputLabel = fmt.Sprintf("Block work order #%d", selected.ID)
+		m.inputSubmit = func(m Model, reason string) (Model, tea.Cmd) {
+			model, cmd, _ := m.transitionSelected(workorder.StatusBlocked, reason)
+			return model, cmd
+		}
+		m.inputActive = true
+		return m, nil, true
+	case "p":
+		selected := m.selectedWorkOrder()
+		if selected == nil || m.workOrders.Prompt == nil {
+			return m, nil, true
+		}
+		order, render := *selected, m.workOrders.Prompt
+		return m, func() tea.Msg {
+			prompt, err := render(order)
+			if err != nil {
+				return actionMsg{Err: err}
+			}
+			if err := clipboard.WriteAll(prompt); err != nil {
+				return actionMsg{Err: fmt.Errorf("copy prompt: %w", err)}
+			}
+			return actionMsg{Notice: fmt.Sprintf("Copied prompt for #%d", order.ID)}
+		}, true
+	}
+	return m, nil, false
+}
+
+// transitionSelected moves the selected order to next, checking the
+// transition first so invalid keys report why instead of touching the store.
+// A reason is recorded as a note on the order.
+func (m Model) transitionSelected(next workorder.Status, reason string) (Model, tea.Cmd, bool) {
+	selected := m.selectedWorkOrder()
+	if selected == nil || m.workOrders.Store == nil {
+		return m, nil, true
+	}
+	if _, err := workorder.Transition(*selected, next, time.Now()); err != nil {
+		return m.withNotice(err.Error(), true), m.clearNoticeCmd(), true
+	}
+
+	store, actor, id := m.workOrders.Store, m.workOrders.Actor, selected.ID
+	return m, func() tea.Msg {
+		ctx := context.Background()
+		order, err := store.UpdateStatus(ctx, id, next)
+		if err != nil {
+			return actionMsg{Err: err}
+		}
+		if reason != "" {
+			if _, err := store.AddEvent(ctx, workorder.EventInput{
+				WorkOrderID: id,
+				Kind:        workorder.EventNote,
+				Actor:       actor,
+				Detail:      fmt.Sprintf("%s: %s", next, reason),
+			}); err != nil {
+				return actionMsg{Err: err}
+			}
+		}
+		return actionMsg{Notice: fmt.Sprintf("Work order #%d is now %s", order.ID, order.Status)}
+	}, true
+}
+
+func renderWorkOrdersView(m Model, styles dashboardStyles) string {
+	width := m.width
+	if width <= 0 {
+		return ""
+	}
+	listWidth, bodyHeight, _, _ := drawerLayout(m.width, m.height)
+	gap := 2
+	if listWidth+gap >= width {
+		return renderWorkOrderList(m, width, bodyHeight, styles)
+	}
+	left := renderWorkOrderList(m, listWidth, bodyHeight, styles)
+	right := renderWorkOrderDetail(m, width-listWidth-gap, bodyHeight, styles)
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, strings.Repeat(" ", gap), right)
+}
+
+func renderWorkOrderList(m Model, width int, height int, styles dashboardStyles) string {
+	innerWidth := maxInt(1, width-2)
+	borderStyle := styles.paneBorderActive
+	rows := []string{
+		renderPaneTopRule(innerWidth, borderStyle),
+		renderPaneHeaderLine(fmt.Sprintf("Work Orders (%d)", len(m.orders)), innerWidth, styles.columnTitle, borderStyle),
+	}
+
+	var lines []string
+	cursorLine := 0
+	if m.workOrders.Store == nil {
+		lines = append(lines, styles.dimText.Render(truncateASCII("No camp found; run carnie camp init.", innerWidth)))
+	} else if len(m.orders) == 0 {
+		lines = append(lines, styles.dimText.Render(truncateASCII("No work orders yet.", innerWidth)))
+	}
+	now := time.Now()
+	var group workorder.Status
+	for i, order := range m.orders {
+		if i == 0 || order.Status != group {
+			group = order.Status
+			lines = append(lines, styles.columnHeader.Render(truncateASCII(fmt.Sprintf("%s (%d)", workOrderGroupTitle(group), countStatus(m.orders, group)), innerWidth)))
+		}
+		title := m.beadTitles[order.BeadID]
+		if title == "" {
+			title = order.Title
+		}
+		age := formatAge(now.Sub(order.CreatedAt))
+		left := truncateASCII(fmt.Sprintf("#%d %s", order.ID, title), maxInt(1, innerWidth-len(age)-1))
+		line := left + strings.Repeat(" ", maxInt(1, innerWidth-len(left)-len(age))) + age
+		style := styles.drawerItem
+		if i == m.orderCursor {
+			style = styles.drawerItemSelected
+			cursorLine = len(lines)
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	listHeight := maxInt(1, height-len(rows)-1)
+	start := 0
+	if cursorLine >= listHeight {
+		start = cursorLine - listHeight + 1
+	}
+	for i := start; i < len(lines) && i < start+listHeight; i++ {
+		rows = append(rows, renderPaneRawRow(lines[i], innerWidth, borderStyle))
+	}
+	for len(rows) < height-1 {
+		rows = append(rows, renderPaneRawRow("", innerWidth, borderStyle))
+	}
+	rows = append(rows, renderPaneBottomRule(innerWidth, borderStyle))
+	return lipgloss.NewStyle().Width(width).Render(lipgloss.JoinVertical(lipgloss.Left, rows...))
+}
+
+func renderWorkOrderDetail(m Model, width int, height int, styles dashboardStyles) string {
+	selected := m.selectedWorkOrder()
+	if selected == nil {
+		return renderPanel("Work Order", []string{styles.dimText.Render(truncateASCII("Select a work order to see details.", width))}, width, height, styles)
+	}
+
+	field := func(label string, value string) string {
+		return styles.dimText.Render(truncateASCII(fmt.Sprintf("%s: %s", label, value), width))
+	}
+	lines := []string{
+		styles.panelTitle.Render(truncateASCII(fmt.Sprintf("#%d %s", selected.ID, selected.Title), width)),
+		field("Status", string(selected.Status)),
+	}
+	if selected.BeadID != "" {
+		bead := selected.BeadID
+		if title := m.beadTitles[selected.BeadID]; title != "" {
+			bead += " " + title
+		}
+		lines = append(lines, field("Bead", bead))
+	}
+	if selected.Crew != "" {
+		lines = append(lines, field("Crew", selected.Crew))
+	}
+	if selected.Assignee != "" {
+		lines = append(lines, field("Assignee", selected.Assignee))
+	}
+	lines = append(lines, field("Created", selected.CreatedAt.Local().Format("Jan 02 15:04")))
+	lines = append(lines, field("Updated", selected.UpdatedAt.Local().Format("Jan 02 15:04")))
+	if selected.StartedAt != nil {
+		lines = append(lines, field("Started", selected.StartedAt.Local().Format("Jan 02 15:04")))
+	}
+	if selected.CompletedAt != nil {
+		lines = append(lines, field("Completed", selected.CompletedAt.Local().Format("Jan 02 15:04")))
+	}
+
+	if selected.Description != "" {
+		lines = append(lines, "", styles.panelTitle.Render("Description"))
+		for _, line := range wrapLines(selected.Description, width) {
+			lines = append(lines, styles.item.Render(truncateASCII(line, width)))
+		}
+	}
+
+	lines = append(lines, "", styles.panelTitle.Render("History"))
+	switch {
+	case m.orderEventsID != selected.ID:
+		lines = append(lines, styles.dimText.Render("Loading..."))
+	case len(m.orderEvents) == 0:
+		lines = append(lines, styles.dimText.Render("(none)"))
+	default:
+		for _, event := range m.orderEvents {
+			line := fmt.Sprintf("%s %s", event.CreatedAt.Local().Format("Jan 02 15:04"), event.Kind)
+			if event.Actor != "" {
+				line += " by " + event.Actor
+			}
+			if event.Detail != "" {
+				line += ": " + event.Detail
+			}
+			lines = append(lines, styles.item.Render(truncateASCII(line, width)))
+		}
+	}
+
+	return renderPanel("Work Order", lines, width, height, styles)
+}
+
+func workOrderGroupTitle(status workorder.Status) string {
+	switch status {
+	case workorder.StatusInProgress:
+		return "In Progress"
+	case workorder.StatusReady:
+		return "Ready"
+	case workorder.StatusBlocked:
+		return "Blocked"
+	case workorder.StatusDraft:
+		return "Draft"
+	case workorder.StatusDone:
+		return "Done"
+	case workorder.StatusCanceled:
+		return "Canceled"
+	default:
+		return string(status)
+	}
+}
+
+func countStatus(orders []workorder.WorkOrder, status workorder.Status) int {
+	count := 0
+	for _, order := range orders {
+		if order.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// formatAge renders a duration compactly: 45s, 12m, 5h, 3d.
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return fmt.Sprintf("%ds", maxInt(0, int(age.Seconds())))
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours()/24))
+	}
+}
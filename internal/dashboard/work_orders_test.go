@@ -0,0 +1,121 @@
+package dashboard
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+func keyPress(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "backspace":
+		return tea.KeyMsg{Type: tea.KeyBackspace}
+	case "ctrl+s":
+		return tea.KeyMsg{Type: tea.KeyCtrlS}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "shift+right":
+		return tea.KeyMsg{Type: tea.KeyShiftRight}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func press(t *testing.T, m Model, key string) (Model, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(keyPress(key))
+	return updated.(Model), cmd
+}
+
+func TestGroupWorkOrders(t *testing.T) {
+	orders := []workorder.WorkOrder{
+		{ID: 1, Status: workorder.StatusDone},
+		{ID: 2, Status: workorder.StatusReady},
+		{ID: 3, Status: workorder.StatusInProgress},
+		{ID: 4, Status: workorder.StatusReady},
+	}
+	grouped := groupWorkOrders(orders)
+	want := []int64{3, 2, 4, 1}
+	for i, id := range want {
+		if grouped[i].ID != id {
+			t.Fatalf("expected order %v, got %+v", want, grouped)
+		}
+	}
+}
+
+func TestWorkOrderKeysTransitionOrders(t *testing.T) {
+	ctx := context.Background()
+	store, err := workorder.OpenStore(filepath.Join(t.TempDir(), "carniecamp.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	ready, err := store.Create(ctx, workorder.CreateInput{Title: "Ship it", Description: "Release the build", BeadID: "cn-1", Status: workorder.StatusReady})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	model := NewModel(beads.NewFake(beads.Issue{ID: "cn-1", Title: "Bead title", Status: beads.StatusOpen}), 0, 0).
+		WithWorkOrders(WorkOrders{Store: store, Actor: "tester"})
+	updated, _ := model.Update(model.loadDataCmd("")())
+	model = updated.(Model)
+	model.activeView = ViewWorkOrders
+	if len(model.orders) != 1 || model.beadTitles["cn-1"] != "Bead title" {
+		t.Fatalf("expected the order and bead title to load, got %+v", model.orders)
+	}
+
+	model, _ = press(t, model, "d")
+	if model.notice == "" || !model.noticeErr {
+		t.Fatal("expected ready -> done to be rejected")
+	}
+
+	model, cmd := press(t, model, "s")
+	if cmd == nil {
+		t.Fatal("expected a start command")
+	}
+	if msg := cmd().(actionMsg); msg.Err != nil {
+		t.Fatalf("start: %v", msg.Err)
+	}
+	started, _ := store.Get(ctx, ready.ID)
+	if started.Status != workorder.StatusInProgress {
+		t.Fatalf("expected in_progress, got %s", started.Status)
+	}
+	model.setWorkOrders([]workorder.WorkOrder{started})
+
+	model, _ = press(t, model, "b")
+	if !model.inputActive {
+		t.Fatal("expected a reason prompt")
+	}
+	for _, r := range "waiting on API" {
+		model, _ = press(t, model, string(r))
+	}
+	model, cmd = press(t, model, "enter")
+	if model.inputActive || cmd == nil {
+		t.Fatal("expected the prompt to submit")
+	}
+	if msg := cmd().(actionMsg); msg.Err != nil {
+		t.Fatalf("block: %v", msg.Err)
+	}
+
+	blocked, _ := store.Get(ctx, ready.ID)
+	if blocked.Status != workorder.StatusBlocked {
+		t.Fatalf("expected blocked, got %s", blocked.Status)
+	}
+	events, _ := store.ListEvents(ctx, ready.ID)
+	last := events[len(events)-1]
+	if last.Kind != workorder.EventNote || last.Detail != "blocked: waiting on API" || last.Actor != "tester" {
+		t.Fatalf("expected the reason as a note, got %+v", last)
+	}
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rikurb8/carnie/internal/session"
+)
+
+// ServerName is the key Carnie's entry uses in MCP client configs.
+const ServerName = "carnie"
+
+// ConfigFile returns the project file tool reads MCP servers from.
+func ConfigFile(tool session.Tool) string {
+	if tool == session.ToolOpencode {
+		return "opencode.json"
+	}
+	return ".mcp.json"
+}
+
+// WriteConfig adds the carnie server to tool's project config under root,
+// keeping every other setting and server in the file. command is how the
+// client should start carnie, e.g. ["carnie", "mcp"]. It returns the file
+// written.
+func WriteConfig(root string, tool session.Tool, command []string) (string, error) {
+	if len(command) == 0 {
+		return "", errors.New("command is required")
+	}
+	path := filepath.Join(root, ConfigFile(tool))
+
+	config := map[string]any{}
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if len(bytes.TrimSpace(data)) > 0 {
+			if err := json.Unmarshal(data, &config); err != nil {
+				return "", fmt.Errorf("parse %s: %w", path, err)
+			}
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return "", fmt.Errorf("read %s: %w", path, err)
+	}
+
+	// Claude keys servers under mcpServers with the command split from its
+	// arguments; opencode keys them under mcp with the full command line.
+	key, entry := "mcpServers", map[string]any{"command": command[0], "args": command[1:]}
+	if tool == session.ToolOpencode {
+		key, entry = "mcp", map[string]any{"type": "local", "command": command, "enabled": true}
+		if _, ok := config["$schema"]; !ok {
+			config["$schema"] = "https://opencode.ai/config.json"
+		}
+	}
+	servers, ok := config[key].(map[string]any)
+	if !ok {
+		if _, exists := config[key]; exists {
+			return "", fmt.Errorf("%s: %q is not an object", path, key)
+		}
+		servers = map[string]any{}
+	}
+	servers[ServerName] = entry
+	config[key] = servers
+
+	out, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, append(out, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("write %s: %w", path, err)
+	}
+	return path, nil
+}
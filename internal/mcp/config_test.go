@@ -0,0 +1,62 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rikurb8/carnie/internal/session"
+)
+
+func TestWriteConfigKeepsExistingSettings(t *testing.T) {
+	root := t.TempDir()
+	existing := `{"model": "anthropic/sonnet", "mcp": {"other": {"type": "remote", "url": "https://example.com"}}}`
+	if err := os.WriteFile(filepath.Join(root, "opencode.json"), []byte(existing), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	for _, tool := range []session.Tool{session.ToolOpencode, session.ToolClaude, session.ToolClaude} {
+		if _, err := WriteConfig(root, tool, []string{"carnie", "mcp"}); err != nil {
+			t.Fatalf("write %s config: %v", tool, err)
+		}
+	}
+
+	var opencode struct {
+		Model string                    `json:"model"`
+		MCP   map[string]map[string]any `json:"mcp"`
+	}
+	readJSON(t, filepath.Join(root, "opencode.json"), &opencode)
+	if opencode.Model != "anthropic/sonnet" || opencode.MCP["other"] == nil || opencode.MCP["carnie"]["type"] != "local" {
+		t.Fatalf("unexpected opencode config %+v", opencode)
+	}
+
+	var claude struct {
+		MCPServers map[string]struct {
+			Command string   `json:"command"`
+			Args    []string `json:"args"`
+		} `json:"mcpServers"`
+	}
+	readJSON(t, filepath.Join(root, ".mcp.json"), &claude)
+	if entry := claude.MCPServers["carnie"]; len(claude.MCPServers) != 1 || entry.Command != "carnie" || len(entry.Args) != 1 || entry.Args[0] != "mcp" {
+		t.Fatalf("unexpected claude config %+v", claude)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ".mcp.json"), []byte(`{"mcpServers": []}`), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if _, err := WriteConfig(root, session.ToolClaude, []string{"carnie", "mcp"}); err == nil {
+		t.Fatal("expected a malformed mcpServers entry to be reported")
+	}
+}
+
+func readJSON(t *testing.T, path string, value any) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		t.Fatalf("decode %s: %v", path, err)
+	}
+}
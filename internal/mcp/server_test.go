@@ -0,0 +1,200 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+func newTestServer(t *testing.T) (*Server, *beads.Fake, *workorder.Store) {
+	t.Helper()
+	store, err := workorder.OpenStore(filepath.Join(t.TempDir(), "carniecamp.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	for _, title := range []string{"Build login", "Write docs"} {
+		if _, err := store.Create(t.Context(), workorder.CreateInput{Title: title, Description: "Do it", BeadID: "cn-2", Status: workorder.StatusReady}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Auth", Status: beads.StatusOpen, IssueType: "feature"},
+		beads.Issue{ID: "cn-2", Title: "Login", Status: beads.StatusOpen, IssueType: "task", Dependencies: []beads.Dependency{
+			{IssueID: "cn-2", DependsOnID: "cn-1", Type: beads.DepParentChild},
+			{IssueID: "cn-2", DependsOnID: "cn-3", Type: beads.DepBlocks},
+		}},
+		beads.Issue{ID: "cn-3", Title: "Schema", Status: beads.StatusOpen, IssueType: "task"},
+	)
+	return &Server{Beads: client, Store: store, Agent: "bot"}, client, store
+}
+
+type testResponse struct {
+	ID     int             `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+// exchange sends messages to the server and returns its responses.
+func exchange(t *testing.T, server *Server, messages ...string) []testResponse {
+	t.Helper()
+	var out bytes.Buffer
+	if err := server.Serve(t.Context(), strings.NewReader(strings.Join(messages, "\n")+"\n"), &out); err != nil {
+		t.Fatalf("serve: %v", err)
+	}
+	var responses []testResponse
+	decoder := json.NewDecoder(&out)
+	for decoder.More() {
+		var resp testResponse
+		if err := decoder.Decode(&resp); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
+
+// callTool runs one tool and returns its text and whether it failed.
+func callTool(t *testing.T, server *Server, name string, args string) (string, bool) {
+	t.Helper()
+	responses := exchange(t, server, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":%s}}`, name, args))
+	if len(responses) != 1 || responses[0].Error != nil {
+		t.Fatalf("unexpected responses to %s: %+v", name, responses)
+	}
+	var result struct {
+		Content []struct{ Text string } `json:"content"`
+		IsError bool                    `json:"isError"`
+	}
+	if err := json.Unmarshal(responses[0].Result, &result); err != nil || len(result.Content) != 1 {
+		t.Fatalf("unexpected tool result %s: %v", responses[0].Result, err)
+	}
+	return result.Content[0].Text, result.IsError
+}
+
+func TestProtocol(t *testing.T) {
+	server, _, _ := newTestServer(t)
+	responses := exchange(t, server,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2024-11-05","capabilities":{}}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"resources/list"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"nope"}}`,
+		`not json`,
+	)
+	if len(responses) != 5 {
+		t.Fatalf("expected five responses (none for the notification), got %+v", responses)
+	}
+	if !strings.Contains(string(responses[0].Result), `"protocolVersion":"2024-11-05"`) {
+		t.Fatalf("expected the requested protocol version, got %s", responses[0].Result)
+	}
+	for _, name := range []string{"list_ready_work", "claim_work_order", "transition_work_order", "add_note", "show_bead", "create_bead"} {
+		if !strings.Contains(string(responses[1].Result), `"name":"`+name+`"`) {
+			t.Fatalf("expected tool %s in %s", name, responses[1].Result)
+		}
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != codeMethodNotFound {
+		t.Fatalf("expected method not found, got %+v", responses[2])
+	}
+	if responses[3].Error == nil || responses[3].Error.Code != codeInvalidParams {
+		t.Fatalf("expected an unknown tool to be invalid params, got %+v", responses[3])
+	}
+	if responses[4].Error == nil || responses[4].Error.Code != codeParseError {
+		t.Fatalf("expected a parse error, got %+v", responses[4])
+	}
+}
+
+func TestWorkOrderTools(t *testing.T) {
+	server, _, store := newTestServer(t)
+
+	text, failed := callTool(t, server, "list_ready_work", `{}`)
+	if failed || !strings.Contains(text, "Build login") || !strings.Contains(text, `"id": "cn-3"`) || strings.Contains(text, `"id": "cn-2"`) {
+		t.Fatalf("expected ready orders and unblocked beads, got %s", text)
+	}
+
+	if text, failed := callTool(t, server, "claim_work_order", `{"id":1,"lease":"1h"}`); failed || !strings.Contains(text, `"assignee": "bot"`) {
+		t.Fatalf("claim: %s", text)
+	}
+	if text, failed := callTool(t, server, "claim_work_order", `{"id":9}`); !failed || text != "work order 9 not found" {
+		t.Fatalf("expected a missing order to fail, got %q", text)
+	}
+	if text, failed := callTool(t, server, "transition_work_order", `{"id":1,"status":"draft"}`); !failed || !strings.Contains(text, "cannot transition") {
+		t.Fatalf("expected the transition to be rejected, got %q", text)
+	}
+	if text, failed := callTool(t, server, "transition_work_order", `{"id":1,"status":"done","note":"shipped"}`); failed || !strings.Contains(text, `"status": "done"`) {
+		t.Fatalf("transition: %s", text)
+	}
+	if _, err := store.Claim(t.Context(), 2, "alice", time.Hour); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if text, failed := callTool(t, server, "transition_work_order", `{"id":2,"status":"done"}`); !failed || !strings.Contains(text, "claimed by alice") {
+		t.Fatalf("expected another agent's claim to be respected, got %q", text)
+	}
+	if text, failed := callTool(t, server, "add_note", `{"id":2,"note":"  "}`); !failed || text != "note is required" {
+		t.Fatalf("expected an empty note to fail, got %q", text)
+	}
+	if text, failed := callTool(t, server, "add_note", `{"id":2,"note":"looking","extra":1}`); !failed || !strings.Contains(text, "unknown field") {
+		t.Fatalf("expected unknown arguments to fail, got %q", text)
+	}
+	if _, failed := callTool(t, server, "add_note", `{"id":2,"note":"looking"}`); failed {
+		t.Fatal("expected the note to be added")
+	}
+
+	events, err := store.ListEvents(t.Context(), 1)
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if last := events[len(events)-1]; last.Kind != workorder.EventNote || last.Detail != "done: shipped" || last.Actor != "bot" {
+		t.Fatalf("expected the transition note, got %+v", last)
+	}
+}
+
+func TestBeadTools(t *testing.T) {
+	server, client, _ := newTestServer(t)
+
+	text, failed := callTool(t, server, "show_bead", `{"id":"cn-2"}`)
+	if failed || !strings.Contains(text, `"parent": {`) || !strings.Contains(text, `"title": "Schema"`) || !strings.Contains(text, "Write docs") {
+		t.Fatalf("unexpected bead detail: %s", text)
+	}
+	if _, failed := callTool(t, server, "show_bead", `{"id":"cn-9"}`); !failed {
+		t.Fatal("expected a missing bead to fail")
+	}
+
+	for args, want := range map[string]string{
+		`{"title":" "}`:                               "title is required",
+		`{"title":"Fix","type":"story"}`:              "invalid type",
+		`{"title":"Fix","priority":7}`:                "out of range",
+		`{"title":"Fix","discovered_from":"cn-9"}`:    "not found",
+		`{"title":"Fix","parent":"cn-1","owner":"x"}`: "unknown field",
+	} {
+		if text, failed := callTool(t, server, "create_bead", args); !failed || !strings.Contains(text, want) {
+			t.Fatalf("expected %s to fail with %q, got %q", args, want, text)
+		}
+	}
+	if len(client.Issues()) != 3 {
+		t.Fatalf("expected rejected creates to leave beads alone, got %d", len(client.Issues()))
+	}
+
+	text, failed = callTool(t, server, "create_bead", `{"title":"Fix token refresh","type":"bug","priority":1,"parent":"cn-1","discovered_from":"cn-2"}`)
+	if failed {
+		t.Fatalf("create: %s", text)
+	}
+	var created beads.Issue
+	if err := json.Unmarshal([]byte(text), &created); err != nil {
+		t.Fatalf("decode created bead: %v", err)
+	}
+	bead, err := client.Show(t.Context(), created.ID)
+	if err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	if bead.Title != "Fix token refresh" || bead.Priority != 1 || bead.ParentID() != "cn-1" || len(bead.Dependencies) != 2 || bead.Dependencies[1].Type != beads.DepDiscoveredFrom {
+		t.Fatalf("unexpected created bead %+v", bead)
+	}
+}
@@ -0,0 +1,357 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+// beadTypes are the issue types create_bead accepts, as bd defines them.
+var beadTypes = []string{"task", "bug", "feature", "epic", "chore"}
+
+// tool is an MCP tool: its advertised definition and the handler behind it.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+
+	call func(s *Server, ctx context.Context, args json.RawMessage) (any, error)
+}
+
+var tools = []tool{
+	{
+		Name:        "list_ready_work",
+		Description: "List ready work orders and the open beads nothing blocks. Expired claims are returned to ready first.",
+		InputSchema: object(nil, map[string]any{
+			"limit": property("integer", "Max entries per list (default 20)"),
+		}),
+		call: (*Server).listReadyWork,
+	},
+	{
+		Name:        "claim_work_order",
+		Description: "Claim a ready or in-progress work order for this agent and start a lease. The order moves to in_progress.",
+		InputSchema: object([]string{"id"}, map[string]any{
+			"id":    property("integer", "Work order ID"),
+			"lease": property("string", "Lease duration such as 30m or 2h (default 15m)"),
+		}),
+		call: (*Server).claimWorkOrder,
+	},
+	{
+		Name:        "transition_work_order",
+		Description: "Move a work order to another status: draft, ready, in_progress, blocked, done or canceled. Moving to in_progress claims the order and moving it back to ready releases the claim. Invalid transitions and orders another agent holds are rejected.",
+		InputSchema: object([]string{"id", "status"}, map[string]any{
+			"id":     property("integer", "Work order ID"),
+			"status": property("string", "New status"),
+			"note":   property("string", "Why the status changed, recorded on the work order"),
+		}),
+		call: (*Server).transitionWorkOrder,
+	},
+	{
+		Name:        "add_note",
+		Description: "Record a progress note on a work order.",
+		InputSchema: object([]string{"id", "note"}, map[string]any{
+			"id":   property("integer", "Work order ID"),
+			"note": property("string", "The note"),
+		}),
+		call: (*Server).addNote,
+	},
+	{
+		Name:        "show_bead",
+		Description: "Read a bead with its description, parent, children, blockers, dependents and work orders.",
+		InputSchema: object([]string{"id"}, map[string]any{
+			"id": property("string", "Bead ID"),
+		}),
+		call: (*Server).showBead,
+	},
+	{
+		Name:        "create_bead",
+		Description: "Create a follow-up bead, optionally under a parent and linked to the bead it was discovered from.",
+		InputSchema: object([]string{"title"}, map[string]any{
+			"title":           property("string", "Title"),
+			"description":     property("string", "Description"),
+			"type":            property("string", "One of "+strings.Join(beadTypes, ", ")+" (default task)"),
+			"priority":        property("integer", "0 (highest) to 4 (default 2)"),
+			"parent":          property("string", "Parent bead ID"),
+			"discovered_from": property("string", "ID of the bead this work was discovered while doing"),
+			"labels":          map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		}),
+		call: (*Server).createBead,
+	},
+}
+
+func object(required []string, properties map[string]any) map[string]any {
+	schema := map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func property(kind string, description string) map[string]any {
+	return map[string]any{"type": kind, "description": description}
+}
+
+// callTool runs a tool. Failures the agent can act on, like an invalid
+// transition, come back as tool results with isError set; only unknown
+// tools are protocol errors.
+func (s *Server) callTool(ctx context.Context, params json.RawMessage) (any, error) {
+	var call struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, fmt.Errorf("invalid tools/call params: %w", err)
+	}
+	for _, candidate := range tools {
+		if candidate.Name != call.Name {
+			continue
+		}
+		result, err := candidate.call(s, ctx, call.Arguments)
+		if err != nil {
+			return toolResult(err.Error(), true), nil
+		}
+		text, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return toolResult(string(text), false), nil
+	}
+	return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + call.Name}
+}
+
+func toolResult(text string, isError bool) map[string]any {
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": text}},
+		"isError": isError,
+	}
+}
+
+// decodeArgs reads tool arguments strictly so typos are reported instead of
+// ignored.
+func decodeArgs(args json.RawMessage, value any) error {
+	if len(bytes.TrimSpace(args)) == 0 || string(args) == "null" {
+		args = json.RawMessage("{}")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(args))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) listReadyWork(ctx context.Context, args json.RawMessage) (any, error) {
+	var input struct {
+		Limit int `json:"limit"`
+	}
+	if err := decodeArgs(args, &input); err != nil {
+		return nil, err
+	}
+	if input.Limit <= 0 {
+		input.Limit = 20
+	}
+
+	issues, err := s.Beads.List(ctx, beads.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ready := beads.Ready(issues)
+	if len(ready) > input.Limit {
+		ready = ready[:input.Limit]
+	}
+	result := struct {
+		WorkOrders []workorder.WorkOrder `json:"work_orders"`
+		Beads      []beads.Issue         `json:"beads"`
+	}{WorkOrders: []workorder.WorkOrder{}, Beads: append([]beads.Issue{}, ready...)}
+
+	if s.Store != nil {
+		if _, err := s.Store.ExpireLeases(ctx, time.Now()); err != nil {
+			return nil, err
+		}
+		status := workorder.StatusReady
+		orders, err := s.Store.List(ctx, workorder.ListOptions{Status: &status, Limit: input.Limit})
+		if err != nil {
+			return nil, err
+		}
+		result.WorkOrders = append(result.WorkOrders, orders...)
+	}
+	return result, nil
+}
+
+func (s *Server) claimWorkOrder(ctx context.Context, args json.RawMessage) (any, error) {
+	var input struct {
+		ID    int64  `json:"id"`
+		Lease string `json:"lease"`
+	}
+	if err := decodeArgs(args, &input); err != nil {
+		return nil, err
+	}
+	if err := s.requireStore(); err != nil {
+		return nil, err
+	}
+	lease := s.Lease
+	if input.Lease != "" {
+		parsed, err := time.ParseDuration(input.Lease)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid lease %q", input.Lease)
+		}
+		lease = parsed
+	}
+	if _, err := s.Store.ExpireLeases(ctx, time.Now()); err != nil {
+		return nil, err
+	}
+	order, err := s.Store.Claim(ctx, input.ID, s.Agent, lease)
+	return order, notFound(err, input.ID)
+}
+
+func (s *Server) transitionWorkOrder(ctx context.Context, args json.RawMessage) (any, error) {
+	var input struct {
+		ID     int64  `json:"id"`
+		Status string `json:"status"`
+		Note   string `json:"note"`
+	}
+	if err := decodeArgs(args, &input); err != nil {
+		return nil, err
+	}
+	if err := s.requireStore(); err != nil {
+		return nil, err
+	}
+	next, err := workorder.ParseStatus(input.Status)
+	if err != nil {
+		return nil, err
+	}
+	order, err := s.Store.Move(ctx, input.ID, next, s.Agent, false)
+	if err != nil {
+		return nil, notFound(err, input.ID)
+	}
+	if note := strings.TrimSpace(input.Note); note != "" {
+		if _, err := s.Store.AddEvent(ctx, workorder.EventInput{
+			WorkOrderID: order.ID,
+			Kind:        workorder.EventNote,
+			Actor:       s.Agent,
+			Detail:      fmt.Sprintf("%s: %s", next, note),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func (s *Server) addNote(ctx context.Context, args json.RawMessage) (any, error) {
+	var input struct {
+		ID   int64  `json:"id"`
+		Note string `json:"note"`
+	}
+	if err := decodeArgs(args, &input); err != nil {
+		return nil, err
+	}
+	if err := s.requireStore(); err != nil {
+		return nil, err
+	}
+	note := strings.TrimSpace(input.Note)
+	if note == "" {
+		return nil, errors.New("note is required")
+	}
+	if _, err := s.Store.Get(ctx, input.ID); err != nil {
+		return nil, notFound(err, input.ID)
+	}
+	return s.Store.AddEvent(ctx, workorder.EventInput{
+		WorkOrderID: input.ID,
+		Kind:        workorder.EventNote,
+		Actor:       s.Agent,
+		Detail:      note,
+	})
+}
+
+func (s *Server) showBead(ctx context.Context, args json.RawMessage) (any, error) {
+	var input struct {
+		ID string `json:"id"`
+	}
+	if err := decodeArgs(args, &input); err != nil {
+		return nil, err
+	}
+	return workorder.LoadBeadDetail(ctx, s.Beads, s.Store, input.ID)
+}
+
+func (s *Server) createBead(ctx context.Context, args json.RawMessage) (any, error) {
+	var input struct {
+		Title          string   `json:"title"`
+		Description    string   `json:"description"`
+		Type           string   `json:"type"`
+		Priority       *int     `json:"priority"`
+		Parent         string   `json:"parent"`
+		DiscoveredFrom string   `json:"discovered_from"`
+		Labels         []string `json:"labels"`
+	}
+	if err := decodeArgs(args, &input); err != nil {
+		return nil, err
+	}
+
+	create := beads.CreateInput{
+		Title:       strings.TrimSpace(input.Title),
+		Description: input.Description,
+		Type:        input.Type,
+		Priority:    2,
+		Parent:      input.Parent,
+		Labels:      input.Labels,
+	}
+	if create.Title == "" {
+		return nil, errors.New("title is required")
+	}
+	if create.Type == "" {
+		create.Type = "task"
+	}
+	if !slices.Contains(beadTypes, create.Type) {
+		return nil, fmt.Errorf("invalid type %q (use %s)", create.Type, strings.Join(beadTypes, ", "))
+	}
+	if input.Priority != nil {
+		if *input.Priority < 0 || *input.Priority > 4 {
+			return nil, fmt.Errorf("priority %d is out of range 0-4", *input.Priority)
+		}
+		create.Priority = *input.Priority
+	}
+	// Check the links up front so a typo does not leave a half-linked bead.
+	for _, id := range []string{input.Parent, input.DiscoveredFrom} {
+		if id == "" {
+			continue
+		}
+		if _, err := s.Beads.Show(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	id, err := s.Beads.Create(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	if input.DiscoveredFrom != "" {
+		if err := s.Beads.AddDep(ctx, id, input.DiscoveredFrom, beads.DepDiscoveredFrom); err != nil {
+			return nil, fmt.Errorf("created %s but could not link it to %s: %w", id, input.DiscoveredFrom, err)
+		}
+	}
+	return s.Beads.Show(ctx, id)
+}
+
+func (s *Server) requireStore() error {
+	if s.Store == nil {
+		return errors.New("no camp found; run carnie camp init first")
+	}
+	return nil
+}
+
+// notFound names the missing work order instead of reporting sql.ErrNoRows.
+func notFound(err error, id int64) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("work order %d not found", id)
+	}
+	return err
+}
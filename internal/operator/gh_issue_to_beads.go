@@ -1,17 +1,19 @@
 package operator
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"os/exec"
-	"text/template"
 	"time"
 
 	"github.com/rikurb8/carnie/internal/session"
 	"github.com/rikurb8/carnie/internal/templates"
 )
 
+func init() {
+	templates.RegisterData("issue-to-beads.md.tmpl", GHIssue{})
+}
+
 // GHUser represents a GitHub user (author, assignee, commenter)
 type GHUser struct {
 	ID    string `json:"id"`
@@ -134,23 +136,8 @@ func PrintIssue(issue *GHIssue) {
 }
 
 // RenderIssueToBeadsPrompt renders the issue-to-beads template with issue data
-func RenderIssueToBeadsPrompt(issue *GHIssue) (string, error) {
-	tmplContent, err := templates.Load("issue-to-beads.md.tmpl")
-	if err != nil {
-		return "", fmt.Errorf("load template: %w", err)
-	}
-
-	tmpl, err := template.New("issue-to-beads").Parse(tmplContent)
-	if err != nil {
-		return "", fmt.Errorf("parse template: %w", err)
-	}
-
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, issue); err != nil {
-		return "", fmt.Errorf("execute template: %w", err)
-	}
-
-	return buf.String(), nil
+func RenderIssueToBeadsPrompt(engine templates.Engine, issue *GHIssue) (string, error) {
+	return engine.Render("issue-to-beads.md.tmpl", issue)
 }
 
 // IssueToBeadsCommand contains the command to start an issue-to-beads session
@@ -161,8 +148,8 @@ type IssueToBeadsCommand struct {
 }
 
 // BuildIssueToBeadsCommand builds a command to start opencode with the issue-to-beads prompt
-func BuildIssueToBeadsCommand(issue *GHIssue, model string) (IssueToBeadsCommand, error) {
-	prompt, err := RenderIssueToBeadsPrompt(issue)
+func BuildIssueToBeadsCommand(engine templates.Engine, issue *GHIssue, model string) (IssueToBeadsCommand, error) {
+	prompt, err := RenderIssueToBeadsPrompt(engine, issue)
 	if err != nil {
 		return IssueToBeadsCommand{}, err
 	}
@@ -48,31 +48,45 @@ Help the user think through their idea and create a well-structured epic with cl
    - P3: Low priority, nice to have
    - P4: Backlog, future consideration
 
-## Creating Beads
-
-When the user is ready, help them create the epic and tasks using these commands:
-
-### Create the Epic
-` + "```" + `bash
-bd create --title="Epic title here" --type=epic --priority=2 --description="Detailed description of the epic goals and scope"
-` + "```" + `
-
-### Create Tasks
-` + "```" + `bash
-bd create --title="Task title" --type=task --priority=1 --description="Clear description of what needs to be done"
+## Writing the Plan
+
+When the user is ready, write the plan as a YAML plan file instead of bd commands.
+Carnie validates it and creates the beads, parents and dependencies in one step.
+
+` + "```" + `yaml
+epic:
+  key: auth
+  title: "Epic title here"
+  priority: 2
+  description: "Detailed description of the epic goals and scope"
+features:
+  - key: login
+    title: "Feature title"
+    priority: 1
+    description: "What this feature delivers"
+    tasks:
+      - key: login-api
+        title: "Task title"
+        priority: 1
+        description: "Clear description of what needs to be done"
+      - key: login-ui
+        title: "Another task"
+        description: "Clear description of what needs to be done"
+        depends_on: [login-api]
+tasks:
+  - key: docs
+    title: "Task directly under the epic"
+    priority: 3
+    description: "Clear description of what needs to be done"
+    depends_on: [login]
 ` + "```" + `
 
-### Link Tasks to Epic
-After creating, link tasks to the epic:
-` + "```" + `bash
-bd dep add <epic-id> <task-id>
-` + "```" + `
+- ` + "`key`" + ` is a short local name, unique within the plan; ` + "`depends_on`" + ` lists keys that must finish first
+- ` + "`priority`" + ` is 0-4 and defaults to 2
+- Features are optional; small epics can list ` + "`tasks`" + ` directly
 
-### Set Task Dependencies
-If task B depends on task A completing first:
-` + "```" + `bash
-bd dep add <task-B-id> <task-A-id>
-` + "```" + `
+Ask the user to save it (e.g. ` + "`plan.yml`" + `), check it with ` + "`carnie plan validate plan.yml`" + `,
+preview with ` + "`carnie plan apply plan.yml --dry-run`" + ` and create the beads with ` + "`carnie plan apply plan.yml`" + `.
 
 ## Guidelines
 
@@ -91,9 +105,9 @@ When presenting the final plan, format it clearly:
 1. **Epic Summary** - One paragraph describing the goal
 2. **Tasks** - Numbered list with title, priority, and brief description
 3. **Dependencies** - Which tasks block others
-4. **Commands** - The exact bd commands to run
+4. **Plan File** - The complete YAML plan in a single code block
 
-Ask the user to confirm before running any commands.`
+Ask the user to confirm the plan before they apply it.`
 
 func epicPlanningInitialPrompt(title string) string {
 	if title == "" {
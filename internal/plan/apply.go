@@ -0,0 +1,106 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// Creator creates beads and dependencies. BeadsCreator talks to bd; tests
+// and dry runs use their own implementations.
+type Creator interface {
+	Create(ctx context.Context, input beads.CreateInput) (string, error)
+	AddDependency(ctx context.Context, issueID string, dependsOnID string) error
+}
+
+// Created maps a plan key to the bead created for it.
+type Created struct {
+	Key   string
+	ID    string
+	Type  string
+	Title string
+}
+
+// Apply validates the plan, creates every item in order with its parent, then
+// adds the dependencies using the resolved IDs. On failure it returns what was
+// created so far so the caller can report partial progress.
+func Apply(ctx context.Context, p Plan, creator Creator) ([]Created, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries := p.Entries()
+	ids := make(map[string]string, len(entries))
+	created := make([]Created, 0, len(entries))
+
+	for _, entry := range entries {
+		id, err := creator.Create(ctx, beads.CreateInput{
+			Title:       entry.Title,
+			Type:        entry.Type,
+			Priority:    entry.PriorityOrDefault(),
+			Description: entry.Description,
+			Parent:      ids[entry.ParentKey],
+			Labels:      entry.Labels,
+		})
+		if err != nil {
+			return created, fmt.Errorf("create %s %q: %w", entry.Type, entry.Key, err)
+		}
+		ids[entry.Key] = id
+		created = append(created, Created{Key: entry.Key, ID: id, Type: entry.Type, Title: entry.Title})
+	}
+
+	for _, entry := range entries {
+		for _, dep := range entry.DependsOn {
+			if err := creator.AddDependency(ctx, ids[entry.Key], ids[dep]); err != nil {
+				return created, fmt.Errorf("add dependency %s -> %s: %w", entry.Key, dep, err)
+			}
+		}
+	}
+
+	return created, nil
+}
+
+// BeadsCreator creates beads through a beads client.
+type BeadsCreator struct {
+	Client beads.Client
+}
+
+func (c BeadsCreator) Create(ctx context.Context, input beads.CreateInput) (string, error) {
+	return c.Client.Create(ctx, input)
+}
+
+func (c BeadsCreator) AddDependency(ctx context.Context, issueID string, dependsOnID string) error {
+	return c.Client.AddDep(ctx, issueID, dependsOnID, beads.DepBlocks)
+}
+
+// DryRunCreator records the bd commands that would run, using each item's
+// key as a placeholder ID.
+type DryRunCreator struct {
+	Commands [][]string
+	next     []string
+}
+
+func (c *DryRunCreator) Create(ctx context.Context, input beads.CreateInput) (string, error) {
+	c.Commands = append(c.Commands, append([]string{"bd"}, input.Args()...))
+	id := fmt.Sprintf("<new-%d>", len(c.Commands))
+	if len(c.next) > 0 {
+		id, c.next = c.next[0], c.next[1:]
+	}
+	return id, nil
+}
+
+func (c *DryRunCreator) AddDependency(ctx context.Context, issueID string, dependsOnID string) error {
+	c.Commands = append(c.Commands, []string{"bd", "dep", "add", issueID, dependsOnID, "--type=" + beads.DepBlocks})
+	return nil
+}
+
+// NewDryRunCreator returns a dry-run creator that reports created IDs as
+// "<key>" placeholders for the plan's entries.
+func NewDryRunCreator(p Plan) *DryRunCreator {
+	creator := &DryRunCreator{}
+	for _, entry := range p.Entries() {
+		creator.next = append(creator.next, "<"+entry.Key+">")
+	}
+	return creator
+}
@@ -0,0 +1,209 @@
+package plan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultPriority is used for items that do not set a priority.
+const DefaultPriority = 2
+
+// Plan is a structured epic breakdown that can be applied to beads.
+type Plan struct {
+	Epic     Item      `yaml:"epic" json:"epic"`
+	Features []Feature `yaml:"features,omitempty" json:"features,omitempty"`
+	Tasks    []Item    `yaml:"tasks,omitempty" json:"tasks,omitempty"` // tasks directly under the epic
+}
+
+// Feature groups tasks under the epic.
+type Feature struct {
+	Item  `yaml:",inline"`
+	Tasks []Item `yaml:"tasks,omitempty" json:"tasks,omitempty"`
+}
+
+// Item is a single bead in the plan. Key is a local name used by DependsOn;
+// real bead IDs are only known after the plan is applied.
+type Item struct {
+	Key         string   `yaml:"key" json:"key"`
+	Title       string   `yaml:"title" json:"title"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Priority    *int     `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Labels      []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+	DependsOn   []string `yaml:"depends_on,omitempty" json:"depends_on,omitempty"`
+}
+
+// PriorityOrDefault returns the item priority or DefaultPriority.
+func (i Item) PriorityOrDefault() int {
+	if i.Priority == nil {
+		return DefaultPriority
+	}
+	return *i.Priority
+}
+
+// Entry is an item with its bead type and parent key, in creation order.
+type Entry struct {
+	Item
+	Type      string
+	ParentKey string
+}
+
+// Entries flattens the plan in creation order: epic, each feature followed
+// by its tasks, then tasks directly under the epic.
+func (p Plan) Entries() []Entry {
+	entries := []Entry{{Item: p.Epic, Type: "epic"}}
+	for _, feature := range p.Features {
+		entries = append(entries, Entry{Item: feature.Item, Type: "feature", ParentKey: p.Epic.Key})
+		for _, task := range feature.Tasks {
+			entries = append(entries, Entry{Item: task, Type: "task", ParentKey: feature.Key})
+		}
+	}
+	for _, task := range p.Tasks {
+		entries = append(entries, Entry{Item: task, Type: "task", ParentKey: p.Epic.Key})
+	}
+	return entries
+}
+
+// Load reads a plan from a .json, .yml or .yaml file. Unknown fields are
+// rejected so typos do not silently drop data.
+func Load(path string) (Plan, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Plan{}, fmt.Errorf("read plan: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ParseJSON(data)
+	}
+	return ParseYAML(data)
+}
+
+func ParseYAML(data []byte) (Plan, error) {
+	var plan Plan
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&plan); err != nil {
+		return Plan{}, fmt.Errorf("parse plan YAML: %w", err)
+	}
+	return plan, nil
+}
+
+func ParseJSON(data []byte) (Plan, error) {
+	var plan Plan
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&plan); err != nil {
+		return Plan{}, fmt.Errorf("parse plan JSON: %w", err)
+	}
+	return plan, nil
+}
+
+// Validate reports every problem found in the plan: missing keys or titles,
+// duplicate keys, out of range priorities, unknown or self dependencies and
+// dependency cycles.
+func (p Plan) Validate() error {
+	var problems []error
+	entries := p.Entries()
+
+	keys := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		label := describe(entry)
+		if entry.Key == "" {
+			problems = append(problems, fmt.Errorf("%s: key is required", label))
+		} else if keys[entry.Key] {
+			problems = append(problems, fmt.Errorf("%s: duplicate key", label))
+		}
+		keys[entry.Key] = true
+
+		if strings.TrimSpace(entry.Title) == "" {
+			problems = append(problems, fmt.Errorf("%s: title is required", label))
+		}
+		if entry.Priority != nil && (*entry.Priority < 0 || *entry.Priority > 4) {
+			problems = append(problems, fmt.Errorf("%s: priority %d is out of range 0-4", label, *entry.Priority))
+		}
+	}
+
+	for _, entry := range entries {
+		for _, dep := range entry.DependsOn {
+			switch {
+			case dep == entry.Key:
+				problems = append(problems, fmt.Errorf("%s: depends on itself", describe(entry)))
+			case !keys[dep]:
+				problems = append(problems, fmt.Errorf("%s: depends on unknown key %q", describe(entry), dep))
+			}
+		}
+	}
+
+	if cycle := findCycle(entries); len(cycle) > 0 {
+		problems = append(problems, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> ")))
+	}
+
+	return errors.Join(problems...)
+}
+
+func describe(entry Entry) string {
+	if entry.Key != "" {
+		return fmt.Sprintf("%s %q", entry.Type, entry.Key)
+	}
+	if entry.Title != "" {
+		return fmt.Sprintf("%s titled %q", entry.Type, entry.Title)
+	}
+	return entry.Type
+}
+
+// findCycle returns the keys of the first dependency cycle found, with the
+// starting key repeated at the end, or nil.
+func findCycle(entries []Entry) []string {
+	deps := make(map[string][]string, len(entries))
+	for _, entry := range entries {
+		deps[entry.Key] = append(deps[entry.Key], entry.DependsOn...)
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(entries))
+	var stack []string
+
+	var visit func(key string) []string
+	visit = func(key string) []string {
+		state[key] = visiting
+		stack = append(stack, key)
+		for _, dep := range deps[key] {
+			if _, known := deps[dep]; !known || dep == key {
+				continue
+			}
+			switch state[dep] {
+			case visiting:
+				for i, item := range stack {
+					if item == dep {
+						return append(append([]string(nil), stack[i:]...), dep)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dep); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[key] = done
+		return nil
+	}
+
+	for _, entry := range entries {
+		if state[entry.Key] == unvisited {
+			if cycle := visit(entry.Key); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
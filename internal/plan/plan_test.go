@@ -0,0 +1,139 @@
+package plan
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+const samplePlan = `
+epic:
+  key: auth
+  title: Authentication
+  description: Let users sign in
+features:
+  - key: login
+    title: Login
+    priority: 1
+    tasks:
+      - key: login-api
+        title: Login API
+      - key: login-ui
+        title: Login UI
+        depends_on: [login-api]
+tasks:
+  - key: docs
+    title: Document auth
+    priority: 3
+    depends_on: [login]
+`
+
+func TestParseYAMLAndJSON(t *testing.T) {
+	fromYAML, err := ParseYAML([]byte(samplePlan))
+	if err != nil {
+		t.Fatalf("parse yaml: %v", err)
+	}
+	if err := fromYAML.Validate(); err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(fromYAML.Entries()) != 5 {
+		t.Fatalf("expected 5 entries, got %d", len(fromYAML.Entries()))
+	}
+
+	fromJSON, err := ParseJSON([]byte(`{"epic":{"key":"e","title":"Epic"},"features":[{"key":"f","title":"Feature","tasks":[{"key":"t","title":"Task","priority":0}]}]}`))
+	if err != nil {
+		t.Fatalf("parse json: %v", err)
+	}
+	entries := fromJSON.Entries()
+	if len(entries) != 3 || entries[2].ParentKey != "f" || entries[2].PriorityOrDefault() != 0 {
+		t.Fatalf("unexpected entries %+v", entries)
+	}
+
+	if _, err := ParseYAML([]byte("epic:\n  key: e\n  title: E\n  prio: 1\n")); err == nil {
+		t.Fatal("expected unknown field to be rejected")
+	}
+}
+
+func TestValidateReportsProblems(t *testing.T) {
+	bad, err := ParseYAML([]byte(`
+epic:
+  key: e
+  title: Epic
+tasks:
+  - key: a
+    title: A
+    priority: 7
+    depends_on: [b, missing]
+  - key: b
+    title: ""
+    depends_on: [a]
+  - key: a
+    title: Duplicate
+`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	err = bad.Validate()
+	if err == nil {
+		t.Fatal("expected validation errors")
+	}
+	for _, want := range []string{"priority 7", "unknown key \"missing\"", "title is required", "duplicate key", "dependency cycle"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("expected %q in %v", want, err)
+		}
+	}
+}
+
+type fakeCreator struct {
+	created []beads.CreateInput
+	deps    [][2]string
+}
+
+func (f *fakeCreator) Create(ctx context.Context, input beads.CreateInput) (string, error) {
+	f.created = append(f.created, input)
+	return "cn-" + strings.ToLower(strings.ReplaceAll(input.Title, " ", "")), nil
+}
+
+func (f *fakeCreator) AddDependency(ctx context.Context, issueID string, dependsOnID string) error {
+	f.deps = append(f.deps, [2]string{issueID, dependsOnID})
+	return nil
+}
+
+func TestApplyResolvesKeys(t *testing.T) {
+	loaded, err := ParseYAML([]byte(samplePlan))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	creator := &fakeCreator{}
+	created, err := Apply(context.Background(), loaded, creator)
+	if err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(created) != 5 || created[0].ID != "cn-authentication" {
+		t.Fatalf("unexpected mapping %+v", created)
+	}
+
+	if parent := creator.created[2].Parent; parent != "cn-login" {
+		t.Fatalf("expected login-api parent cn-login, got %q", parent)
+	}
+	if creator.created[2].Priority != DefaultPriority {
+		t.Fatalf("expected default priority, got %d", creator.created[2].Priority)
+	}
+
+	expected := [][2]string{
+		{"cn-loginui", "cn-loginapi"},
+		{"cn-documentauth", "cn-login"},
+	}
+	if len(creator.deps) != len(expected) {
+		t.Fatalf("expected deps %v, got %v", expected, creator.deps)
+	}
+	for i := range expected {
+		if creator.deps[i] != expected[i] {
+			t.Fatalf("expected deps %v, got %v", expected, creator.deps)
+		}
+	}
+}
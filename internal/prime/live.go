@@ -0,0 +1,449 @@
+package prime
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+// Section names one block of live context.
+type Section string
+
+const (
+	SectionCamp     Section = "camp"
+	SectionWork     Section = "work"
+	SectionBead     Section = "bead"
+	SectionReady    Section = "ready"
+	SectionEvents   Section = "events"
+	SectionWorktree Section = "worktree"
+)
+
+// Sections lists every live section in the order they are printed.
+var Sections = []Section{SectionCamp, SectionWork, SectionBead, SectionReady, SectionEvents, SectionWorktree}
+
+// DefaultBudgets caps each section's body, in bytes. Long descriptions and
+// lists are cut at a line boundary so a prime stays small enough to paste
+// into a fresh context.
+var DefaultBudgets = map[Section]int{
+	SectionCamp:     600,
+	SectionWork:     2500,
+	SectionBead:     2000,
+	SectionReady:    1200,
+	SectionEvents:   1200,
+	SectionWorktree: 600,
+}
+
+var sectionTitles = map[Section]string{
+	SectionCamp:     "Camp",
+	SectionWork:     "Current Work Order",
+	SectionBead:     "Bead",
+	SectionReady:    "Ready Beads",
+	SectionEvents:   "Recent Events",
+	SectionWorktree: "Worktree",
+}
+
+// ParseSection validates a section name.
+func ParseSection(value string) (Section, error) {
+	for _, section := range Sections {
+		if string(section) == value {
+			return section, nil
+		}
+	}
+	names := make([]string, len(Sections))
+	for i, section := range Sections {
+		names[i] = string(section)
+	}
+	return "", fmt.Errorf("unknown section %q (use %s)", value, strings.Join(names, ", "))
+}
+
+// LiveSources is where live sections read from. Any of them may be missing;
+// the sections that need it then say so.
+type LiveSources struct {
+	Root  string // camp or project root; git runs here
+	Camp  *config.CampConfig
+	Beads beads.Client
+	Store *workorder.Store
+	Agent string
+	// WorkOrderID picks the current work order instead of looking for the
+	// agent's claim.
+	WorkOrderID int64
+}
+
+// LiveOptions selects and sizes the live sections.
+type LiveOptions struct {
+	Sections   []Section       // nil prints every section
+	Budgets    map[Section]int // overrides DefaultBudgets; 0 or less means no cap
+	ReadyLimit int             // ready beads listed (default 10)
+	EventLimit int             // events listed (default 10)
+	Now        time.Time
+	// Git runs git in dir; nil runs the git binary.
+	Git func(ctx context.Context, dir string, args ...string) (string, error)
+}
+
+// live carries what several sections share, loaded once.
+type live struct {
+	src   LiveSources
+	opts  LiveOptions
+	order *workorder.WorkOrder
+	why   string
+	graph *beads.Graph
+	err   error
+}
+
+// RenderLive renders the selected live sections as markdown, to append to a
+// role prompt. Failures are reported inside their section rather than
+// failing the prime.
+func RenderLive(ctx context.Context, src LiveSources, opts LiveOptions) string {
+	if opts.Sections == nil {
+		opts.Sections = Sections
+	}
+	if opts.ReadyLimit <= 0 {
+		opts.ReadyLimit = 10
+	}
+	if opts.EventLimit <= 0 {
+		opts.EventLimit = 10
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Git == nil {
+		opts.Git = runGit
+	}
+	state := &live{src: src, opts: opts}
+	state.load(ctx)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n---\n\n# Live Context\n\n_Generated %s._\n", opts.Now.Format("2006-01-02 15:04"))
+	for _, section := range Sections {
+		if !containsSection(opts.Sections, section) {
+			continue
+		}
+		var body string
+		switch section {
+		case SectionCamp:
+			body = state.camp(ctx)
+		case SectionWork:
+			body = state.work()
+		case SectionBead:
+			body = state.bead()
+		case SectionReady:
+			body = state.ready()
+		case SectionEvents:
+			body = state.events(ctx)
+		case SectionWorktree:
+			body = state.worktree(ctx)
+		}
+		budget, ok := opts.Budgets[section]
+		if !ok {
+			budget = DefaultBudgets[section]
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", sectionTitles[section], truncateSection(strings.TrimRight(body, "\n"), section, budget))
+	}
+	return b.String()
+}
+
+func (l *live) load(ctx context.Context) {
+	if l.src.Beads != nil {
+		issues, err := l.src.Beads.List(ctx, beads.ListOptions{})
+		if err != nil {
+			l.err = err
+		} else {
+			l.graph = beads.NewGraph(issues)
+		}
+	}
+	if l.src.Store != nil {
+		l.order, l.why = l.currentOrder(ctx)
+	}
+}
+
+// currentOrder finds the work order the agent is on: the one asked for,
+// else the agent's claim (in progress first), else the only order in
+// progress in the camp.
+func (l *live) currentOrder(ctx context.Context) (*workorder.WorkOrder, string) {
+	store := l.src.Store
+	if l.src.WorkOrderID != 0 {
+		order, err := store.Get(ctx, l.src.WorkOrderID)
+		if err != nil {
+			return nil, fmt.Sprintf("work order %d not found", l.src.WorkOrderID)
+		}
+		return &order, "requested"
+	}
+	if l.src.Agent != "" {
+		orders, err := store.List(ctx, workorder.ListOptions{Assignee: l.src.Agent})
+		if err == nil {
+			var fallback *workorder.WorkOrder
+			for i := range orders {
+				order := orders[i]
+				if order.Status == workorder.StatusInProgress {
+					return &order, "claimed by " + l.src.Agent
+				}
+				if fallback == nil && order.Status != workorder.StatusDone && order.Status != workorder.StatusCanceled {
+					fallback = &order
+				}
+			}
+			if fallback != nil {
+				return fallback, "assigned to " + l.src.Agent
+			}
+		}
+	}
+	status := workorder.StatusInProgress
+	orders, err := store.List(ctx, workorder.ListOptions{Status: &status})
+	if err == nil && len(orders) == 1 {
+		return &orders[0], "the only work order in progress"
+	}
+	return nil, ""
+}
+
+func (l *live) camp(ctx context.Context) string {
+	if l.src.Camp == nil {
+		return "_No camp found; run `carnie camp init`._"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "- Name: %s\n", l.src.Camp.Name)
+	if l.src.Camp.Description != "" {
+		fmt.Fprintf(&b, "- Description: %s\n", l.src.Camp.Description)
+	}
+	if l.src.Root != "" {
+		fmt.Fprintf(&b, "- Root: %s\n", l.src.Root)
+	}
+	if l.src.Agent != "" {
+		fmt.Fprintf(&b, "- You: %s\n", l.src.Agent)
+	}
+	if l.src.Store != nil {
+		if counts, err := l.src.Store.CountByStatus(ctx); err == nil {
+			var parts []string
+			for _, status := range workorder.ValidStatuses() {
+				if counts[status] > 0 {
+					parts = append(parts, fmt.Sprintf("%d %s", counts[status], status))
+				}
+			}
+			if len(parts) == 0 {
+				parts = []string{"none"}
+			}
+			fmt.Fprintf(&b, "- Work orders: %s\n", strings.Join(parts, ", "))
+		}
+	}
+	return b.String()
+}
+
+func (l *live) work() string {
+	if l.src.Store == nil {
+		return "_No work order database._"
+	}
+	if l.order == nil {
+		if l.why != "" {
+			return "_" + l.why + "._"
+		}
+		return "_No work order is claimed by or assigned to you._"
+	}
+	order := l.order
+	var b strings.Builder
+	fmt.Fprintf(&b, "### #%d %s\n\n", order.ID, order.Title)
+	fmt.Fprintf(&b, "- Status: %s (%s)\n", order.Status, l.why)
+	if order.Assignee != "" {
+		lease := ""
+		if order.LeaseUntil != nil {
+			lease = fmt.Sprintf(", lease until %s", order.LeaseUntil.Local().Format("15:04"))
+			if order.LeaseExpired(l.opts.Now) {
+				lease = ", lease expired"
+			}
+		}
+		fmt.Fprintf(&b, "- Assignee: %s%s\n", order.Assignee, lease)
+	}
+	if order.Crew != "" {
+		fmt.Fprintf(&b, "- Crew: %s\n", order.Crew)
+	}
+	if order.BeadID != "" {
+		fmt.Fprintf(&b, "- Bead: %s\n", order.BeadID)
+	}
+	fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(order.Description))
+	return b.String()
+}
+
+func (l *live) bead() string {
+	if l.order == nil {
+		return "_No current work order._"
+	}
+	if l.order.BeadID == "" {
+		return "_The current work order has no bead._"
+	}
+	if l.graph == nil {
+		return l.unavailable("beads")
+	}
+	issue, ok := l.graph.ByID[l.order.BeadID]
+	if !ok {
+		return fmt.Sprintf("_Bead %s not found._", l.order.BeadID)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "### %s %s\n\n", issue.ID, issue.Title)
+	fmt.Fprintf(&b, "- %s, P%d, %s\n", issue.IssueType, issue.Priority, issue.Status)
+	if parentID, ok := l.graph.Parent[issue.ID]; ok {
+		parent := l.graph.ByID[parentID]
+		fmt.Fprintf(&b, "- Parent: %s %s (%s, %s)\n", parent.ID, parent.Title, parent.IssueType, parent.Status)
+	}
+	for _, blockerID := range l.graph.Blocks[issue.ID] {
+		blocker := l.graph.ByID[blockerID]
+		fmt.Fprintf(&b, "- Blocked by: %s %s (%s)\n", blocker.ID, blocker.Title, blocker.Status)
+	}
+	if description := strings.TrimSpace(issue.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+	if criteria := strings.TrimSpace(issue.AcceptanceCriteria); criteria != "" {
+		fmt.Fprintf(&b, "\n**Acceptance criteria:**\n\n%s\n", criteria)
+	}
+	if parentID, ok := l.graph.Parent[issue.ID]; ok {
+		if description := strings.TrimSpace(l.graph.ByID[parentID].Description); description != "" {
+			fmt.Fprintf(&b, "\n**Parent %s:**\n\n%s\n", parentID, description)
+		}
+	}
+	return b.String()
+}
+
+func (l *live) ready() string {
+	if l.graph == nil {
+		return l.unavailable("beads")
+	}
+	ready := beads.Ready(l.graph.Issues)
+	if len(ready) == 0 {
+		return "_Nothing is ready._"
+	}
+	sort.SliceStable(ready, func(i, j int) bool {
+		if ready[i].Priority != ready[j].Priority {
+			return ready[i].Priority < ready[j].Priority
+		}
+		return ready[i].ID < ready[j].ID
+	})
+	var b strings.Builder
+	for i, issue := range ready {
+		if i == l.opts.ReadyLimit {
+			fmt.Fprintf(&b, "- … %d more (bd ready)\n", len(ready)-i)
+			break
+		}
+		fmt.Fprintf(&b, "- %s [P%d %s] %s\n", issue.ID, issue.Priority, issue.IssueType, issue.Title)
+	}
+	return b.String()
+}
+
+// events lists the current work order's latest events, or the latest across
+// recently updated orders when there is no current one.
+func (l *live) events(ctx context.Context) string {
+	if l.src.Store == nil {
+		return "_No work order database._"
+	}
+	var ids []int64
+	if l.order != nil {
+		ids = []int64{l.order.ID}
+	} else {
+		orders, err := l.src.Store.List(ctx, workorder.ListOptions{Limit: 5})
+		if err != nil {
+			return fmt.Sprintf("_Unavailable: %v._", err)
+		}
+		for _, order := range orders {
+			ids = append(ids, order.ID)
+		}
+	}
+
+	var events []workorder.Event
+	for _, id := range ids {
+		orderEvents, err := l.src.Store.ListEvents(ctx, id)
+		if err != nil {
+			return fmt.Sprintf("_Unavailable: %v._", err)
+		}
+		events = append(events, orderEvents...)
+	}
+	if len(events) == 0 {
+		return "_No events yet._"
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].CreatedAt.After(events[j].CreatedAt) })
+	if len(events) > l.opts.EventLimit {
+		events = events[:l.opts.EventLimit]
+	}
+	var b strings.Builder
+	for _, event := range events {
+		fmt.Fprintf(&b, "- %s #%d %s", event.CreatedAt.Local().Format("01-02 15:04"), event.WorkOrderID, event.Kind)
+		if event.Actor != "" {
+			fmt.Fprintf(&b, " by %s", event.Actor)
+		}
+		if event.Detail != "" {
+			fmt.Fprintf(&b, ": %s", event.Detail)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+func (l *live) worktree(ctx context.Context) string {
+	top, err := l.opts.Git(ctx, l.src.Root, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "_Not a git repository._"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "- Path: %s\n", top)
+	if branch, err := l.opts.Git(ctx, l.src.Root, "branch", "--show-current"); err == nil {
+		if branch == "" {
+			branch = "(detached HEAD)"
+		}
+		fmt.Fprintf(&b, "- Branch: %s\n", branch)
+	}
+	if head, err := l.opts.Git(ctx, l.src.Root, "log", "-1", "--format=%h %s"); err == nil && head != "" {
+		fmt.Fprintf(&b, "- HEAD: %s\n", head)
+	}
+	if status, err := l.opts.Git(ctx, l.src.Root, "status", "--porcelain"); err == nil {
+		changed := 0
+		if status != "" {
+			changed = len(strings.Split(status, "\n"))
+		}
+		fmt.Fprintf(&b, "- Uncommitted changes: %d files\n", changed)
+	}
+	return b.String()
+}
+
+func (l *live) unavailable(source string) string {
+	if l.err != nil {
+		return fmt.Sprintf("_Unavailable: %v._", l.err)
+	}
+	return fmt.Sprintf("_No %s found._", source)
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	command := exec.CommandContext(ctx, "git", args...)
+	command.Dir = dir
+	output, err := command.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// truncateSection cuts body to budget bytes, at a line boundary when there
+// is one, and says how to see the rest.
+func truncateSection(body string, section Section, budget int) string {
+	if budget <= 0 || len(body) <= budget {
+		return body
+	}
+	cut := strings.LastIndex(body[:budget], "\n")
+	if cut <= 0 {
+		cut = budget
+		for cut > 0 && !utf8.RuneStart(body[cut]) {
+			cut--
+		}
+	}
+	return fmt.Sprintf("%s\n\n_… %d more bytes; raise `--budget %s=N` to see them._", strings.TrimRight(body[:cut], "\n"), len(body)-cut, section)
+}
+
+func containsSection(sections []Section, section Section) bool {
+	for _, candidate := range sections {
+		if candidate == section {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+package prime
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+func TestRenderLive(t *testing.T) {
+	ctx := context.Background()
+	store, err := workorder.OpenStore(filepath.Join(t.TempDir(), "carniecamp.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+	order, err := store.Create(ctx, workorder.CreateInput{Title: "Build login", Description: "Acceptance: users can sign in.", BeadID: "cn-2", Status: workorder.StatusReady})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := store.Claim(ctx, order.ID, "bot", time.Hour); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Auth", Description: "Everything about signing in.", Status: beads.StatusOpen, IssueType: "feature"},
+		beads.Issue{ID: "cn-2", Title: "Login", Description: "Form and session.", AcceptanceCriteria: "Users stay signed in.", Status: beads.StatusInProgress, IssueType: "task", Dependencies: []beads.Dependency{
+			{IssueID: "cn-2", DependsOnID: "cn-1", Type: beads.DepParentChild},
+		}},
+		beads.Issue{ID: "cn-3", Title: "Docs", Status: beads.StatusOpen, Priority: 1, IssueType: "task"},
+	)
+	git := func(ctx context.Context, dir string, args ...string) (string, error) {
+		switch args[0] {
+		case "rev-parse":
+			return "/work/login", nil
+		case "branch":
+			return "feature/login", nil
+		case "status":
+			return " M a.go\n?? b.go", nil
+		}
+		return "", errors.New("unexpected")
+	}
+	src := LiveSources{Root: "/work/login", Camp: &config.CampConfig{Name: "shop"}, Beads: client, Store: store, Agent: "bot"}
+
+	out := RenderLive(ctx, src, LiveOptions{Git: git})
+	for _, want := range []string{
+		"- Name: shop",
+		"- Work orders: 1 in_progress",
+		"### #1 Build login",
+		"- Status: in_progress (claimed by bot)",
+		"Acceptance: users can sign in.",
+		"- Parent: cn-1 Auth (feature, open)",
+		"**Acceptance criteria:**\n\nUsers stay signed in.",
+		"**Parent cn-1:**",
+		"- cn-1 [P0 feature] Auth\n- cn-3 [P1 task] Docs",
+		"#1 claimed by bot",
+		"- Branch: feature/login",
+		"- Uncommitted changes: 2 files",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+
+	out = RenderLive(ctx, src, LiveOptions{
+		Sections:   []Section{SectionWork, SectionReady},
+		Budgets:    map[Section]int{SectionWork: 40},
+		ReadyLimit: 1,
+		Git:        git,
+	})
+	if strings.Contains(out, "## Camp") || strings.Contains(out, "## Worktree") {
+		t.Fatalf("expected only the selected sections:\n%s", out)
+	}
+	if strings.Contains(out, "Acceptance") || !strings.Contains(out, "more bytes; raise `--budget work=N`") {
+		t.Fatalf("expected the work section to be cut to its budget:\n%s", out)
+	}
+	if !strings.Contains(out, "- … 1 more (bd ready)") {
+		t.Fatalf("expected the ready list to be limited:\n%s", out)
+	}
+}
+
+func TestRenderLiveWithoutSources(t *testing.T) {
+	out := RenderLive(context.Background(), LiveSources{}, LiveOptions{
+		Git: func(context.Context, string, ...string) (string, error) { return "", errors.New("not a repo") },
+	})
+	for _, want := range []string{"_No camp found", "_No work order database._", "_No current work order._", "_No beads found._", "_Not a git repository._"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in:\n%s", want, out)
+		}
+	}
+}
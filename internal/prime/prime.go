@@ -16,6 +16,12 @@ const (
 
 var validRoles = []Role{RoleOperator, RoleCarnie}
 
+func init() {
+	for _, role := range validRoles {
+		templates.RegisterData(string(role)+".md", RoleData{})
+	}
+}
+
 // ValidRoles returns all valid role names.
 func ValidRoles() []Role {
 	return validRoles
@@ -31,10 +37,11 @@ func IsValidRole(r string) bool {
 	return false
 }
 
-// LoadPrompt loads the embedded template for the given role.
-func LoadPrompt(role Role) (string, error) {
+// LoadPrompt loads the template for the given role, taking overrides from
+// loader.
+func LoadPrompt(loader templates.Loader, role Role) (string, error) {
 	filename := string(role) + ".md"
-	content, err := templates.Load(filename)
+	content, err := loader.Load(filename)
 	if err != nil {
 		return "", fmt.Errorf("load template for role %q: %w", role, err)
 	}
@@ -3,6 +3,8 @@ package prime
 import (
 	"strings"
 	"testing"
+
+	"github.com/rikurb8/carnie/internal/templates"
 )
 
 func TestValidRoles(t *testing.T) {
@@ -53,7 +55,7 @@ func TestIsValidRole(t *testing.T) {
 }
 
 func TestLoadPromptOperator(t *testing.T) {
-	content, err := LoadPrompt(RoleOperator)
+	content, err := LoadPrompt(templates.Loader{}, RoleOperator)
 	if err != nil {
 		t.Fatalf("LoadPrompt(operator) error: %v", err)
 	}
@@ -67,7 +69,7 @@ func TestLoadPromptOperator(t *testing.T) {
 }
 
 func TestLoadPromptCarnie(t *testing.T) {
-	content, err := LoadPrompt(RoleCarnie)
+	content, err := LoadPrompt(templates.Loader{}, RoleCarnie)
 	if err != nil {
 		t.Fatalf("LoadPrompt(carnie) error: %v", err)
 	}
@@ -0,0 +1,279 @@
+package prime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/templates"
+	"gopkg.in/yaml.v3"
+)
+
+// RolesDir holds camp role files, one <name>.md per role.
+const RolesDir = ".carnie/roles"
+
+// RoleSource says where a role comes from.
+type RoleSource string
+
+const (
+	SourceBuiltIn RoleSource = "built-in"
+	SourceCamp    RoleSource = "camp"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// RoleInfo describes a role `carnie prime` can print.
+type RoleInfo struct {
+	Name        Role
+	Source      RoleSource
+	Description string
+	Inherits    Role   // built-in role printed before this one, if any
+	Path        string // role file, empty for built-ins and inline prompts
+
+	body string
+}
+
+// RoleData is available to role files as template variables, e.g.
+// {{.CampName}} or {{.Agent}}.
+type RoleData struct {
+	Role            string
+	CampName        string
+	CampDescription string
+	Root            string
+	Agent           string
+	Date            string
+}
+
+// NewRoleData fills RoleData from the camp and today's date.
+func NewRoleData(role Role, root string, cfg *config.CampConfig, agent string) RoleData {
+	data := RoleData{Role: string(role), Root: root, Agent: agent, Date: time.Now().Format("2006-01-02")}
+	if cfg != nil {
+		data.CampName = cfg.Name
+		data.CampDescription = cfg.Description
+	}
+	return data
+}
+
+// roleFrontMatter is the optional YAML header of a role file.
+type roleFrontMatter struct {
+	Description string `yaml:"description"`
+	Inherits    string `yaml:"inherits"`
+}
+
+// LoadRoles returns the built-in roles followed by the camp's roles: those
+// declared under roles in camp.yml, then any other .carnie/roles/*.md file,
+// each group sorted by name.
+func LoadRoles(root string, cfg *config.CampConfig) ([]RoleInfo, error) {
+	roles := make([]RoleInfo, 0, len(validRoles))
+	for _, role := range validRoles {
+		roles = append(roles, RoleInfo{Name: role, Source: SourceBuiltIn, Description: builtInDescriptions[role]})
+	}
+
+	seen := map[Role]bool{}
+	var declared []RoleInfo
+	if cfg != nil {
+		for _, rc := range cfg.Roles {
+			info, err := loadDeclaredRole(root, rc)
+			if err != nil {
+				return nil, err
+			}
+			if seen[info.Name] {
+				return nil, fmt.Errorf("role %q is declared twice in %s", info.Name, config.CampConfigFile)
+			}
+			seen[info.Name] = true
+			declared = append(declared, info)
+		}
+	}
+
+	var discovered []RoleInfo
+	paths, _ := filepath.Glob(filepath.Join(root, RolesDir, "*.md"))
+	for _, path := range paths {
+		name := Role(strings.TrimSuffix(filepath.Base(path), ".md"))
+		if seen[name] {
+			continue
+		}
+		info := RoleInfo{Name: name, Source: SourceCamp, Path: path}
+		if err := readRoleFile(&info, path); err != nil {
+			return nil, err
+		}
+		discovered = append(discovered, info)
+	}
+
+	sort.Slice(declared, func(i, j int) bool { return declared[i].Name < declared[j].Name })
+	for _, info := range append(declared, discovered...) {
+		if err := checkCampRole(info); err != nil {
+			return nil, err
+		}
+		roles = append(roles, info)
+	}
+	return roles, nil
+}
+
+var builtInDescriptions = map[Role]string{
+	RoleOperator: "Plans work and turns ideas into beads",
+	RoleCarnie:   "Implements work orders",
+}
+
+func loadDeclaredRole(root string, rc config.RoleConfig) (RoleInfo, error) {
+	info := RoleInfo{
+		Name:        Role(rc.Name),
+		Source:      SourceCamp,
+		Description: rc.Description,
+		Inherits:    Role(rc.Inherits),
+		body:        rc.Prompt,
+	}
+	if rc.Name == "" {
+		return info, fmt.Errorf("role in %s has no name", config.CampConfigFile)
+	}
+	if rc.Prompt != "" && rc.File == "" {
+		return info, nil
+	}
+
+	path := rc.File
+	if path == "" {
+		path = filepath.Join(RolesDir, rc.Name+".md")
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	info.Path = path
+	if err := readRoleFile(&info, path); err != nil {
+		if rc.File == "" && errors.Is(err, os.ErrNotExist) {
+			return info, fmt.Errorf("role %q: no prompt or file, and %s does not exist", rc.Name, path)
+		}
+		return info, err
+	}
+	// camp.yml settings win over the file's front matter.
+	if rc.Description != "" {
+		info.Description = rc.Description
+	}
+	if rc.Inherits != "" {
+		info.Inherits = Role(rc.Inherits)
+	}
+	return info, nil
+}
+
+// readRoleFile reads a role file into info, taking description and inherits
+// from its front matter.
+func readRoleFile(info *RoleInfo, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read role %q: %w", info.Name, err)
+	}
+	front, body, err := splitFrontMatter(string(data))
+	if err != nil {
+		return fmt.Errorf("role %q: %w", info.Name, err)
+	}
+	info.body = body
+	if front.Description != "" {
+		info.Description = front.Description
+	}
+	if front.Inherits != "" {
+		info.Inherits = Role(front.Inherits)
+	}
+	return nil
+}
+
+// splitFrontMatter separates an optional leading "---" YAML block from the
+// markdown body.
+func splitFrontMatter(content string) (roleFrontMatter, string, error) {
+	var front roleFrontMatter
+	normalized := strings.ReplaceAll(content, "\r\n", "\n")
+	if !strings.HasPrefix(normalized, "---\n") {
+		return front, content, nil
+	}
+	rest := normalized[len("---\n"):]
+	end := strings.Index(rest, "\n---")
+	if end < 0 {
+		return front, content, errors.New("front matter is not closed with ---")
+	}
+	if err := yaml.Unmarshal([]byte(rest[:end]), &front); err != nil {
+		return front, content, fmt.Errorf("parse front matter: %w", err)
+	}
+	body := rest[end+len("\n---"):]
+	body = strings.TrimPrefix(body, "\n")
+	return front, body, nil
+}
+
+func checkCampRole(info RoleInfo) error {
+	if !roleNamePattern.MatchString(string(info.Name)) {
+		return fmt.Errorf("role %q: names use lowercase letters, digits, - and _", info.Name)
+	}
+	if IsValidRole(string(info.Name)) {
+		return fmt.Errorf("role %q is built in; pick another name and set inherits: %s instead", info.Name, info.Name)
+	}
+	if info.Inherits != "" && !IsValidRole(string(info.Inherits)) {
+		return fmt.Errorf("role %q inherits %q, which is not a built-in role (%s)", info.Name, info.Inherits, builtInNames())
+	}
+	return nil
+}
+
+// FindRole returns the role called name.
+func FindRole(roles []RoleInfo, name string) (RoleInfo, bool) {
+	for _, role := range roles {
+		if string(role.Name) == name {
+			return role, true
+		}
+	}
+	return RoleInfo{}, false
+}
+
+// RoleNames lists the names of roles, in order.
+func RoleNames(roles []RoleInfo) []string {
+	names := make([]string, len(roles))
+	for i, role := range roles {
+		names[i] = string(role.Name)
+	}
+	return names
+}
+
+// RenderRole returns the prompt for role, rendered with data by engine.
+// Built-in roles come from the engine's loader, so a camp or user override of
+// operator.md or carnie.md is picked up; camp roles follow the workflow of the
+// role they inherit.
+func RenderRole(engine templates.Engine, role RoleInfo, data RoleData) (string, error) {
+	body := role.body
+	if role.Source == SourceBuiltIn {
+		content, err := LoadPrompt(engine.Loader, role.Name)
+		if err != nil {
+			return "", err
+		}
+		body = content
+	}
+	out, err := renderRoleBody(engine, role.Name, body, data)
+	if err != nil || role.Inherits == "" {
+		return out, err
+	}
+
+	base, err := LoadPrompt(engine.Loader, role.Inherits)
+	if err != nil {
+		return "", err
+	}
+	base, err = renderRoleBody(engine, role.Inherits, base, data)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(base, "\n") + "\n\n" + out, nil
+}
+
+func renderRoleBody(engine templates.Engine, name Role, body string, data RoleData) (string, error) {
+	out, err := engine.RenderString(string(name), body, data)
+	if err != nil {
+		return "", fmt.Errorf("role %q: %w", name, err)
+	}
+	return out, nil
+}
+
+func builtInNames() string {
+	names := make([]string, len(validRoles))
+	for i, role := range validRoles {
+		names[i] = string(role)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,88 @@
+package prime
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/templates"
+)
+
+func writeRole(t *testing.T, root, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, RolesDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadRoles(t *testing.T) {
+	root := t.TempDir()
+	writeRole(t, root, "tester", "---\ndescription: Writes tests\n---\nTest {{.CampName}}.\n")
+	writeRole(t, root, "reviewer", "Review things.\n")
+	cfg := &config.CampConfig{Name: "shop", Roles: []config.RoleConfig{
+		{Name: "reviewer", Description: "Reviews finished work", Inherits: "carnie"},
+		{Name: "release-manager", Prompt: "Ship {{.CampName}} on {{.Date}}."},
+	}}
+
+	roles, err := LoadRoles(root, cfg)
+	if err != nil {
+		t.Fatalf("LoadRoles: %v", err)
+	}
+	got := strings.Join(RoleNames(roles), ",")
+	if got != "operator,carnie,release-manager,reviewer,tester" {
+		t.Fatalf("unexpected roles %s", got)
+	}
+
+	reviewer, _ := FindRole(roles, "reviewer")
+	if reviewer.Source != SourceCamp || reviewer.Inherits != RoleCarnie || reviewer.Description != "Reviews finished work" {
+		t.Fatalf("unexpected reviewer %+v", reviewer)
+	}
+	out, err := RenderRole(templates.Engine{}, reviewer, RoleData{})
+	if err != nil {
+		t.Fatalf("RenderRole: %v", err)
+	}
+	if !strings.Contains(out, "Carnie") || !strings.HasSuffix(out, "Review things.\n") {
+		t.Fatalf("expected the carnie workflow before the reviewer role:\n%s", out)
+	}
+
+	tester, _ := FindRole(roles, "tester")
+	out, err = RenderRole(templates.Engine{}, tester, RoleData{CampName: "shop"})
+	if err != nil {
+		t.Fatalf("RenderRole: %v", err)
+	}
+	if out != "Test shop.\n" || tester.Description != "Writes tests" {
+		t.Fatalf("unexpected tester %q %+v", out, tester)
+	}
+}
+
+func TestLoadRolesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		roles []config.RoleConfig
+		want  string
+	}{
+		{"built-in name", []config.RoleConfig{{Name: "carnie", Prompt: "x"}}, "is built in"},
+		{"unknown parent", []config.RoleConfig{{Name: "docs-writer", Prompt: "x", Inherits: "reviewer"}}, "not a built-in role"},
+		{"missing file", []config.RoleConfig{{Name: "docs-writer"}}, "does not exist"},
+		{"bad name", []config.RoleConfig{{Name: "Docs Writer", Prompt: "x"}}, "lowercase"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadRoles(t.TempDir(), &config.CampConfig{Roles: tt.roles})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("expected %q error, got %v", tt.want, err)
+			}
+		})
+	}
+
+	role := RoleInfo{Name: "broken", Source: SourceCamp, body: "{{.Nope}}"}
+	if _, err := RenderRole(templates.Engine{}, role, RoleData{}); err == nil {
+		t.Fatal("expected an error for an unknown template variable")
+	}
+}
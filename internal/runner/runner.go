@@ -0,0 +1,390 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/prime"
+	"github.com/rikurb8/carnie/internal/session"
+	"github.com/rikurb8/carnie/internal/templates"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+const runLogDir = ".carnie/runs"
+
+// ErrBudgetExhausted is returned when a work order or the camp has already
+// spent its configured budget and no new run may be launched.
+var ErrBudgetExhausted = errors.New("budget exhausted")
+
+// ErrCrewAtCapacity is returned when the assigned crew member already has as
+// many work orders in progress as its concurrency limit allows.
+var ErrCrewAtCapacity = workorder.ErrCrewAtCapacity
+
+// Runner launches agent sessions for work orders and records each run.
+type Runner struct {
+	Store  *workorder.Store
+	Root   string             // Camp root, used as the agent working directory
+	Config *config.CampConfig // Optional camp config for tool and model defaults
+	Agent  string             // Agent that claims the work order for the run
+	Lease  time.Duration      // Claim lease renewed while the run is active (default 15m)
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Options overrides how a run is launched.
+type Options struct {
+	Tool    string // Tool override ("claude" or "opencode")
+	Model   string // Model override
+	Message string // Extra instructions appended to a resume prompt
+}
+
+// Plan describes a session that is ready to be launched for a work order.
+type Plan struct {
+	Order       workorder.WorkOrder
+	Session     session.Options
+	ResumedFrom *workorder.Run
+}
+
+// Command returns the shell command the plan would execute.
+func (p Plan) Command() string {
+	return session.Command(p.Session)
+}
+
+// PlanRun builds a fresh one-shot session for the work order.
+func (r *Runner) PlanRun(ctx context.Context, orderID int64, opts Options) (Plan, error) {
+	order, err := r.Store.Get(ctx, orderID)
+	if err != nil {
+		return Plan{}, fmt.Errorf("load work order %d: %w", orderID, err)
+	}
+
+	prompt, err := RenderPrompt(r.Root, r.Config, order)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	tool, model := r.resolveToolAndModel(opts, r.crewMember(order))
+	return Plan{
+		Order: order,
+		Session: session.Options{
+			Tool:       tool,
+			Model:      model,
+			Prompt:     prompt,
+			JSONOutput: true,
+		},
+	}, nil
+}
+
+// PlanResume builds a session that continues the latest recorded conversation
+// for the work order, prefixed with an interruption preamble.
+func (r *Runner) PlanResume(ctx context.Context, orderID int64, opts Options) (Plan, error) {
+	order, err := r.Store.Get(ctx, orderID)
+	if err != nil {
+		return Plan{}, fmt.Errorf("load work order %d: %w", orderID, err)
+	}
+
+	previous, err := r.Store.LatestResumableRun(ctx, orderID)
+	if err != nil {
+		return Plan{}, err
+	}
+
+	prompt, err := workorder.RenderResumePrompt(templates.NewEngine(r.Root), workorder.ResumePromptData{
+		WorkOrder: order,
+		Message:   opts.Message,
+	})
+	if err != nil {
+		return Plan{}, err
+	}
+
+	tool := session.ParseTool(previous.Tool)
+	model := previous.Model
+	if opts.Model != "" {
+		model = session.NormalizeModel(tool, opts.Model)
+	}
+
+	return Plan{
+		Order: order,
+		Session: session.Options{
+			Tool:       tool,
+			Model:      model,
+			Prompt:     prompt,
+			SessionID:  previous.SessionID,
+			JSONOutput: true,
+		},
+		ResumedFrom: &previous,
+	}, nil
+}
+
+// Execute claims the work order for the runner's agent, then launches the
+// planned session within the camp budgets, streaming its output and recording
+// the run with its session ID, exit code and usage. The lease is renewed while
+// the session runs, and a claim Execute took itself is released when the
+// session exits with the order still in progress.
+func (r *Runner) Execute(ctx context.Context, plan Plan) (workorder.Run, error) {
+	maxCost, maxTokens, err := r.budgetLimits(ctx, plan.Order.ID)
+	if err != nil {
+		return workorder.Run{}, err
+	}
+
+	claimed, err := r.claim(ctx, plan.Order.ID)
+	if err != nil {
+		return workorder.Run{}, err
+	}
+	if claimed {
+		defer r.release(context.WithoutCancel(ctx), plan.Order.ID)
+	}
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	defer stopHeartbeat()
+	go r.heartbeat(heartbeatCtx, plan.Order.ID)
+
+	logPath, logFile, err := r.openRunLog(plan.Order.ID)
+	if err != nil {
+		return workorder.Run{}, err
+	}
+	defer logFile.Close()
+
+	input := workorder.CreateRunInput{
+		WorkOrderID: plan.Order.ID,
+		Tool:        string(plan.Session.Tool),
+		Model:       plan.Session.Model,
+		LogPath:     logPath,
+	}
+	if plan.ResumedFrom != nil {
+		input.SessionID = plan.ResumedFrom.SessionID
+		input.ResumedFrom = &plan.ResumedFrom.ID
+	}
+	run, err := r.Store.CreateRun(ctx, input)
+	if err != nil {
+		return workorder.Run{}, err
+	}
+
+	var stdout io.Writer = logFile
+	if r.Stdout != nil {
+		stdout = io.MultiWriter(r.Stdout, logFile)
+	}
+	result, runErr := session.Run(ctx, plan.Session, session.RunOptions{
+		Dir:        r.Root,
+		Stdout:     stdout,
+		Stderr:     r.Stderr,
+		MaxCostUSD: maxCost,
+		MaxTokens:  maxTokens,
+	})
+	if runErr != nil && result.ExitCode == 0 {
+		result.ExitCode = -1
+	}
+
+	finished, err := r.Store.FinishRun(context.WithoutCancel(ctx), run.ID, workorder.FinishRunInput{
+		ExitCode:  result.ExitCode,
+		SessionID: result.SessionID,
+		Usage:     result.Usage,
+	})
+	if err != nil {
+		return run, err
+	}
+
+	return finished, runErr
+}
+
+// budgetLimits checks the camp budgets before a launch and returns the
+// tightest cost and token ceilings the new run may use (0 = no limit).
+func (r *Runner) budgetLimits(ctx context.Context, orderID int64) (float64, int64, error) {
+	if r.Config == nil {
+		return 0, 0, nil
+	}
+	budgets := r.Config.Budgets
+
+	maxCost := budgets.RunUSD
+	limit := func(remaining float64) {
+		if maxCost <= 0 || remaining < maxCost {
+			maxCost = remaining
+		}
+	}
+
+	if budgets.WorkOrderUSD > 0 {
+		spent, err := r.Store.UsageTotals(ctx, workorder.UsageFilter{WorkOrderID: orderID})
+		if err != nil {
+			return 0, 0, err
+		}
+		if spent.Usage.CostUSD >= budgets.WorkOrderUSD {
+			return 0, 0, fmt.Errorf("%w: work order %d has spent $%.2f of its $%.2f budget", ErrBudgetExhausted, orderID, spent.Usage.CostUSD, budgets.WorkOrderUSD)
+		}
+		limit(budgets.WorkOrderUSD - spent.Usage.CostUSD)
+	}
+
+	if budgets.CampUSD > 0 {
+		spent, err := r.Store.UsageTotals(ctx, workorder.UsageFilter{})
+		if err != nil {
+			return 0, 0, err
+		}
+		if spent.Usage.CostUSD >= budgets.CampUSD {
+			return 0, 0, fmt.Errorf("%w: camp has spent $%.2f of its $%.2f budget", ErrBudgetExhausted, spent.Usage.CostUSD, budgets.CampUSD)
+		}
+		limit(budgets.CampUSD - spent.Usage.CostUSD)
+	}
+
+	return maxCost, budgets.RunTokens, nil
+}
+
+// claim takes the work order for r.Agent, counting the crew member's load in
+// the same update. It reports false when the agent already held a live claim,
+// as the daemon does when it dispatches, so the caller keeps ownership of it.
+// A blocked order is moved to ready so it can be claimed, and blocked again
+// if the claim fails.
+func (r *Runner) claim(ctx context.Context, orderID int64) (bool, error) {
+	if r.Agent == "" {
+		return false, fmt.Errorf("agent is required to run work order %d", orderID)
+	}
+	order, err := r.Store.Get(ctx, orderID)
+	if err != nil {
+		return false, fmt.Errorf("load work order %d: %w", orderID, err)
+	}
+	now := time.Now()
+	if order.Assignee == r.Agent && order.Status == workorder.StatusInProgress && order.LeaseUntil != nil && !order.LeaseExpired(now) {
+		return false, nil
+	}
+	if order.Assignee != "" && order.Assignee != r.Agent && order.LeaseUntil != nil && !order.LeaseExpired(now) {
+		return false, fmt.Errorf("%w: work order %d is held by %s until %s", workorder.ErrNotClaimant, orderID, order.Assignee, order.LeaseUntil.Format(time.RFC3339))
+	}
+	blocked := order.Status == workorder.StatusBlocked
+	if blocked {
+		if _, err := r.Store.UpdateStatusBy(ctx, orderID, workorder.StatusReady, r.Agent); err != nil {
+			return false, err
+		}
+	}
+
+	if _, err := r.Store.ClaimWithin(ctx, orderID, r.Agent, r.lease(), r.crewMember(order).Concurrency); err != nil {
+		if blocked {
+			err = errors.Join(err, r.Store.Reblock(ctx, orderID, r.Agent))
+		}
+		if errors.Is(err, workorder.ErrClaimed) {
+			return false, fmt.Errorf("%w: %w", workorder.ErrNotClaimant, err)
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// release drops the runner's claim if the agent left the order in progress.
+func (r *Runner) release(ctx context.Context, orderID int64) {
+	order, err := r.Store.Get(ctx, orderID)
+	if err != nil || order.Assignee != r.Agent || order.Status != workorder.StatusInProgress {
+		return
+	}
+	if _, err := r.Store.Release(ctx, orderID, r.Agent, false); err != nil && r.Stderr != nil {
+		fmt.Fprintf(r.Stderr, "release work order %d: %v\n", orderID, err)
+	}
+}
+
+func (r *Runner) heartbeat(ctx context.Context, orderID int64) {
+	lease := r.lease()
+	ticker := time.NewTicker(lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.Store.Heartbeat(ctx, orderID, r.Agent, lease); err != nil && ctx.Err() == nil && r.Stderr != nil {
+				fmt.Fprintf(r.Stderr, "heartbeat work order %d: %v\n", orderID, err)
+			}
+		}
+	}
+}
+
+func (r *Runner) lease() time.Duration {
+	if r.Lease > 0 {
+		return r.Lease
+	}
+	return workorder.DefaultLeaseDuration
+}
+
+func (r *Runner) crewMember(order workorder.WorkOrder) config.CrewMember {
+	if order.Crew == "" {
+		return config.CrewMember{}
+	}
+	member, _ := r.Config.CrewMember(order.Crew)
+	return member
+}
+
+func (r *Runner) resolveToolAndModel(opts Options, member config.CrewMember) (session.Tool, string) {
+	toolName := config.DefaultAgentTool
+	model := config.DefaultAgentModel
+	if r.Config != nil {
+		if r.Config.Defaults.AgentTool != "" {
+			toolName = r.Config.Defaults.AgentTool
+		}
+		if r.Config.Defaults.AgentModel != "" {
+			model = r.Config.Defaults.AgentModel
+		}
+	}
+	if member.Tool != "" {
+		toolName = member.Tool
+	}
+	if member.Model != "" {
+		model = member.Model
+	}
+	if opts.Tool != "" {
+		toolName = opts.Tool
+	}
+	if opts.Model != "" {
+		model = opts.Model
+	}
+
+	tool := session.ParseTool(toolName)
+	return tool, session.NormalizeModel(tool, model)
+}
+
+func (r *Runner) openRunLog(orderID int64) (string, *os.File, error) {
+	dir := filepath.Join(r.Root, runLogDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", nil, fmt.Errorf("create run log directory: %w", err)
+	}
+
+	name := fmt.Sprintf("wo-%d-%s.log", orderID, time.Now().UTC().Format("20060102T150405"))
+	path := filepath.Join(dir, name)
+	file, err := os.Create(path)
+	if err != nil {
+		return "", nil, fmt.Errorf("create run log: %w", err)
+	}
+
+	return filepath.Join(runLogDir, name), file, nil
+}
+
+// RenderPrompt renders the Carnie prompt for a work order, including the role
+// context, the assigned crew member's persona, linked bead details and camp metadata.
+func RenderPrompt(root string, cfg *config.CampConfig, order workorder.WorkOrder) (string, error) {
+	engine := templates.NewEngine(root)
+	role := prime.RoleInfo{Name: prime.RoleCarnie, Source: prime.SourceBuiltIn}
+	rolePrompt, err := prime.RenderRole(engine, role, prime.NewRoleData(role.Name, root, cfg, order.Assignee))
+	if err != nil {
+		return "", err
+	}
+
+	var beadInfo beads.Issue
+	if beadsRoot, err := beads.FindRoot(root); err == nil {
+		beadInfo, _ = beads.NewJSONL(beadsRoot).Show(context.Background(), order.BeadID)
+	}
+
+	data := workorder.PromptData{
+		RolePrompt:      rolePrompt,
+		WorkOrder:       order,
+		BeadTitle:       beadInfo.Title,
+		BeadDescription: beadInfo.Description,
+	}
+	if cfg != nil {
+		data.ProjectName = cfg.Name
+		data.ProjectDescription = cfg.Description
+		if member, ok := cfg.CrewMember(order.Crew); ok && order.Crew != "" {
+			data.CrewName = member.Name
+			data.CrewPrompt = member.RolePrompt
+		}
+	}
+
+	return workorder.RenderPrompt(engine, data)
+}
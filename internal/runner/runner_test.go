@@ -0,0 +1,203 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/config"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+// fakeTool puts a claude script on PATH that sleeps for a moment and reports
+// a session ID.
+func fakeTool(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	script := "#!/bin/sh\nsleep 0.4\necho '{\"type\":\"result\",\"session_id\":\"sess-1\"}'\n"
+	if err := os.WriteFile(filepath.Join(dir, "claude"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func newTestRunner(t *testing.T, cfg *config.CampConfig) (*Runner, *workorder.Store) {
+	t.Helper()
+	root := t.TempDir()
+	store, err := workorder.OpenStore(filepath.Join(root, "carniecamp.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return &Runner{Store: store, Root: root, Config: cfg, Agent: "alice", Lease: time.Minute}, store
+}
+
+func createReady(t *testing.T, store *workorder.Store, crew string) workorder.WorkOrder {
+	t.Helper()
+	order, err := store.Create(context.Background(), workorder.CreateInput{Title: "Build it", Description: "Work", Status: workorder.StatusReady, Crew: crew})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	return order
+}
+
+func TestExecuteClaimsAndReleases(t *testing.T) {
+	fakeTool(t)
+	ctx := context.Background()
+	r, store := newTestRunner(t, nil)
+	order := createReady(t, store, "")
+
+	plan, err := r.PlanRun(ctx, order.ID, Options{Tool: "claude"})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.Execute(ctx, plan)
+		done <- err
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		current, err := store.Get(ctx, order.ID)
+		if err != nil {
+			t.Fatalf("get: %v", err)
+		}
+		if current.Assignee == "alice" && current.Status == workorder.StatusInProgress {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected the run to claim the order, got %s/%q", current.Status, current.Assignee)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	current, err := store.Get(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if current.Status != workorder.StatusReady || current.Assignee != "" {
+		t.Fatalf("expected the claim to be released, got %s/%q", current.Status, current.Assignee)
+	}
+	runs, err := store.ListRuns(ctx, order.ID)
+	if err != nil || len(runs) != 1 || runs[0].SessionID != "sess-1" {
+		t.Fatalf("expected one recorded run, got %+v (%v)", runs, err)
+	}
+}
+
+func TestExecuteRefusesLiveClaim(t *testing.T) {
+	fakeTool(t)
+	ctx := context.Background()
+	r, store := newTestRunner(t, nil)
+	order := createReady(t, store, "")
+	if _, err := store.Claim(ctx, order.ID, "bob", time.Hour); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	plan, err := r.PlanRun(ctx, order.ID, Options{Tool: "claude"})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if _, err := r.Execute(ctx, plan); !errors.Is(err, workorder.ErrNotClaimant) {
+		t.Fatalf("expected ErrNotClaimant, got %v", err)
+	}
+	if runs, _ := store.ListRuns(ctx, order.ID); len(runs) != 0 {
+		t.Fatalf("expected no run to be recorded, got %d", len(runs))
+	}
+}
+
+func TestExecuteRespectsCrewCapacity(t *testing.T) {
+	fakeTool(t)
+	ctx := context.Background()
+	cfg := &config.CampConfig{Crew: []config.CrewMember{{Name: "builder", Concurrency: 1}}}
+	r, store := newTestRunner(t, cfg)
+	busy := createReady(t, store, "builder")
+	if _, err := store.Claim(ctx, busy.ID, "bob", time.Hour); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	order := createReady(t, store, "builder")
+
+	plan, err := r.PlanRun(ctx, order.ID, Options{Tool: "claude"})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if _, err := r.Execute(ctx, plan); !errors.Is(err, ErrCrewAtCapacity) {
+		t.Fatalf("expected ErrCrewAtCapacity, got %v", err)
+	}
+	current, _ := store.Get(ctx, order.ID)
+	if current.Status != workorder.StatusReady || current.Assignee != "" {
+		t.Fatalf("expected the order to stay unclaimed, got %s/%q", current.Status, current.Assignee)
+	}
+}
+
+func TestExecuteKeepsBlockWhenClaimFails(t *testing.T) {
+	fakeTool(t)
+	ctx := context.Background()
+	cfg := &config.CampConfig{Crew: []config.CrewMember{{Name: "builder", Concurrency: 1}}}
+	r, store := newTestRunner(t, cfg)
+	busy := createReady(t, store, "builder")
+	if _, err := store.Claim(ctx, busy.ID, "bob", time.Hour); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	order := createReady(t, store, "builder")
+	if _, err := store.UpdateStatus(ctx, order.ID, workorder.StatusBlocked); err != nil {
+		t.Fatalf("block: %v", err)
+	}
+
+	plan, err := r.PlanRun(ctx, order.ID, Options{Tool: "claude"})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if _, err := r.Execute(ctx, plan); !errors.Is(err, ErrCrewAtCapacity) {
+		t.Fatalf("expected ErrCrewAtCapacity, got %v", err)
+	}
+	current, _ := store.Get(ctx, order.ID)
+	if current.Status != workorder.StatusBlocked {
+		t.Fatalf("expected the order to stay blocked, got %s", current.Status)
+	}
+	events, err := store.ListEvents(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if last := events[len(events)-1]; last.Actor != "alice" {
+		t.Fatalf("expected the status change to record alice, got %q", last.Actor)
+	}
+}
+
+func TestExecuteKeepsCallersClaimAndRenewsLease(t *testing.T) {
+	fakeTool(t)
+	ctx := context.Background()
+	r, store := newTestRunner(t, nil)
+	r.Lease = 150 * time.Millisecond
+	order := createReady(t, store, "")
+	claimed, err := store.Claim(ctx, order.ID, "alice", r.Lease)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	plan, err := r.PlanRun(ctx, order.ID, Options{Tool: "claude"})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if _, err := r.Execute(ctx, plan); err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+
+	current, err := store.Get(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if current.Assignee != "alice" || current.Status != workorder.StatusInProgress {
+		t.Fatalf("expected the caller's claim to be kept, got %s/%q", current.Status, current.Assignee)
+	}
+	if !current.LeaseUntil.After(*claimed.LeaseUntil) {
+		t.Fatalf("expected the lease to be renewed during the run")
+	}
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+// beadStatuses are the statuses a bead can be moved to over the API.
+var beadStatuses = []string{beads.StatusOpen, beads.StatusInProgress, beads.StatusBlocked, beads.StatusClosed}
+
+// WorkOrderDetail is a work order with its event history and the statuses
+// it can move to next.
+type WorkOrderDetail struct {
+	workorder.WorkOrder
+	Transitions []string          `json:"transitions"`
+	Events      []workorder.Event `json:"events"`
+}
+
+// StatusRequest moves a bead or work order to Status. Reason is the close
+// reason for beads; Note is recorded as a work order event. Force moves a
+// work order another agent holds under a live lease.
+type StatusRequest struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Note   string `json:"note,omitempty"`
+	Force  bool   `json:"force,omitempty"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status, err := s.Beads.Status(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	counts := map[workorder.Status]int{}
+	if s.Store != nil {
+		if counts, err = s.Store.CountByStatus(r.Context()); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"beads":       status.Summary,
+		"work_orders": counts,
+	})
+}
+
+func (s *Server) handleListBeads(w http.ResponseWriter, r *http.Request) {
+	opts := beads.ListOptions{}
+	if status := r.URL.Query().Get("status"); status != "" {
+		opts.Statuses = strings.Split(status, ",")
+	}
+	if issueType := r.URL.Query().Get("type"); issueType != "" {
+		opts.Types = strings.Split(issueType, ",")
+	}
+	issues, err := s.Beads.List(r.Context(), opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Priority != issues[j].Priority {
+			return issues[i].Priority < issues[j].Priority
+		}
+		return issues[i].ID < issues[j].ID
+	})
+	writeJSON(w, http.StatusOK, issues)
+}
+
+func (s *Server) handleShowBead(w http.ResponseWriter, r *http.Request) {
+	detail, err := s.beadDetail(r, r.PathValue("id"))
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+func (s *Server) beadDetail(r *http.Request, id string) (workorder.BeadDetail, error) {
+	return workorder.LoadBeadDetail(r.Context(), s.Beads, s.Store, id)
+}
+
+func (s *Server) handleBeadStatus(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	var req StatusRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if !slices.Contains(beadStatuses, req.Status) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid status %q (use %s)", req.Status, strings.Join(beadStatuses, ", ")))
+		return
+	}
+	if _, err := s.Beads.Show(r.Context(), id); err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+
+	var err error
+	if req.Status == beads.StatusClosed {
+		err = s.Beads.Close(r.Context(), id, req.Reason)
+	} else {
+		status := req.Status
+		err = s.Beads.Update(r.Context(), id, beads.UpdateInput{Status: &status})
+	}
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	detail, err := s.beadDetail(r, id)
+	if err != nil {
+		writeError(w, statusFor(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+func (s *Server) handleListWorkOrders(w http.ResponseWriter, r *http.Request) {
+	if !s.requireStore(w) {
+		return
+	}
+	opts := workorder.ListOptions{BeadID: r.URL.Query().Get("bead")}
+	if value := r.URL.Query().Get("status"); value != "" {
+		status, err := workorder.ParseStatus(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		opts.Status = &status
+	}
+	orders, err := s.Store.List(r.Context(), opts)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if orders == nil {
+		orders = []workorder.WorkOrder{}
+	}
+	writeJSON(w, http.StatusOK, orders)
+}
+
+func (s *Server) handleShowWorkOrder(w http.ResponseWriter, r *http.Request) {
+	order, ok := s.workOrder(w, r)
+	if !ok {
+		return
+	}
+	detail, err := s.workOrderDetail(r, order)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+func (s *Server) workOrderDetail(r *http.Request, order workorder.WorkOrder) (WorkOrderDetail, error) {
+	events, err := s.Store.ListEvents(r.Context(), order.ID)
+	if err != nil {
+		return WorkOrderDetail{}, err
+	}
+	detail := WorkOrderDetail{WorkOrder: order, Transitions: []string{}, Events: []workorder.Event{}}
+	for _, next := range workorder.ValidStatuses() {
+		if workorder.CanTransition(order.Status, next) {
+			detail.Transitions = append(detail.Transitions, string(next))
+		}
+	}
+	if events != nil {
+		detail.Events = events
+	}
+	return detail, nil
+}
+
+func (s *Server) handleWorkOrderStatus(w http.ResponseWriter, r *http.Request) {
+	order, ok := s.workOrder(w, r)
+	if !ok {
+		return
+	}
+	var req StatusRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	next, err := workorder.ParseStatus(req.Status)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if !workorder.CanTransition(order.Status, next) {
+		writeError(w, http.StatusConflict, fmt.Errorf("cannot transition from %q to %q", order.Status, next))
+		return
+	}
+	if next == workorder.StatusInProgress && s.Actor == "" {
+		writeError(w, http.StatusBadRequest, errNoActor)
+		return
+	}
+	updated, err := s.Store.Move(r.Context(), order.ID, next, s.Actor, req.Force)
+	if err != nil {
+		if errors.Is(err, workorder.ErrClaimed) && !req.Force {
+			err = fmt.Errorf("%w; send force to override", err)
+		}
+		writeError(w, workOrderStatusFor(err), err)
+		return
+	}
+	if note := strings.TrimSpace(req.Note); note != "" {
+		if _, err := s.Store.AddEvent(r.Context(), workorder.EventInput{WorkOrderID: order.ID, Kind: workorder.EventNote, Actor: s.Actor, Detail: note}); err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	detail, err := s.workOrderDetail(r, updated)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, detail)
+}
+
+var errNoActor = errors.New("the server has no agent identity to claim with; set CN_AGENT")
+
+// workOrderStatusFor maps work order errors to HTTP statuses.
+func workOrderStatusFor(err error) int {
+	switch {
+	case errors.Is(err, workorder.ErrClaimed), errors.Is(err, workorder.ErrNotClaimant):
+		return http.StatusConflict
+	case errors.Is(err, errNoActor):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+func (s *Server) handleWorkOrderPrompt(w http.ResponseWriter, r *http.Request) {
+	order, ok := s.workOrder(w, r)
+	if !ok {
+		return
+	}
+	if s.Prompt == nil {
+		writeError(w, http.StatusNotImplemented, errors.New("prompt rendering is not configured"))
+		return
+	}
+	prompt, err := s.Prompt(order)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"prompt": prompt})
+}
+
+// workOrder loads the work order named by the id path value, writing the
+// error response when it cannot.
+func (s *Server) workOrder(w http.ResponseWriter, r *http.Request) (workorder.WorkOrder, bool) {
+	if !s.requireStore(w) {
+		return workorder.WorkOrder{}, false
+	}
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid work order id %q", r.PathValue("id")))
+		return workorder.WorkOrder{}, false
+	}
+	order, err := s.Store.Get(r.Context(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			writeError(w, http.StatusNotFound, fmt.Errorf("work order %d not found", id))
+		} else {
+			writeError(w, http.StatusInternalServerError, err)
+		}
+		return workorder.WorkOrder{}, false
+	}
+	return order, true
+}
+
+func (s *Server) requireStore(w http.ResponseWriter) bool {
+	if s.Store == nil {
+		writeError(w, http.StatusNotFound, errors.New("no camp found; run carnie camp init first"))
+		return false
+	}
+	return true
+}
+
+// statusFor maps bead errors to HTTP statuses.
+func statusFor(err error) int {
+	if errors.Is(err, beads.ErrNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+// heartbeatEvery keeps idle streams open through proxies, in poll ticks.
+const heartbeatEvery = 15
+
+// handleEvents streams Server-Sent Events: "ready" once connected, then
+// "change" whenever beads or work orders differ from the last poll. Clients
+// refetch what they show; the events carry no payload beyond a version.
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, errors.New("streaming is not supported"))
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	ctx := r.Context()
+	last, err := s.fingerprint(ctx)
+	if err != nil {
+		writeEvent(w, "error", err.Error())
+		flusher.Flush()
+		return
+	}
+	writeEvent(w, "ready", last)
+	flusher.Flush()
+
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for ticks := 1; ; ticks++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current, err := s.fingerprint(ctx)
+		switch {
+		case err != nil:
+			// A poll can race a write to the beads files; try again next tick.
+			if ctx.Err() != nil {
+				return
+			}
+		case current != last:
+			last = current
+			writeEvent(w, "change", current)
+		case ticks%heartbeatEvery == 0:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		default:
+			continue
+		}
+		flusher.Flush()
+	}
+}
+
+func writeEvent(w http.ResponseWriter, event string, data string) {
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
+
+// fingerprint summarizes the camp's state so polls can tell whether anything
+// changed. Versioned bead clients answer without reading issues.
+func (s *Server) fingerprint(ctx context.Context) (string, error) {
+	hash := fnv.New64a()
+	if versioned, ok := s.Beads.(beads.Versioned); ok {
+		fmt.Fprint(hash, versioned.Version())
+	} else {
+		issues, err := s.Beads.List(ctx, beads.ListOptions{IncludeTombstones: true})
+		if err != nil {
+			return "", err
+		}
+		if err := json.NewEncoder(hash).Encode(issues); err != nil {
+			return "", err
+		}
+	}
+	if s.Store != nil {
+		orders, err := s.Store.List(ctx, workorder.ListOptions{})
+		if err != nil {
+			return "", err
+		}
+		for _, order := range orders {
+			fmt.Fprintf(hash, "|%d:%s:%s:%d", order.ID, order.Status, order.Assignee, order.UpdatedAt.UnixNano())
+		}
+	}
+	return fmt.Sprintf("%016x", hash.Sum64()), nil
+}
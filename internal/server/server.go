@@ -0,0 +1,112 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+const (
+	// DefaultAddr only listens on loopback; the API is for the local user.
+	DefaultAddr = "127.0.0.1:7777"
+	// DefaultPollInterval is how often the event stream checks for changes.
+	DefaultPollInterval = 2 * time.Second
+	// TokenHeader carries the mutation token; "Authorization: Bearer" works too.
+	TokenHeader = "X-Carnie-Token"
+)
+
+//go:embed ui
+var uiFiles embed.FS
+
+// Server serves a JSON API over a camp's beads and work orders, the web UI
+// and a Server-Sent Events stream that fires when either changes. Reads are
+// open; mutations need Token.
+type Server struct {
+	Beads beads.Client
+	// Store is nil outside a camp; the work order endpoints then fail.
+	Store *workorder.Store
+	Token string
+	// Actor is recorded on work order events the API adds.
+	Actor string
+	// Prompt renders a work order's agent prompt.
+	Prompt       func(order workorder.WorkOrder) (string, error)
+	PollInterval time.Duration
+}
+
+// New creates a server with the default poll interval.
+func New(client beads.Client, store *workorder.Store, token string) *Server {
+	return &Server{Beads: client, Store: store, Token: token, PollInterval: DefaultPollInterval}
+}
+
+// NewToken returns a random token for guarding mutations.
+func NewToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// Handler routes the API, the event stream and the UI.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/status", s.handleStatus)
+	mux.HandleFunc("GET /api/beads", s.handleListBeads)
+	mux.HandleFunc("GET /api/beads/{id}", s.handleShowBead)
+	mux.HandleFunc("POST /api/beads/{id}/status", s.guard(s.handleBeadStatus))
+	mux.HandleFunc("GET /api/workorders", s.handleListWorkOrders)
+	mux.HandleFunc("GET /api/workorders/{id}", s.handleShowWorkOrder)
+	mux.HandleFunc("POST /api/workorders/{id}/status", s.guard(s.handleWorkOrderStatus))
+	mux.HandleFunc("GET /api/workorders/{id}/prompt", s.handleWorkOrderPrompt)
+	mux.HandleFunc("GET /api/events", s.handleEvents)
+
+	ui, _ := fs.Sub(uiFiles, "ui")
+	mux.Handle("GET /", http.FileServerFS(ui))
+	return mux
+}
+
+// guard rejects requests that do not carry the server token.
+func (s *Server) guard(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(TokenHeader)
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			token = bearer
+		}
+		if s.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.Token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or wrong token"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// decodeBody reads a small JSON request body into value.
+func decodeBody(r *http.Request, value any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(value); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
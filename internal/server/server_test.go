@@ -0,0 +1,238 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+	"github.com/rikurb8/carnie/internal/workorder"
+)
+
+const testToken = "secret"
+
+func newTestServer(t *testing.T) (*httptest.Server, *beads.Fake, *workorder.Store) {
+	t.Helper()
+	store, err := workorder.OpenStore(filepath.Join(t.TempDir(), "carniecamp.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	if _, err := store.Create(t.Context(), workorder.CreateInput{Title: "Build login", Description: "Do it", BeadID: "cn-2", Status: workorder.StatusReady}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-1", Title: "Auth", Status: beads.StatusOpen, IssueType: "feature"},
+		beads.Issue{ID: "cn-2", Title: "Login", Status: beads.StatusOpen, IssueType: "task", Dependencies: []beads.Dependency{
+			{IssueID: "cn-2", DependsOnID: "cn-1", Type: beads.DepParentChild},
+			{IssueID: "cn-2", DependsOnID: "cn-3", Type: beads.DepBlocks},
+		}},
+		beads.Issue{ID: "cn-3", Title: "Schema", Status: beads.StatusInProgress, IssueType: "task"},
+	)
+	srv := New(client, store, testToken)
+	srv.Actor = "tester"
+	srv.PollInterval = 10 * time.Millisecond
+	srv.Prompt = func(order workorder.WorkOrder) (string, error) {
+		return "Work on " + order.Title, nil
+	}
+	ts := httptest.NewServer(srv.Handler())
+	t.Cleanup(ts.Close)
+	return ts, client, store
+}
+
+func request(t *testing.T, ts *httptest.Server, method string, path string, body string, token string) (int, []byte) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("new request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set(TokenHeader, token)
+	}
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return resp.StatusCode, data
+}
+
+func decode[T any](t *testing.T, data []byte) T {
+	t.Helper()
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Fatalf("decode %s: %v", data, err)
+	}
+	return value
+}
+
+func TestReadEndpoints(t *testing.T) {
+	ts, _, _ := newTestServer(t)
+
+	code, data := request(t, ts, http.MethodGet, "/api/beads?status=open", "", "")
+	if issues := decode[[]beads.Issue](t, data); code != http.StatusOK || len(issues) != 2 {
+		t.Fatalf("expected two open beads, got %d %s", code, data)
+	}
+
+	code, data = request(t, ts, http.MethodGet, "/api/beads/cn-2", "", "")
+	detail := decode[workorder.BeadDetail](t, data)
+	if code != http.StatusOK || detail.Title != "Login" || len(detail.BlockedBy) != 1 || len(detail.WorkOrders) != 1 {
+		t.Fatalf("unexpected bead detail %d %s", code, data)
+	}
+	if code, _ := request(t, ts, http.MethodGet, "/api/beads/cn-9", "", ""); code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a missing bead, got %d", code)
+	}
+
+	code, data = request(t, ts, http.MethodGet, "/api/workorders/1", "", "")
+	order := decode[WorkOrderDetail](t, data)
+	if code != http.StatusOK || order.Status != "ready" || !strings.Contains(strings.Join(order.Transitions, ","), "in_progress") {
+		t.Fatalf("unexpected work order detail %d %s", code, data)
+	}
+	if code, _ := request(t, ts, http.MethodGet, "/api/workorders?status=bogus", "", ""); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad status filter, got %d", code)
+	}
+
+	code, data = request(t, ts, http.MethodGet, "/api/workorders/1/prompt", "", "")
+	if prompt := decode[map[string]string](t, data); code != http.StatusOK || prompt["prompt"] != "Work on Build login" {
+		t.Fatalf("unexpected prompt %d %s", code, data)
+	}
+
+	code, data = request(t, ts, http.MethodGet, "/api/status", "", "")
+	if code != http.StatusOK || !strings.Contains(string(data), `"total_issues":3`) {
+		t.Fatalf("unexpected status %d %s", code, data)
+	}
+}
+
+func TestMutationsNeedToken(t *testing.T) {
+	ts, client, store := newTestServer(t)
+
+	if code, _ := request(t, ts, http.MethodPost, "/api/beads/cn-2/status", `{"status":"in_progress"}`, ""); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", code)
+	}
+	if code, _ := request(t, ts, http.MethodPost, "/api/workorders/1/status", `{"status":"in_progress"}`, "wrong"); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 with a wrong token, got %d", code)
+	}
+
+	if code, data := request(t, ts, http.MethodPost, "/api/beads/cn-2/status", `{"status":"closed","reason":"shipped"}`, testToken); code != http.StatusOK {
+		t.Fatalf("close bead: %d %s", code, data)
+	}
+	if issue, _ := client.Show(t.Context(), "cn-2"); issue.Status != beads.StatusClosed {
+		t.Fatalf("expected cn-2 closed, got %s", issue.Status)
+	}
+	if code, _ := request(t, ts, http.MethodPost, "/api/beads/cn-2/status", `{"status":"done"}`, testToken); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for an unknown bead status, got %d", code)
+	}
+
+	code, data := request(t, ts, http.MethodPost, "/api/workorders/1/status", `{"status":"in_progress","note":"picked up"}`, testToken)
+	order := decode[WorkOrderDetail](t, data)
+	if code != http.StatusOK || order.Status != "in_progress" || order.Events[len(order.Events)-1].Detail != "picked up" {
+		t.Fatalf("unexpected transition result %d %s", code, data)
+	}
+	if code, _ := request(t, ts, http.MethodPost, "/api/workorders/1/status", `{"status":"draft"}`, testToken); code != http.StatusConflict {
+		t.Fatalf("expected 409 for an invalid transition, got %d", code)
+	}
+	if stored, _ := store.Get(t.Context(), 1); stored.Status != workorder.StatusInProgress {
+		t.Fatalf("expected the rejected transition to leave the order alone, got %s", stored.Status)
+	}
+}
+
+func TestWorkOrderStatusRespectsClaims(t *testing.T) {
+	ts, _, store := newTestServer(t)
+
+	code, data := request(t, ts, http.MethodPost, "/api/workorders/1/status", `{"status":"in_progress"}`, testToken)
+	order := decode[WorkOrderDetail](t, data)
+	if code != http.StatusOK || order.Assignee != "tester" || order.LeaseUntil == nil {
+		t.Fatalf("expected starting work to claim the order, got %d %s", code, data)
+	}
+	if last := order.Events[len(order.Events)-1]; last.Kind != workorder.EventClaimed || last.Actor != "tester" {
+		t.Fatalf("expected a claimed event by tester, got %+v", last)
+	}
+
+	if _, err := store.Release(t.Context(), 1, "tester", false); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if _, err := store.Claim(t.Context(), 1, "bob", time.Hour); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if code, data := request(t, ts, http.MethodPost, "/api/workorders/1/status", `{"status":"blocked"}`, testToken); code != http.StatusConflict {
+		t.Fatalf("expected 409 for an order claimed by another agent, got %d %s", code, data)
+	}
+	if stored, _ := store.Get(t.Context(), 1); stored.Status != workorder.StatusInProgress || stored.Assignee != "bob" {
+		t.Fatalf("expected bob's claim to be left alone, got %s/%q", stored.Status, stored.Assignee)
+	}
+
+	code, data = request(t, ts, http.MethodPost, "/api/workorders/1/status", `{"status":"ready","force":true}`, testToken)
+	order = decode[WorkOrderDetail](t, data)
+	if code != http.StatusOK || order.Status != workorder.StatusReady || order.Assignee != "" {
+		t.Fatalf("expected a forced release, got %d %s", code, data)
+	}
+	if last := order.Events[len(order.Events)-1]; last.Kind != workorder.EventReleased || last.Actor != "tester" {
+		t.Fatalf("expected a released event by tester, got %+v", last)
+	}
+
+	code, data = request(t, ts, http.MethodPost, "/api/workorders/1/status", `{"status":"canceled"}`, testToken)
+	order = decode[WorkOrderDetail](t, data)
+	if last := order.Events[len(order.Events)-1]; code != http.StatusOK || last.Kind != workorder.EventStatusChanged || last.Actor != "tester" {
+		t.Fatalf("expected a status change by tester, got %d %s", code, data)
+	}
+}
+
+func TestEventsStreamChanges(t *testing.T) {
+	ts, client, _ := newTestServer(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/api/events", nil)
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	defer resp.Body.Close()
+	if got := resp.Header.Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", got)
+	}
+
+	lines := bufio.NewScanner(resp.Body)
+	next := func() string {
+		for lines.Scan() {
+			if event, ok := strings.CutPrefix(lines.Text(), "event: "); ok {
+				return event
+			}
+		}
+		t.Fatalf("stream ended: %v", lines.Err())
+		return ""
+	}
+	if event := next(); event != "ready" {
+		t.Fatalf("expected a ready event first, got %q", event)
+	}
+	if err := client.Close(t.Context(), "cn-3", "done"); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if event := next(); event != "change" {
+		t.Fatalf("expected a change event, got %q", event)
+	}
+}
+
+func TestServesUI(t *testing.T) {
+	ts, _, _ := newTestServer(t)
+
+	code, data := request(t, ts, http.MethodGet, "/", "", "")
+	if code != http.StatusOK || !strings.Contains(string(data), `<script src="app.js">`) {
+		t.Fatalf("expected the UI index, got %d %s", code, data)
+	}
+	if code, _ := request(t, ts, http.MethodGet, "/app.js", "", ""); code != http.StatusOK {
+		t.Fatalf("expected app.js, got %d", code)
+	}
+}
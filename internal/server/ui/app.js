@@ -0,0 +1,225 @@
+// Carnie web UI: a board of beads by status, the work orders list and
+// detail pages, refreshed over the /api/events stream. No dependencies.
+"use strict";
+
+const TOKEN_KEY = "carnie-token";
+const BEAD_COLUMNS = [
+  ["open", "Open"],
+  ["in_progress", "In Progress"],
+  ["blocked", "Blocked"],
+  ["closed", "Closed"],
+];
+const CLOSED_LIMIT = 20;
+
+const app = document.getElementById("app");
+
+// The token arrives once as ?token= from the URL carnie serve prints; keep
+// it and drop it from the address bar.
+(function storeToken() {
+  const params = new URLSearchParams(location.search);
+  const token = params.get("token");
+  if (token) {
+    localStorage.setItem(TOKEN_KEY, token);
+    history.replaceState(null, "", location.pathname + location.hash);
+  }
+})();
+
+async function api(path, options = {}) {
+  const headers = { "Content-Type": "application/json" };
+  const token = localStorage.getItem(TOKEN_KEY);
+  if (token) headers["X-Carnie-Token"] = token;
+  const response = await fetch(path, { ...options, headers });
+  const body = await response.json().catch(() => ({}));
+  if (!response.ok) throw new Error(body.error || response.statusText);
+  return body;
+}
+
+function post(path, payload) {
+  return api(path, { method: "POST", body: JSON.stringify(payload) });
+}
+
+function el(tag, attrs = {}, ...children) {
+  const node = document.createElement(tag);
+  for (const [key, value] of Object.entries(attrs)) {
+    if (key === "onclick") node.addEventListener("click", value);
+    else node.setAttribute(key, value);
+  }
+  for (const child of children.flat()) {
+    if (child === null || child === undefined) continue;
+    node.append(child instanceof Node ? child : String(child));
+  }
+  return node;
+}
+
+function when(value) {
+  return value ? new Date(value).toLocaleString() : "";
+}
+
+function showError(err) {
+  app.replaceChildren(el("p", { class: "error" }, err.message));
+}
+
+async function renderBoard() {
+  const [beads, orders, status] = await Promise.all([
+    api("/api/beads"),
+    api("/api/workorders").catch(() => []),
+    api("/api/status"),
+  ]);
+  const summary = status.beads;
+  document.getElementById("counts").textContent =
+    `${summary.total_issues} beads · ${summary.open_issues} open · ${summary.ready_issues} ready · ${summary.in_progress_issues} in progress · ${summary.blocked_issues} blocked`;
+
+  const columns = BEAD_COLUMNS.map(([key, label]) => {
+    let issues = beads.filter((issue) => issue.status === key);
+    if (key === "closed") {
+      issues = issues
+        .sort((a, b) => (b.closed_at || "").localeCompare(a.closed_at || ""))
+        .slice(0, CLOSED_LIMIT);
+    }
+    return el("div", { class: "column" },
+      el("h2", {}, `${label} (${issues.length})`),
+      issues.map((issue) =>
+        el("a", { class: "card", href: `#/bead/${encodeURIComponent(issue.id)}` },
+          el("div", { class: "id" }, issue.id, el("span", { class: "tag" }, issue.issue_type), el("span", { class: "tag" }, `P${issue.priority}`)),
+          issue.title)));
+  });
+
+  const rows = orders.map((order) =>
+    el("tr", {},
+      el("td", {}, el("a", { href: `#/workorder/${order.id}` }, `#${order.id}`)),
+      el("td", { class: "mono" }, order.status),
+      el("td", {}, order.title),
+      el("td", { class: "mono" }, order.bead_id || ""),
+      el("td", {}, order.assignee || "")));
+
+  app.replaceChildren(
+    el("div", { class: "board" }, columns),
+    el("section", {},
+      el("h2", {}, `Work orders (${orders.length})`),
+      orders.length
+        ? el("table", {}, el("tr", {}, ["#", "Status", "Title", "Bead", "Assignee"].map((h) => el("th", {}, h))), rows)
+        : el("p", { class: "muted" }, "None.")));
+}
+
+function issueList(title, issues) {
+  if (!issues.length) return null;
+  return el("section", {},
+    el("h3", {}, title),
+    el("ul", {}, issues.map((issue) =>
+      el("li", {}, el("a", { href: `#/bead/${encodeURIComponent(issue.id)}` }, issue.id), ` ${issue.title} `, el("span", { class: "tag" }, issue.status)))));
+}
+
+async function renderBead(id) {
+  const bead = await api(`/api/beads/${encodeURIComponent(id)}`);
+  const buttons = BEAD_COLUMNS
+    .filter(([key]) => key !== bead.status)
+    .map(([key, label]) => el("button", {
+      onclick: async () => {
+        const payload = { status: key };
+        if (key === "closed") payload.reason = prompt("Close reason", "Completed") ?? "";
+        try {
+          await post(`/api/beads/${encodeURIComponent(id)}/status`, payload);
+          await route();
+        } catch (err) {
+          alert(err.message);
+        }
+      },
+    }, `Move to ${label}`));
+
+  app.replaceChildren(el("div", { class: "detail" },
+    el("p", {}, el("a", { href: "#/" }, "← Board")),
+    el("h1", {}, bead.title),
+    el("p", { class: "mono" }, `${bead.id} · ${bead.issue_type} · P${bead.priority} · ${bead.status}`, bead.assignee ? ` · ${bead.assignee}` : ""),
+    el("div", { class: "actions" }, buttons),
+    bead.description ? el("pre", {}, bead.description) : el("p", { class: "muted" }, "No description."),
+    issueList("Children", bead.children),
+    issueList("Blocked by", bead.blocked_by),
+    issueList("Blocks", bead.blocks),
+    bead.work_orders.length
+      ? el("section", {}, el("h3", {}, "Work orders"), el("ul", {}, bead.work_orders.map((order) =>
+          el("li", {}, el("a", { href: `#/workorder/${order.id}` }, `#${order.id}`), ` ${order.title} `, el("span", { class: "tag" }, order.status)))))
+      : null,
+    el("p", { class: "muted" }, `Created ${when(bead.created_at)} · updated ${when(bead.updated_at)}`)));
+}
+
+async function renderWorkOrder(id) {
+  const order = await api(`/api/workorders/${id}`);
+  const note = el("input", { type: "text", placeholder: "Note (optional)" });
+  const promptBox = el("div");
+  const claimed = Boolean(order.assignee && order.lease_until && new Date(order.lease_until) > new Date());
+  const buttons = order.transitions.map((status) => el("button", {
+    onclick: async () => {
+      const force = claimed && confirm(`#${id} is claimed by ${order.assignee}. Move it anyway?`);
+      if (claimed && !force) return;
+      try {
+        await post(`/api/workorders/${id}/status`, { status, note: note.value, force });
+        await route();
+      } catch (err) {
+        alert(err.message);
+      }
+    },
+  }, `Move to ${status}`));
+  const showPrompt = el("button", {
+    onclick: async () => {
+      try {
+        const rendered = await api(`/api/workorders/${id}/prompt`);
+        promptBox.replaceChildren(el("h3", {}, "Prompt"), el("pre", {}, rendered.prompt));
+      } catch (err) {
+        promptBox.replaceChildren(el("p", { class: "error" }, err.message));
+      }
+    },
+  }, "Render prompt");
+
+  app.replaceChildren(el("div", { class: "detail" },
+    el("p", {}, el("a", { href: "#/" }, "← Board")),
+    el("h1", {}, `#${order.id} ${order.title}`),
+    el("p", { class: "mono" }, order.status,
+      order.bead_id ? el("span", {}, " · ", el("a", { href: `#/bead/${encodeURIComponent(order.bead_id)}` }, order.bead_id)) : null,
+      order.crew ? ` · crew ${order.crew}` : "",
+      order.assignee ? ` · ${order.assignee}` : ""),
+    buttons.length ? el("div", { class: "actions" }, note, buttons) : null,
+    el("pre", {}, order.description),
+    el("div", { class: "actions" }, showPrompt),
+    promptBox,
+    el("section", {},
+      el("h3", {}, "Events"),
+      el("table", {}, order.events.map((event) =>
+        el("tr", {},
+          el("td", { class: "mono" }, when(event.created_at)),
+          el("td", { class: "mono" }, event.kind),
+          el("td", {}, event.actor || ""),
+          el("td", {}, event.detail || "")))))));
+}
+
+async function route() {
+  const [, kind, id] = location.hash.match(/^#\/(bead|workorder)\/(.+)$/) || [];
+  try {
+    if (kind === "bead") await renderBead(decodeURIComponent(id));
+    else if (kind === "workorder") await renderWorkOrder(id);
+    else await renderBoard();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+function listen() {
+  const live = document.getElementById("live");
+  const source = new EventSource("/api/events");
+  source.addEventListener("ready", () => {
+    live.textContent = "live";
+    live.classList.add("on");
+  });
+  source.addEventListener("change", () => {
+    // Keep a half-typed note; refresh once the page is idle again.
+    if (document.activeElement && document.activeElement.tagName === "INPUT") return;
+    route();
+  });
+  source.onerror = () => {
+    live.textContent = "offline";
+    live.classList.remove("on");
+  };
+}
+
+window.addEventListener("hashchange", route);
+route();
+listen();
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+)
+
+// sessionIDKeys are the JSON keys tools use for their conversation ID.
+var sessionIDKeys = []string{"session_id", "sessionID", "sessionId"}
+
+// sessionIDPattern matches a line that is only a session announcement, such
+// as "Session ID: abc123". The id label is required and anchored so agent
+// prose like "Session: expired" is not taken for an ID.
+var sessionIDPattern = regexp.MustCompile(`(?i)^session[ _-]?id\s*[:=]\s*([A-Za-z0-9][A-Za-z0-9_-]{5,})$`)
+
+// ParseSessionID extracts a session ID from a single line of tool output.
+// JSON lines are searched for the session keys emitted by claude and opencode;
+// other lines fall back to a plain-text match. It returns "" when none is found.
+func ParseSessionID(line []byte) string {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 {
+		return ""
+	}
+
+	if trimmed[0] == '{' {
+		var value any
+		if err := json.Unmarshal(trimmed, &value); err == nil {
+			return findSessionID(value, 0)
+		}
+	}
+
+	match := sessionIDPattern.FindSubmatch(trimmed)
+	if match == nil {
+		return ""
+	}
+	return string(match[1])
+}
+
+// findSessionID searches decoded JSON for a session ID key, preferring shallow matches.
+func findSessionID(value any, depth int) string {
+	if depth > 4 {
+		return ""
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return ""
+	}
+	for _, key := range sessionIDKeys {
+		if id, ok := object[key].(string); ok && id != "" {
+			return id
+		}
+	}
+	for _, nested := range object {
+		if id := findSessionID(nested, depth+1); id != "" {
+			return id
+		}
+	}
+	return ""
+}
@@ -0,0 +1,117 @@
+package session
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+)
+
+// RunOptions configures how a session process is executed.
+type RunOptions struct {
+	Dir    string    // Working directory for the tool
+	Stdout io.Writer // Receives the tool output as it is produced
+	Stderr io.Writer // Receives the tool error output
+
+	MaxCostUSD float64 // Stop the run once reported cost exceeds this (0 = no limit)
+	MaxTokens  int64   // Stop the run once reported tokens exceed this (0 = no limit)
+}
+
+// ErrBudgetExceeded is returned when a run is stopped for exceeding its budget.
+var ErrBudgetExceeded = errors.New("run budget exceeded")
+
+// Result captures what carnie learned from a finished session run.
+type Result struct {
+	ExitCode       int
+	SessionID      string
+	Usage          Usage
+	BudgetExceeded bool
+}
+
+// Run starts the tool described by opts and waits for it to exit.
+// Output is streamed to the configured writers and scanned for a session ID
+// and usage data; the process is stopped once a configured budget is exceeded.
+func Run(ctx context.Context, opts Options, runOpts RunOptions) (Result, error) {
+	args := Args(opts)
+	if len(args) == 0 {
+		return Result{}, fmt.Errorf("build session command")
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	command := exec.CommandContext(runCtx, args[0], args[1:]...)
+	command.Dir = runOpts.Dir
+	command.Stdin = os.Stdin
+	command.Stderr = runOpts.Stderr
+	if command.Stderr == nil {
+		command.Stderr = os.Stderr
+	}
+
+	stdout, err := command.StdoutPipe()
+	if err != nil {
+		return Result{}, fmt.Errorf("open %s output: %w", args[0], err)
+	}
+	if err := command.Start(); err != nil {
+		return Result{}, fmt.Errorf("start %s: %w", args[0], err)
+	}
+
+	var result Result
+	var meter usageMeter
+	reader := bufio.NewReader(stdout)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			if runOpts.Stdout != nil {
+				_, _ = runOpts.Stdout.Write(line)
+			}
+			if id := ParseSessionID(line); id != "" {
+				result.SessionID = id
+			}
+			if meter.observe(line) {
+				result.Usage = meter.total
+				if !result.BudgetExceeded && overBudget(result.Usage, runOpts) {
+					result.BudgetExceeded = true
+					cancel()
+				}
+			}
+		}
+		if readErr != nil {
+			break
+		}
+	}
+
+	waitErr := command.Wait()
+	var exitErr *exec.ExitError
+	if errors.As(waitErr, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+	}
+	if result.BudgetExceeded {
+		return result, fmt.Errorf("%w: %s", ErrBudgetExceeded, describeUsage(result.Usage))
+	}
+	if exitErr != nil {
+		return result, fmt.Errorf("%s exited with code %d", args[0], result.ExitCode)
+	}
+	if waitErr != nil {
+		return result, fmt.Errorf("wait for %s: %w", args[0], waitErr)
+	}
+
+	return result, nil
+}
+
+func overBudget(usage Usage, runOpts RunOptions) bool {
+	if runOpts.MaxCostUSD > 0 && usage.CostUSD > runOpts.MaxCostUSD {
+		return true
+	}
+	if runOpts.MaxTokens > 0 && usage.TotalTokens() > runOpts.MaxTokens {
+		return true
+	}
+	return false
+}
+
+func describeUsage(usage Usage) string {
+	return fmt.Sprintf("%d tokens, $%.4f", usage.TotalTokens(), usage.CostUSD)
+}
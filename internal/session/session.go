@@ -31,6 +31,8 @@ type Options struct {
 	Prompt       string // Initial prompt/message to send
 	SystemPrompt string // System prompt (for claude)
 	Interactive  bool   // When true, do not send the prompt as a one-shot
+	SessionID    string // Existing session/conversation to resume
+	JSONOutput   bool   // Request machine-readable output for one-shot runs
 }
 
 // NormalizeModel ensures a model string is compatible with the tool.
@@ -52,23 +54,32 @@ func NormalizeModel(tool Tool, model string) string {
 
 // Command returns the command line to start the tool with the given options.
 func Command(opts Options) string {
-	toolCmd := buildToolCommand(opts)
+	toolCmd := Args(opts)
 	if len(toolCmd) == 0 {
 		return ""
 	}
-	return formatCommand(toolCmd[0], toolCmd[1:])
+	return FormatCommand(toolCmd[0], toolCmd[1:])
 }
 
-func formatCommand(name string, args []string) string {
+// Args returns the command name and arguments to start the tool with the given options.
+func Args(opts Options) []string {
+	return buildToolCommand(opts)
+}
+
+// FormatCommand joins name and args into a command line that can be pasted
+// into a shell.
+func FormatCommand(name string, args []string) string {
 	parts := make([]string, 0, len(args)+1)
-	parts = append(parts, quoteArg(name))
+	parts = append(parts, QuoteArg(name))
 	for _, arg := range args {
-		parts = append(parts, quoteArg(arg))
+		parts = append(parts, QuoteArg(arg))
 	}
 	return strings.Join(parts, " ")
 }
 
-func quoteArg(arg string) string {
+// QuoteArg quotes arg for a shell when it contains whitespace, quotes or
+// shell metacharacters, and returns it unchanged otherwise.
+func QuoteArg(arg string) string {
 	if arg == "" {
 		return `""`
 	}
@@ -131,12 +142,19 @@ func buildClaudeArgs(opts Options) []string {
 		args = append(args, "--model", opts.Model)
 	}
 
+	if opts.SessionID != "" {
+		args = append(args, "--resume", opts.SessionID)
+	}
+
 	if opts.SystemPrompt != "" {
 		args = append(args, "--system-prompt", opts.SystemPrompt)
 	}
 
 	if opts.Prompt != "" && !opts.Interactive {
 		args = append(args, "--print", opts.Prompt)
+		if opts.JSONOutput {
+			args = append(args, "--output-format", "stream-json", "--verbose")
+		}
 	}
 
 	return args
@@ -150,12 +168,19 @@ func buildOpencodeArgs(opts Options) []string {
 		args = append(args, "--model", opts.Model)
 	}
 
+	if opts.SessionID != "" {
+		args = append(args, "--session", opts.SessionID)
+	}
+
 	if opts.SystemPrompt != "" {
 		args = append(args, "--prompt", opts.SystemPrompt)
 	}
 
 	if opts.Prompt != "" && !opts.Interactive {
 		args = append(args, "-p", opts.Prompt)
+		if opts.JSONOutput {
+			args = append(args, "--format", "json")
+		}
 	}
 
 	return args
@@ -81,6 +81,16 @@ func TestBuildClaudeArgs(t *testing.T) {
 			},
 			wantArgs: []string{"claude", "--system-prompt", "system"},
 		},
+		{
+			name: "resume with json output",
+			opts: Options{
+				Tool:       ToolClaude,
+				Prompt:     "continue",
+				SessionID:  "abc-123",
+				JSONOutput: true,
+			},
+			wantArgs: []string{"claude", "--resume", "abc-123", "--print", "continue", "--output-format", "stream-json", "--verbose"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -145,6 +155,16 @@ func TestBuildOpencodeArgs(t *testing.T) {
 			},
 			wantArgs: []string{"opencode", "--prompt", "system"},
 		},
+		{
+			name: "resume with json output",
+			opts: Options{
+				Tool:       ToolOpencode,
+				Prompt:     "continue",
+				SessionID:  "ses_123",
+				JSONOutput: true,
+			},
+			wantArgs: []string{"opencode", "--session", "ses_123", "-p", "continue", "--format", "json"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -233,3 +253,112 @@ func TestCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatCommand(t *testing.T) {
+	got := FormatCommand("bd", []string{"create", "", "it's $HOME", "a\nb", "--title=`x`", "plain"})
+	want := `bd create "" $'it\'s $HOME' $'a\nb' $'--title=` + "`x`" + `' plain`
+	if got != want {
+		t.Errorf("FormatCommand() = %q, want %q", got, want)
+	}
+}
+
+func TestParseSessionID(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want string
+	}{
+		{"claude result", `{"type":"result","session_id":"4f1c2a9e-1234","total_cost_usd":0.01}`, "4f1c2a9e-1234"},
+		{"opencode event", `{"type":"text","sessionID":"ses_abc123","part":{"text":"hi"}}`, "ses_abc123"},
+		{"nested", `{"type":"system","data":{"sessionId":"nested-42"}}`, "nested-42"},
+		{"plain text", "Session ID: run_98765", "run_98765"},
+		{"plain text with padding", "  session_id=run_98765  ", "run_98765"},
+		{"prose without id label", "Session: expired", ""},
+		{"prose mentioning an id", "The session id: abcdef was not found", ""},
+		{"no session", `{"type":"text","text":"hello"}`, ""},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseSessionID([]byte(tt.line))
+			if got != tt.want {
+				t.Errorf("ParseSessionID(%q) = %q, want %q", tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseUsage(t *testing.T) {
+	tests := []struct {
+		name           string
+		line           string
+		want           Usage
+		wantCumulative bool
+		wantOK         bool
+	}{
+		{
+			name:           "claude result",
+			line:           `{"type":"result","session_id":"s1","total_cost_usd":0.25,"usage":{"input_tokens":100,"output_tokens":40,"cache_read_input_tokens":10,"cache_creation_input_tokens":5}}`,
+			want:           Usage{InputTokens: 100, OutputTokens: 40, CacheReadTokens: 10, CacheWriteTokens: 5, CostUSD: 0.25},
+			wantCumulative: true,
+			wantOK:         true,
+		},
+		{
+			name:   "opencode step finish",
+			line:   `{"type":"step_finish","sessionID":"ses_1","part":{"type":"step-finish","cost":0.01,"tokens":{"input":20,"output":5,"reasoning":3,"cache":{"read":2,"write":1}}}}`,
+			want:   Usage{InputTokens: 20, OutputTokens: 8, CacheReadTokens: 2, CacheWriteTokens: 1, CostUSD: 0.01},
+			wantOK: true,
+		},
+		{
+			name: "claude assistant message",
+			line: `{"type":"assistant","message":{"usage":{"input_tokens":3}}}`,
+		},
+		{
+			name: "plain text",
+			line: "Working on it...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, cumulative, ok := ParseUsage([]byte(tt.line))
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if cumulative != tt.wantCumulative {
+				t.Errorf("cumulative = %v, want %v", cumulative, tt.wantCumulative)
+			}
+			if got != tt.want {
+				t.Errorf("usage = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUsageMeter(t *testing.T) {
+	var meter usageMeter
+	lines := []string{
+		`{"type":"system","subtype":"init","session_id":"s1"}`,
+		`{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":10,"output_tokens":2,"cache_read_input_tokens":100}}}`,
+		`{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":10,"output_tokens":5,"cache_read_input_tokens":100}}}`,
+		`{"type":"user","message":{"content":[]}}`,
+		`{"type":"assistant","message":{"id":"msg_2","usage":{"input_tokens":4,"output_tokens":3,"cache_creation_input_tokens":20}}}`,
+	}
+	for _, line := range lines {
+		meter.observe([]byte(line))
+	}
+	want := Usage{InputTokens: 14, OutputTokens: 8, CacheReadTokens: 100, CacheWriteTokens: 20}
+	if meter.total != want {
+		t.Fatalf("running total = %+v, want %+v", meter.total, want)
+	}
+
+	meter.observe([]byte(`{"type":"result","total_cost_usd":0.5,"usage":{"input_tokens":15,"output_tokens":9,"cache_read_input_tokens":100,"cache_creation_input_tokens":20}}`))
+	want = Usage{InputTokens: 15, OutputTokens: 9, CacheReadTokens: 100, CacheWriteTokens: 20, CostUSD: 0.5}
+	if meter.total != want {
+		t.Fatalf("final total = %+v, want %+v", meter.total, want)
+	}
+	if meter.observe([]byte(`{"type":"assistant","message":{"id":"msg_3","usage":{"input_tokens":1}}}`)) || meter.total != want {
+		t.Fatalf("expected the result line to stay authoritative, got %+v", meter.total)
+	}
+}
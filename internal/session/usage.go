@@ -0,0 +1,193 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Usage holds token and cost accounting reported by a tool.
+type Usage struct {
+	InputTokens      int64
+	OutputTokens     int64
+	CacheReadTokens  int64
+	CacheWriteTokens int64
+	CostUSD          float64
+}
+
+// Add returns the sum of two usage values.
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		InputTokens:      u.InputTokens + other.InputTokens,
+		OutputTokens:     u.OutputTokens + other.OutputTokens,
+		CacheReadTokens:  u.CacheReadTokens + other.CacheReadTokens,
+		CacheWriteTokens: u.CacheWriteTokens + other.CacheWriteTokens,
+		CostUSD:          u.CostUSD + other.CostUSD,
+	}
+}
+
+// Sub returns u minus other.
+func (u Usage) Sub(other Usage) Usage {
+	return Usage{
+		InputTokens:      u.InputTokens - other.InputTokens,
+		OutputTokens:     u.OutputTokens - other.OutputTokens,
+		CacheReadTokens:  u.CacheReadTokens - other.CacheReadTokens,
+		CacheWriteTokens: u.CacheWriteTokens - other.CacheWriteTokens,
+		CostUSD:          u.CostUSD - other.CostUSD,
+	}
+}
+
+// TotalTokens returns all tokens counted against the run.
+func (u Usage) TotalTokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheReadTokens + u.CacheWriteTokens
+}
+
+// IsZero reports whether no usage was recorded.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// claudeUsage is the usage object on claude's result line and on each
+// message it streams.
+type claudeUsage struct {
+	InputTokens              int64 `json:"input_tokens"`
+	OutputTokens             int64 `json:"output_tokens"`
+	CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+}
+
+func (u claudeUsage) usage() Usage {
+	return Usage{
+		InputTokens:      u.InputTokens,
+		OutputTokens:     u.OutputTokens,
+		CacheReadTokens:  u.CacheReadInputTokens,
+		CacheWriteTokens: u.CacheCreationInputTokens,
+	}
+}
+
+// claudeUsageLine is the subset of claude's JSON output carrying usage.
+// The final "result" line reports cumulative totals for the whole session.
+type claudeUsageLine struct {
+	Type         string       `json:"type"`
+	TotalCostUSD *float64     `json:"total_cost_usd"`
+	Usage        *claudeUsage `json:"usage"`
+}
+
+// claudeMessageLine is a stream-json "assistant" event. Every event for the
+// same API message repeats that message's usage, so it is keyed by ID.
+type claudeMessageLine struct {
+	Type    string `json:"type"`
+	Message *struct {
+		ID    string       `json:"id"`
+		Usage *claudeUsage `json:"usage"`
+	} `json:"message"`
+}
+
+// opencodeUsageLine is the subset of opencode's JSON events carrying usage.
+// Each finished step reports the tokens and cost for that step only.
+type opencodeUsageLine struct {
+	Type string `json:"type"`
+	Part *struct {
+		Type   string   `json:"type"`
+		Cost   *float64 `json:"cost"`
+		Tokens *struct {
+			Input     int64 `json:"input"`
+			Output    int64 `json:"output"`
+			Reasoning int64 `json:"reasoning"`
+			Cache     struct {
+				Read  int64 `json:"read"`
+				Write int64 `json:"write"`
+			} `json:"cache"`
+		} `json:"tokens"`
+	} `json:"part"`
+}
+
+// ParseUsage extracts usage from a single line of JSON tool output.
+// cumulative reports whether the values are session totals that replace
+// earlier figures rather than a delta to add. ok is false when the line
+// carries no usage data.
+func ParseUsage(line []byte) (usage Usage, cumulative bool, ok bool) {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return Usage{}, false, false
+	}
+
+	var claude claudeUsageLine
+	if err := json.Unmarshal(trimmed, &claude); err == nil && claude.Type == "result" && (claude.TotalCostUSD != nil || claude.Usage != nil) {
+		if claude.Usage != nil {
+			usage = claude.Usage.usage()
+		}
+		if claude.TotalCostUSD != nil {
+			usage.CostUSD = *claude.TotalCostUSD
+		}
+		return usage, true, true
+	}
+
+	var opencode opencodeUsageLine
+	if err := json.Unmarshal(trimmed, &opencode); err == nil && opencode.Part != nil && opencode.Part.Type == "step-finish" {
+		if opencode.Part.Cost != nil {
+			usage.CostUSD = *opencode.Part.Cost
+		}
+		if tokens := opencode.Part.Tokens; tokens != nil {
+			usage.InputTokens = tokens.Input
+			usage.OutputTokens = tokens.Output + tokens.Reasoning
+			usage.CacheReadTokens = tokens.Cache.Read
+			usage.CacheWriteTokens = tokens.Cache.Write
+		}
+		return usage, false, !usage.IsZero()
+	}
+
+	return Usage{}, false, false
+}
+
+// parseClaudeMessageUsage extracts the usage of a claude stream-json
+// assistant message. It carries tokens but no cost.
+func parseClaudeMessageUsage(line []byte) (id string, usage Usage, ok bool) {
+	trimmed := bytes.TrimSpace(line)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return "", Usage{}, false
+	}
+	var claude claudeMessageLine
+	if err := json.Unmarshal(trimmed, &claude); err != nil || claude.Type != "assistant" || claude.Message == nil || claude.Message.Usage == nil {
+		return "", Usage{}, false
+	}
+	return claude.Message.ID, claude.Message.Usage.usage(), true
+}
+
+// usageMeter totals usage over the output lines of a run. Claude's assistant
+// messages keep a running total while it works, so budgets can stop a run
+// early; its result line is authoritative and replaces that total.
+type usageMeter struct {
+	total    Usage
+	final    bool
+	messages map[string]Usage
+}
+
+// observe folds line into the total, reporting whether it carried usage.
+func (m *usageMeter) observe(line []byte) bool {
+	if usage, cumulative, ok := ParseUsage(line); ok {
+		if cumulative {
+			m.total = usage
+			m.final = true
+		} else {
+			m.total = m.total.Add(usage)
+		}
+		return true
+	}
+	if m.final {
+		return false
+	}
+	id, usage, ok := parseClaudeMessageUsage(line)
+	if !ok {
+		return false
+	}
+	if id == "" {
+		m.total = m.total.Add(usage)
+		return true
+	}
+	if m.messages == nil {
+		m.messages = map[string]Usage{}
+	}
+	m.total = m.total.Add(usage.Sub(m.messages[id]))
+	m.messages[id] = usage
+	return true
+}
@@ -0,0 +1,295 @@
+package templates
+
+import (
+	"fmt"
+	"reflect"
+	"text/template"
+	"text/template/parse"
+)
+
+var dataTypes = map[string]reflect.Type{}
+
+// RegisterData records the struct the template name is rendered with, so
+// overrides of it are checked against the fields they will really get. The
+// packages that render built-in templates register them from init.
+func RegisterData(name string, data any) {
+	dataTypes[name] = reflect.TypeOf(data)
+}
+
+// NewData returns a pointer to a new value of the struct name is rendered
+// with, for decoding sample data into.
+func NewData(name string) (any, bool) {
+	dataType, ok := dataTypes[name]
+	if !ok {
+		return nil, false
+	}
+	return reflect.New(dataType).Interface(), true
+}
+
+// ValidateTemplate checks tmpl against the struct registered for its name.
+// Templates nobody registered are only parsed.
+func ValidateTemplate(tmpl Template) error {
+	return check(Engine{}.Funcs(), tmpl.Name, tmpl.Content, dataTypes[tmpl.Name])
+}
+
+// check parses content and walks every action in it, including both sides
+// of if, with and range, resolving each field against dataType. Values whose
+// type is only known at render time (map entries, interfaces, builtins like
+// index) are left to the strict renderer.
+func check(funcs template.FuncMap, name string, content string, dataType reflect.Type) error {
+	tmpl, err := template.New(name).Funcs(funcs).Parse(content)
+	if err != nil {
+		return err
+	}
+	c := checker{tmpl: tmpl, funcs: funcs, visiting: map[string]bool{}}
+	return c.template(tmpl, dataType)
+}
+
+type checker struct {
+	tmpl     *template.Template
+	funcs    template.FuncMap
+	tree     *parse.Tree
+	visiting map[string]bool
+}
+
+// scope maps variable names to their types; a nil type is unknown.
+type scope map[string]reflect.Type
+
+func (s scope) copy() scope {
+	copied := make(scope, len(s))
+	for name, t := range s {
+		copied[name] = t
+	}
+	return copied
+}
+
+func (c *checker) template(tmpl *template.Template, dot reflect.Type) error {
+	if tmpl.Tree == nil || c.visiting[tmpl.Name()] {
+		return nil
+	}
+	c.visiting[tmpl.Name()] = true
+	defer delete(c.visiting, tmpl.Name())
+
+	outer := c.tree
+	c.tree = tmpl.Tree
+	defer func() { c.tree = outer }()
+	return c.walk(tmpl.Tree.Root, dot, scope{"$": dot})
+}
+
+func (c *checker) walk(node parse.Node, dot reflect.Type, vars scope) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := c.walk(child, dot, vars); err != nil {
+				return err
+			}
+		}
+	case *parse.ActionNode:
+		_, err := c.pipe(n.Pipe, dot, vars)
+		return err
+	case *parse.IfNode:
+		inner := vars.copy()
+		if _, err := c.pipe(n.Pipe, dot, inner); err != nil {
+			return err
+		}
+		return c.branches(n.List, dot, n.ElseList, dot, inner)
+	case *parse.WithNode:
+		inner := vars.copy()
+		value, err := c.pipe(n.Pipe, dot, inner)
+		if err != nil {
+			return err
+		}
+		return c.branches(n.List, value, n.ElseList, dot, inner)
+	case *parse.RangeNode:
+		inner := vars.copy()
+		value, err := c.pipe(&parse.PipeNode{Cmds: n.Pipe.Cmds}, dot, inner)
+		if err != nil {
+			return err
+		}
+		key, elem := rangeTypes(value)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			inner[n.Pipe.Decl[0].Ident[0]] = key
+			inner[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		return c.branches(n.List, elem, n.ElseList, dot, inner)
+	case *parse.TemplateNode:
+		var value reflect.Type
+		if n.Pipe != nil {
+			var err error
+			if value, err = c.pipe(n.Pipe, dot, vars.copy()); err != nil {
+				return err
+			}
+		}
+		called := c.tmpl.Lookup(n.Name)
+		if called == nil {
+			return c.errorf(n, "no such template %q", n.Name)
+		}
+		return c.template(called, value)
+	}
+	return nil
+}
+
+func (c *checker) branches(list *parse.ListNode, listDot reflect.Type, elseList *parse.ListNode, elseDot reflect.Type, vars scope) error {
+	if err := c.walk(list, listDot, vars.copy()); err != nil {
+		return err
+	}
+	return c.walk(elseList, elseDot, vars.copy())
+}
+
+// pipe checks a pipeline, declares its variables in vars and returns the
+// type it produces.
+func (c *checker) pipe(pipe *parse.PipeNode, dot reflect.Type, vars scope) (reflect.Type, error) {
+	if pipe == nil {
+		return nil, nil
+	}
+	var value reflect.Type
+	for _, cmd := range pipe.Cmds {
+		for _, arg := range cmd.Args[1:] {
+			if _, err := c.arg(arg, dot, vars); err != nil {
+				return nil, err
+			}
+		}
+		var err error
+		if value, err = c.arg(cmd.Args[0], dot, vars); err != nil {
+			return nil, err
+		}
+	}
+	for _, variable := range pipe.Decl {
+		vars[variable.Ident[0]] = value
+	}
+	return value, nil
+}
+
+func (c *checker) arg(node parse.Node, dot reflect.Type, vars scope) (reflect.Type, error) {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot, nil
+	case *parse.FieldNode:
+		return c.fields(n, dot, n.Ident)
+	case *parse.VariableNode:
+		return c.fields(n, vars[n.Ident[0]], n.Ident[1:])
+	case *parse.ChainNode:
+		value, err := c.arg(n.Node, dot, vars)
+		if err != nil {
+			return nil, err
+		}
+		return c.fields(n, value, n.Field)
+	case *parse.PipeNode:
+		return c.pipe(n, dot, vars.copy())
+	case *parse.IdentifierNode:
+		return c.funcResult(n.Ident), nil
+	case *parse.StringNode:
+		return reflect.TypeFor[string](), nil
+	case *parse.BoolNode:
+		return reflect.TypeFor[bool](), nil
+	case *parse.NumberNode:
+		switch {
+		case n.IsInt:
+			return reflect.TypeFor[int](), nil
+		case n.IsFloat:
+			return reflect.TypeFor[float64](), nil
+		}
+	}
+	return nil, nil
+}
+
+// fields resolves a chain of field or method names starting from value.
+func (c *checker) fields(node parse.Node, value reflect.Type, names []string) (reflect.Type, error) {
+	for _, name := range names {
+		if value == nil {
+			return nil, nil
+		}
+		next, ok := fieldType(value, name)
+		if !ok {
+			return nil, c.errorf(node, "can't evaluate field %s in type %s", name, value)
+		}
+		value = next
+	}
+	return value, nil
+}
+
+func (c *checker) funcResult(name string) reflect.Type {
+	switch name {
+	case "not", "eq", "ne", "lt", "le", "gt", "ge":
+		return reflect.TypeFor[bool]()
+	case "len":
+		return reflect.TypeFor[int]()
+	case "print", "printf", "println", "html", "js", "urlquery":
+		return reflect.TypeFor[string]()
+	}
+	if fn, ok := c.funcs[name]; ok {
+		if fnType := reflect.TypeOf(fn); fnType.Kind() == reflect.Func && fnType.NumOut() > 0 {
+			return fnType.Out(0)
+		}
+	}
+	return nil
+}
+
+func (c *checker) errorf(node parse.Node, format string, args ...any) error {
+	location, context := c.tree.ErrorContext(node)
+	return fmt.Errorf("template: %s: checking %q at <%s>: %s", location, c.tree.Name, context, fmt.Sprintf(format, args...))
+}
+
+// fieldType returns the type of field or method name on value. A nil type
+// with ok set means the result is only known at render time.
+func fieldType(value reflect.Type, name string) (reflect.Type, bool) {
+	if value.Kind() == reflect.Interface {
+		return nil, true
+	}
+	methods := value
+	if value.Kind() != reflect.Pointer {
+		methods = reflect.PointerTo(value)
+	}
+	if method, ok := methods.MethodByName(name); ok {
+		if method.Type.NumOut() == 0 {
+			return nil, true
+		}
+		return method.Type.Out(0), true
+	}
+
+	base := value
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+	switch base.Kind() {
+	case reflect.Struct:
+		if field, ok := base.FieldByName(name); ok && field.IsExported() {
+			return field.Type, true
+		}
+	case reflect.Map:
+		if base.Key().Kind() == reflect.String {
+			return base.Elem(), true
+		}
+	case reflect.Interface:
+		return nil, true
+	}
+	return nil, false
+}
+
+// rangeTypes returns the key and element types of ranging over value.
+func rangeTypes(value reflect.Type) (reflect.Type, reflect.Type) {
+	if value == nil {
+		return nil, nil
+	}
+	for value.Kind() == reflect.Pointer {
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeFor[int](), value.Elem()
+	case reflect.Map:
+		return value.Key(), value.Elem()
+	case reflect.Chan:
+		return value.Elem(), value.Elem()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value, value
+	}
+	return nil, nil
+}
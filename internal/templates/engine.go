@@ -0,0 +1,79 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"text/template"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// Engine renders templates strictly: a field or map key the data doesn't
+// have is an error instead of "<no value>". Every template gets the helpers
+// in Funcs.
+type Engine struct {
+	Loader Loader
+	Root   string       // repository root readFile is confined to; empty disables it
+	Beads  beads.Client // backs bead and children; nil makes them fail
+	Now    func() time.Time
+}
+
+// NewEngine returns an engine for the camp at root: overrides come from its
+// .carnie/templates and bead lookups read its beads database or issues file.
+func NewEngine(root string) Engine {
+	engine := Engine{Loader: NewLoader(root), Root: root}
+	if root == "" {
+		return engine
+	}
+	if beadsRoot, err := beads.FindRoot(root); err == nil {
+		engine.Beads = beads.NewLocal(beadsRoot)
+	}
+	return engine
+}
+
+// Parse parses content as the template name with the helper functions.
+func (e Engine) Parse(name string, content string) (*template.Template, error) {
+	return template.New(name).Option("missingkey=error").Funcs(e.Funcs()).Parse(content)
+}
+
+// RenderString renders content with data.
+func (e Engine) RenderString(name string, content string, data any) (string, error) {
+	tmpl, err := e.Parse(name, content)
+	if err != nil {
+		return "", fmt.Errorf("parse %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("execute %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
+
+// Render loads name through the engine's loader and renders it with data.
+func (e Engine) Render(name string, data any) (string, error) {
+	tmpl, err := e.Loader.Resolve(name)
+	if err != nil {
+		return "", fmt.Errorf("load %s: %w", name, err)
+	}
+	source := name
+	if tmpl.Path != "" {
+		source = tmpl.Path
+	}
+	return e.RenderString(source, tmpl.Content, data)
+}
+
+// Validate parses content and checks every field it uses, in all branches,
+// against the type of data, the struct the template is normally rendered
+// with, so a misspelled field is caught before the template is used.
+func (e Engine) Validate(name string, content string, data any) error {
+	return check(e.Funcs(), name, content, reflect.TypeOf(data))
+}
+
+func (e Engine) now() time.Time {
+	if e.Now != nil {
+		return e.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,140 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+func TestEngineStrict(t *testing.T) {
+	data := struct{ Title string }{}
+	engine := Engine{}
+	if err := engine.Validate("t", "{{.Title}}", data); err != nil {
+		t.Fatalf("expected a valid template, got %v", err)
+	}
+	if err := engine.Validate("t", "{{.Titel}}", data); err == nil {
+		t.Fatal("expected an error for an unknown field")
+	}
+	if err := engine.Validate("t", "{{if .Title}}", data); err == nil {
+		t.Fatal("expected a parse error")
+	}
+	if _, err := engine.RenderString("t", "{{.missing}}", map[string]any{}); err == nil {
+		t.Fatal("expected an error for a missing map key")
+	}
+}
+
+func TestValidateChecksEveryBranch(t *testing.T) {
+	type item struct{ Name string }
+	data := struct {
+		Title string
+		Items []item
+		Index map[string]item
+		When  time.Time
+	}{}
+	engine := Engine{}
+
+	valid := []string{
+		`{{if .Title}}{{.Title}}{{else}}{{len .Items}}{{end}}`,
+		`{{range $i, $item := .Items}}{{$i}} {{$item.Name}} {{.Name}} {{$.Title}}{{end}}`,
+		`{{range .Index}}{{.Name}}{{end}}{{with .Index.anything}}{{.Name}}{{end}}`,
+		`{{.When.Format "2006"}} {{(bead "cn-1").Title}} {{define "x"}}{{.Name}}{{end}}{{range .Items}}{{template "x" .}}{{end}}`,
+	}
+	for _, tmpl := range valid {
+		if err := engine.Validate("t", tmpl, data); err != nil {
+			t.Errorf("%s: %v", tmpl, err)
+		}
+	}
+
+	invalid := []string{
+		`{{if .Title}}{{.Titel}}{{end}}`,
+		`{{if not .Title}}{{else}}{{.Titel}}{{end}}`,
+		`{{range .Items}}{{.Nmae}}{{end}}`,
+		`{{range $item := .Items}}{{$item.Nmae}}{{end}}`,
+		`{{with .Items}}{{else}}{{.Nmae}}{{end}}`,
+		`{{(bead "cn-1").Titel}}`,
+		`{{define "x"}}{{.Nmae}}{{end}}{{range .Items}}{{template "x" .}}{{end}}`,
+	}
+	for _, tmpl := range invalid {
+		if err := engine.Validate("t", tmpl, data); err == nil {
+			t.Errorf("%s: expected an unknown field error", tmpl)
+		}
+	}
+}
+
+func TestEngineFuncs(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "NOTES.md"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	engine := Engine{
+		Root: root,
+		Beads: beads.NewFake(
+			beads.Issue{ID: "cn-1", Title: "Auth", Status: beads.StatusOpen},
+			beads.Issue{ID: "cn-2", Title: "Login", Status: beads.StatusOpen, Dependencies: []beads.Dependency{
+				{IssueID: "cn-2", DependsOnID: "cn-1", Type: beads.DepParentChild},
+			}},
+		),
+		Now: func() time.Time { return time.Date(2026, 3, 4, 5, 6, 0, 0, time.UTC) },
+	}
+
+	tests := []struct {
+		tmpl string
+		want string
+	}{
+		{`{{indent 2 "a\n\nb"}}`, "  a\n\n  b"},
+		{`{{truncate 5 "abcdefgh"}}`, "abcd…"},
+		{`{{truncate 10 "short"}}`, "short"},
+		{`{{wrap 10 "one two three four\n- keep this list item"}}`, "one two\nthree four\n- keep this list item"},
+		{`{{mdEscape "*a* [b] #1"}}`, `\*a\* \[b\] \#1`},
+		{`{{date "date" now}}`, "2026-03-04"},
+		{`{{date "15:04" "2026-01-02T10:30:00Z"}}`, "10:30"},
+		{`{{(bead "cn-1").Title}}`, "Auth"},
+		{`{{with bead ""}}x{{else}}none{{end}}`, "none"},
+		{`{{range children "cn-1"}}{{.ID}} {{.Title}}{{end}}`, "cn-2 Login"},
+		{`{{readFile "NOTES.md"}}`, "notes"},
+	}
+	for _, tt := range tests {
+		got, err := engine.RenderString("t", tt.tmpl, nil)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.tmpl, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.tmpl, got, tt.want)
+		}
+	}
+
+	for _, tmpl := range []string{`{{bead "cn-9"}}`, `{{readFile "../secret"}}`, `{{readFile "/etc/passwd"}}`, `{{readFile "missing.md"}}`} {
+		if _, err := engine.RenderString("t", tmpl, nil); err == nil {
+			t.Errorf("%s: expected an error", tmpl)
+		}
+	}
+	if _, err := (Engine{}).RenderString("t", `{{bead "cn-1"}}`, nil); err == nil || !strings.Contains(err.Error(), "no beads found") {
+		t.Errorf("expected bead to fail without a beads client, got %v", err)
+	}
+}
+
+func TestReadFileRefusesSymlinkEscape(t *testing.T) {
+	outside := t.TempDir()
+	if err := os.WriteFile(filepath.Join(outside, "secret"), []byte("secret"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	root := t.TempDir()
+	if err := os.Symlink(filepath.Join(outside, "secret"), filepath.Join(root, "link.md")); err != nil {
+		t.Skipf("symlinks unavailable: %v", err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "docs")); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := Engine{Root: root}
+	for _, tmpl := range []string{`{{readFile "link.md"}}`, `{{readFile "docs/secret"}}`} {
+		out, err := engine.RenderString("t", tmpl, nil)
+		if err == nil {
+			t.Errorf("%s: expected the symlink escape to be refused, got %q", tmpl, out)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package templates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+
+	"github.com/rikurb8/carnie/internal/beads"
+)
+
+// maxReadFile caps what readFile pulls into a prompt.
+const maxReadFile = 64 * 1024
+
+// Funcs returns the helpers available to every template:
+//
+//	indent N TEXT       prefix each non-empty line with N spaces
+//	truncate N TEXT     cut to N characters, ending in "…"
+//	wrap N TEXT         re-flow paragraphs to N columns
+//	mdEscape TEXT       escape markdown so TEXT renders literally
+//	date LAYOUT TIME    format a time with a Go layout or date, datetime, rfc3339
+//	now                 the current time
+//	bead ID             the bead with ID, or nil for an empty ID
+//	children ID         the beads whose parent is ID
+//	readFile PATH       a file under the repository root
+func (e Engine) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"indent":   indent,
+		"truncate": truncate,
+		"wrap":     wrap,
+		"mdEscape": mdEscape,
+		"date":     formatDate,
+		"now":      e.now,
+		"bead":     e.bead,
+		"children": e.children,
+		"readFile": e.readFile,
+	}
+}
+
+func indent(spaces int, text string) string {
+	pad := strings.Repeat(" ", spaces)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func truncate(limit int, text string) string {
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return strings.TrimRight(string(runes[:limit-1]), " ") + "…"
+}
+
+// wrap re-flows each paragraph to width columns. Lines that look like list
+// items, headings or code are kept as they are.
+func wrap(width int, text string) string {
+	if width <= 0 {
+		return text
+	}
+	var out []string
+	var words []string
+	flush := func() {
+		line := ""
+		for _, word := range words {
+			if line != "" && utf8.RuneCountInString(line)+1+utf8.RuneCountInString(word) > width {
+				out = append(out, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		if line != "" {
+			out = append(out, line)
+		}
+		words = nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			flush()
+			out = append(out, "")
+		case strings.HasPrefix(line, "    "), strings.HasPrefix(line, "\t"),
+			strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, "```"),
+			strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+			flush()
+			out = append(out, line)
+		default:
+			words = append(words, strings.Fields(line)...)
+		}
+	}
+	flush()
+	return strings.Join(out, "\n")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `#`, `\#`, `|`, `\|`, `!`, `\!`, `~`, `\~`,
+)
+
+func mdEscape(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+var dateLayouts = map[string]string{
+	"date":     "2006-01-02",
+	"datetime": "2006-01-02 15:04",
+	"rfc3339":  time.RFC3339,
+}
+
+// formatDate accepts a time.Time, a *time.Time or an RFC 3339 string. A nil
+// or zero time formats as "".
+func formatDate(layout string, value any) (string, error) {
+	if named, ok := dateLayouts[layout]; ok {
+		layout = named
+	}
+	var t time.Time
+	switch v := value.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v != nil {
+			t = *v
+		}
+	case string:
+		if v == "" {
+			return "", nil
+		}
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return "", fmt.Errorf("date: %w", err)
+		}
+		t = parsed
+	default:
+		return "", fmt.Errorf("date: cannot format %T", value)
+	}
+	if t.IsZero() {
+		return "", nil
+	}
+	return t.Format(layout), nil
+}
+
+func (e Engine) bead(id string) (*beads.Issue, error) {
+	if id == "" {
+		return nil, nil
+	}
+	if e.Beads == nil {
+		return nil, fmt.Errorf("bead %s: no beads found", id)
+	}
+	issue, err := e.Beads.Show(context.Background(), id)
+	if err != nil {
+		return nil, fmt.Errorf("bead %s: %w", id, err)
+	}
+	return &issue, nil
+}
+
+func (e Engine) children(id string) ([]beads.Issue, error) {
+	if id == "" {
+		return nil, nil
+	}
+	if e.Beads == nil {
+		return nil, fmt.Errorf("children of %s: no beads found", id)
+	}
+	issues, err := e.Beads.List(context.Background(), beads.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("children of %s: %w", id, err)
+	}
+	graph := beads.NewGraph(issues)
+	children := make([]beads.Issue, 0, len(graph.Children[id]))
+	for _, childID := range graph.Children[id] {
+		children = append(children, graph.ByID[childID])
+	}
+	return children, nil
+}
+
+// readFile returns a file under the repository root. The file is opened
+// through an os.Root, so paths and symlinks that leave the root are refused,
+// and large files are cut to maxReadFile bytes.
+func (e Engine) readFile(path string) (string, error) {
+	if e.Root == "" {
+		return "", fmt.Errorf("readFile %s: no repository root", path)
+	}
+	if filepath.IsAbs(path) {
+		return "", fmt.Errorf("readFile %s: path must be relative to the repository root", path)
+	}
+	root, err := os.OpenRoot(e.Root)
+	if err != nil {
+		return "", fmt.Errorf("readFile %s: %w", path, err)
+	}
+	defer root.Close()
+
+	file, err := root.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("readFile %s: no such file", path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("readFile %s: %w", path, err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxReadFile))
+	if err != nil {
+		return "", fmt.Errorf("readFile %s: %w", path, err)
+	}
+	return string(data), nil
+}
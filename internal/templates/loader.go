@@ -0,0 +1,160 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// OverrideDir holds a camp's template overrides, relative to the camp root.
+const OverrideDir = ".carnie/templates"
+
+// BaseDir sits next to ejected overrides and keeps the built-in version each
+// was copied from, so a later carnie can show what changed upstream since.
+const BaseDir = ".base"
+
+// Source says which layer a template was loaded from.
+type Source string
+
+const (
+	SourceCamp    Source = "camp"
+	SourceUser    Source = "user"
+	SourceBuiltIn Source = "built-in"
+)
+
+// Template is a template resolved through the loader's layers.
+type Template struct {
+	Name    string
+	Source  Source
+	Path    string // file the override was read from, empty for built-ins
+	Content string
+}
+
+// Loader resolves templates from the camp's .carnie/templates, then the
+// user's config directory, then the embedded defaults.
+type Loader struct {
+	CampDir string // empty to skip the camp layer
+	UserDir string // empty to skip the user layer
+}
+
+// NewLoader returns a loader for the camp at root. An empty root skips the
+// camp layer.
+func NewLoader(root string) Loader {
+	var loader Loader
+	if root != "" {
+		loader.CampDir = filepath.Join(root, OverrideDir)
+	}
+	loader.UserDir, _ = UserDir()
+	return loader
+}
+
+// UserDir returns the directory for user-wide overrides, usually
+// ~/.config/carnie/templates.
+func UserDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "carnie", "templates"), nil
+}
+
+// Names lists the embedded templates that can be overridden.
+func Names() []string {
+	entries, _ := fs.ReadDir(FS, ".")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Resolve returns the highest-priority version of name. An override that
+// fails ValidateTemplate is an error rather than a broken prompt.
+func (l Loader) Resolve(name string) (Template, error) {
+	layers, err := l.Layers(name)
+	if err != nil {
+		return Template{}, err
+	}
+	tmpl := layers[0]
+	if tmpl.Source != SourceBuiltIn {
+		if err := ValidateTemplate(tmpl); err != nil {
+			return Template{}, fmt.Errorf("template override %s: %w", tmpl.Path, err)
+		}
+	}
+	return tmpl, nil
+}
+
+// Load returns the content of the highest-priority version of name.
+func (l Loader) Load(name string) (string, error) {
+	tmpl, err := l.Resolve(name)
+	return tmpl.Content, err
+}
+
+// Layers returns every version of name that exists, highest priority first.
+// The built-in version is always last.
+func (l Loader) Layers(name string) ([]Template, error) {
+	builtIn, err := Load(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown template %q", name)
+	}
+
+	var layers []Template
+	for _, layer := range []struct {
+		dir    string
+		source Source
+	}{{l.CampDir, SourceCamp}, {l.UserDir, SourceUser}} {
+		if layer.dir == "" {
+			continue
+		}
+		path := filepath.Join(layer.dir, name)
+		data, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read template override: %w", err)
+		}
+		layers = append(layers, Template{Name: name, Source: layer.source, Path: path, Content: string(data)})
+	}
+	return append(layers, Template{Name: name, Source: SourceBuiltIn, Content: builtIn}), nil
+}
+
+// Eject copies the built-in name into dir for editing and records the copy
+// under BaseDir. An existing override is only replaced when force is set.
+func Eject(dir string, name string, force bool) (string, error) {
+	content, err := Load(name)
+	if err != nil {
+		return "", fmt.Errorf("unknown template %q", name)
+	}
+	path := filepath.Join(dir, name)
+	if _, err := os.Stat(path); err == nil && !force {
+		return "", fmt.Errorf("%s already exists; use --force to overwrite it", path)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, BaseDir), 0755); err != nil {
+		return "", fmt.Errorf("create template directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("write template: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, BaseDir, name), []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("record template base: %w", err)
+	}
+	return path, nil
+}
+
+// Base returns the built-in version an override was ejected from. ok is
+// false for built-ins and for overrides written by hand.
+func Base(tmpl Template) (string, bool) {
+	if tmpl.Path == "" {
+		return "", false
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(tmpl.Path), BaseDir, tmpl.Name))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
@@ -0,0 +1,80 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoaderLayers(t *testing.T) {
+	campDir := t.TempDir()
+	userDir := t.TempDir()
+	loader := Loader{CampDir: campDir, UserDir: userDir}
+
+	tmpl, err := loader.Resolve("workorder.md.tmpl")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if tmpl.Source != SourceBuiltIn || tmpl.Path != "" {
+		t.Fatalf("expected the built-in template, got %s %q", tmpl.Source, tmpl.Path)
+	}
+
+	if err := os.WriteFile(filepath.Join(userDir, "workorder.md.tmpl"), []byte("user"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := loader.Load("workorder.md.tmpl"); content != "user" {
+		t.Fatalf("expected the user override, got %q", content)
+	}
+
+	if err := os.WriteFile(filepath.Join(campDir, "workorder.md.tmpl"), []byte("camp"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	layers, err := loader.Layers("workorder.md.tmpl")
+	if err != nil {
+		t.Fatalf("layers: %v", err)
+	}
+	if len(layers) != 3 || layers[0].Source != SourceCamp || layers[0].Content != "camp" || layers[1].Source != SourceUser || layers[2].Source != SourceBuiltIn {
+		t.Fatalf("unexpected layers %+v", layers)
+	}
+
+	if _, err := loader.Resolve("nope.md"); err == nil {
+		t.Fatal("expected an error for an unknown template")
+	}
+}
+
+func TestResolveValidatesOverrides(t *testing.T) {
+	RegisterData("workorder.md.tmpl", struct{ Title string }{})
+	t.Cleanup(func() { delete(dataTypes, "workorder.md.tmpl") })
+	campDir := t.TempDir()
+	loader := Loader{CampDir: campDir}
+
+	if err := os.WriteFile(filepath.Join(campDir, "workorder.md.tmpl"), []byte("{{if .Title}}{{.Titel}}{{end}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loader.Resolve("workorder.md.tmpl"); err == nil || !strings.Contains(err.Error(), "Titel") {
+		t.Fatalf("expected the override to fail validation, got %v", err)
+	}
+	if layers, err := loader.Layers("workorder.md.tmpl"); err != nil || layers[0].Source != SourceCamp {
+		t.Fatalf("expected Layers to return the override as-is, got %v", err)
+	}
+}
+
+func TestEjectRecordsBase(t *testing.T) {
+	dir := t.TempDir()
+	path, err := Eject(dir, "workorder.md.tmpl", false)
+	if err != nil {
+		t.Fatalf("eject: %v", err)
+	}
+	builtIn, _ := Load("workorder.md.tmpl")
+	tmpl := Template{Name: "workorder.md.tmpl", Source: SourceCamp, Path: path}
+	if base, ok := Base(tmpl); !ok || base != builtIn {
+		t.Fatalf("expected the built-in to be recorded as the base")
+	}
+	if _, err := Eject(dir, "workorder.md.tmpl", false); err == nil {
+		t.Fatal("expected eject to refuse to overwrite")
+	}
+	if _, ok := Base(Template{Name: "workorder.md.tmpl", Source: SourceBuiltIn}); ok {
+		t.Fatal("expected no base for a built-in")
+	}
+}
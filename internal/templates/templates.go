@@ -2,7 +2,7 @@ package templates
 
 import "embed"
 
-//go:embed operator.md carnie.md issue-to-beads.md.tmpl workorder.md.tmpl
+//go:embed operator.md carnie.md issue-to-beads.md.tmpl workorder.md.tmpl workorder-resume.md.tmpl
 var FS embed.FS
 
 // Load reads an embedded template file by name.
@@ -1,81 +1,45 @@
 package workorder
 
 import (
-	"bufio"
-	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
-)
-
-type BeadInfo struct {
-	ID          string
-	Title       string
-	Description string
-}
+	"context"
 
-type beadIssue struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-}
+	"github.com/rikurb8/carnie/internal/beads"
+)
 
-func LoadBeadIndex(startDir string) (map[string]BeadInfo, error) {
-	root, err := findBeadsRoot(startDir)
-	if err != nil {
-		return nil, err
-	}
-	issues, err := loadBeadIssues(root)
+// LoadBeadIndex returns the beads known to client keyed by ID, for looking up
+// the bead a work order is linked to.
+func LoadBeadIndex(ctx context.Context, client beads.Client) (map[string]beads.Issue, error) {
+	issues, err := client.List(ctx, beads.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
-	index := make(map[string]BeadInfo, len(issues))
+	index := make(map[string]beads.Issue, len(issues))
 	for _, issue := range issues {
-		index[issue.ID] = BeadInfo{ID: issue.ID, Title: issue.Title, Description: issue.Description}
+		index[issue.ID] = issue
 	}
 	return index, nil
 }
 
-func findBeadsRoot(startDir string) (string, error) {
-	dir := startDir
-	for {
-		beadsPath := filepath.Join(dir, ".beads")
-		if info, err := os.Stat(beadsPath); err == nil && info.IsDir() {
-			return dir, nil
-		}
-		parent := filepath.Dir(dir)
-		if parent == dir {
-			return "", fmt.Errorf("no .beads directory found")
-		}
-		dir = parent
-	}
+// BeadDetail is a bead with its relations and the work orders linked to it.
+type BeadDetail struct {
+	beads.Detail
+	WorkOrders []WorkOrder `json:"work_orders"`
 }
 
-func loadBeadIssues(root string) ([]beadIssue, error) {
-	path := filepath.Join(root, ".beads", "issues.jsonl")
-	file, err := os.Open(path)
+// LoadBeadDetail returns the bead called id with its relations and, when
+// store is not nil, its work orders.
+func LoadBeadDetail(ctx context.Context, client beads.Client, store *Store, id string) (BeadDetail, error) {
+	detail, err := beads.LoadDetail(ctx, client, id)
 	if err != nil {
-		return nil, fmt.Errorf("open beads issues: %w", err)
+		return BeadDetail{}, err
 	}
-	defer file.Close()
-
-	var issues []beadIssue
-	scanner := bufio.NewScanner(file)
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
-		if line == "" {
-			continue
-		}
-		var issue beadIssue
-		if err := json.Unmarshal([]byte(line), &issue); err != nil {
-			return nil, fmt.Errorf("parse bead issue at line %d: %w", lineNum, err)
+	result := BeadDetail{Detail: detail, WorkOrders: []WorkOrder{}}
+	if store != nil {
+		orders, err := store.List(ctx, ListOptions{BeadID: id})
+		if err != nil {
+			return BeadDetail{}, err
 		}
-		issues = append(issues, issue)
-	}
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("read beads issues: %w", err)
+		result.WorkOrders = append(result.WorkOrders, orders...)
 	}
-	return issues, nil
+	return result, nil
 }
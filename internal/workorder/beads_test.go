@@ -1,24 +1,19 @@
 package workorder
 
 import (
-	"os"
-	"path/filepath"
+	"context"
 	"testing"
+
+	"github.com/rikurb8/carnie/internal/beads"
 )
 
 func TestLoadBeadIndex(t *testing.T) {
-	dir := t.TempDir()
-	beadsDir := filepath.Join(dir, ".beads")
-	if err := os.MkdirAll(beadsDir, 0755); err != nil {
-		t.Fatalf("create beads dir: %v", err)
-	}
+	client := beads.NewFake(
+		beads.Issue{ID: "cn-ta1.2", Title: "Add WorkOrder domain", Description: "Define status transitions", Status: beads.StatusOpen},
+		beads.Issue{ID: "cn-gone", Title: "Deleted", Status: beads.StatusTombstone},
+	)
 
-	data := []byte("{\"id\":\"cn-ta1.2\",\"title\":\"Add WorkOrder domain\",\"description\":\"Define status transitions\"}\n")
-	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), data, 0644); err != nil {
-		t.Fatalf("write issues file: %v", err)
-	}
-
-	index, err := LoadBeadIndex(dir)
+	index, err := LoadBeadIndex(context.Background(), client)
 	if err != nil {
 		t.Fatalf("load bead index: %v", err)
 	}
@@ -30,4 +25,7 @@ func TestLoadBeadIndex(t *testing.T) {
 	if info.Description == "" {
 		t.Fatal("expected bead description to be populated")
 	}
+	if _, ok := index["cn-gone"]; ok {
+		t.Fatal("expected tombstones to be skipped")
+	}
 }
@@ -0,0 +1,344 @@
+package workorder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+// DefaultLeaseDuration is how long a claim stays valid without a heartbeat.
+const DefaultLeaseDuration = 15 * time.Minute
+
+var (
+	// ErrClaimed is returned when another agent holds a live claim on the order.
+	ErrClaimed = errors.New("work order is claimed")
+	// ErrNotClaimant is returned when an agent acts on a claim it does not hold.
+	ErrNotClaimant = errors.New("work order is not claimed by this agent")
+	// ErrCrewAtCapacity is returned when a claim would give the order's crew
+	// member more work orders in progress than its concurrency allows.
+	ErrCrewAtCapacity = errors.New("crew member at capacity")
+)
+
+// Claim assigns the work order to agent and starts its lease. A ready order
+// moves to in_progress. The claim succeeds only if the order is unclaimed,
+// already held by agent, or its previous lease has expired; the check and the
+// update happen in a single conditional UPDATE so concurrent claims cannot
+// both win.
+func (s *Store) Claim(ctx context.Context, id int64, agent string, lease time.Duration) (WorkOrder, error) {
+	return s.ClaimWithin(ctx, id, agent, lease, 0)
+}
+
+// ClaimWithin claims like Claim, and when crewLimit is positive also refuses
+// with ErrCrewAtCapacity if taking a ready order would put more than
+// crewLimit of its crew member's orders in progress. The load is counted in
+// the same UPDATE that takes the claim.
+func (s *Store) ClaimWithin(ctx context.Context, id int64, agent string, lease time.Duration, crewLimit int) (WorkOrder, error) {
+	if agent == "" {
+		return WorkOrder{}, fmt.Errorf("agent is required")
+	}
+	if lease <= 0 {
+		lease = DefaultLeaseDuration
+	}
+
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return WorkOrder{}, err
+	}
+	if current.Status != StatusReady && current.Status != StatusInProgress {
+		return WorkOrder{}, fmt.Errorf("cannot claim %s work order %d", current.Status, id)
+	}
+
+	now := time.Now().UTC()
+	// Only a live lease protects a claim; an assignee left over from
+	// finished or manually moved work does not.
+	if current.Assignee != "" && current.Assignee != agent && current.LeaseUntil != nil && !current.LeaseExpired(now) {
+		return WorkOrder{}, fmt.Errorf("%w by %s until %s", ErrClaimed, current.Assignee, current.LeaseUntil.Format(time.RFC3339))
+	}
+
+	updated := current
+	if current.Status == StatusReady {
+		updated, err = Transition(current, StatusInProgress, now)
+		if err != nil {
+			return WorkOrder{}, err
+		}
+	}
+	leaseUntil := now.Add(lease)
+	updated.Assignee = agent
+	updated.LeaseUntil = &leaseUntil
+	updated.UpdatedAt = now
+
+	var conditions []sqlite.BoolExpression
+	limited := crewLimit > 0 && current.Crew != "" && current.Status == StatusReady
+	if limited {
+		conditions = append(conditions, crewLoad(current.Crew).LT(sqlite.Int(int64(crewLimit))))
+	}
+	if err := s.swap(ctx, current, updated, conditions...); err != nil {
+		if limited && errors.Is(err, ErrClaimed) {
+			if load, loadErr := s.CrewLoad(ctx); loadErr == nil && load[current.Crew] >= crewLimit {
+				return WorkOrder{}, fmt.Errorf("%w: %s has %d of %d work orders in progress", ErrCrewAtCapacity, current.Crew, load[current.Crew], crewLimit)
+			}
+		}
+		return WorkOrder{}, err
+	}
+
+	detail := fmt.Sprintf("lease until %s", leaseUntil.Format(time.RFC3339))
+	if current.Assignee != "" && current.Assignee != agent {
+		detail = fmt.Sprintf("took over from %s; %s", current.Assignee, detail)
+	}
+	if _, err := s.AddEvent(ctx, EventInput{WorkOrderID: id, Kind: EventClaimed, Actor: agent, Detail: detail}); err != nil {
+		return WorkOrder{}, err
+	}
+
+	return updated, nil
+}
+
+// Reblock moves an order that was unblocked for a claim back to blocked when
+// the claim failed. It leaves the order alone if it is no longer ready, so a
+// claim that won the race in the meantime is kept.
+func (s *Store) Reblock(ctx context.Context, id int64, actor string) error {
+	current, err := s.Get(ctx, id)
+	if err != nil || current.Status != StatusReady {
+		return err
+	}
+	if _, err := s.UpdateStatusBy(ctx, id, StatusBlocked, actor); err != nil {
+		return fmt.Errorf("block work order %d again: %w", id, err)
+	}
+	return nil
+}
+
+// Move changes the order's status as actor under the claim rules. Moving to
+// in_progress claims the order, unblocking it first and blocking it again if
+// the claim fails; moving a claimed in_progress order back to ready releases
+// the claim; other moves go through UpdateStatusBy. An order another agent
+// holds under a live lease is refused with ErrClaimed unless force is set, in
+// which case its claim is released or taken over.
+func (s *Store) Move(ctx context.Context, id int64, next Status, actor string, force bool) (WorkOrder, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return WorkOrder{}, err
+	}
+	if !CanTransition(current.Status, next) {
+		return WorkOrder{}, fmt.Errorf("cannot transition from %q to %q", current.Status, next)
+	}
+	if current.Assignee != "" && current.Assignee != actor && current.LeaseUntil != nil && !current.LeaseExpired(time.Now()) && !force {
+		return WorkOrder{}, fmt.Errorf("%w by %s until %s", ErrClaimed, current.Assignee, current.LeaseUntil.Format(time.RFC3339))
+	}
+
+	switch {
+	case next == StatusInProgress:
+		blocked := current.Status == StatusBlocked
+		if blocked {
+			if _, err := s.UpdateStatusBy(ctx, id, StatusReady, actor); err != nil {
+				return WorkOrder{}, err
+			}
+		}
+		claimed, err := s.Claim(ctx, id, actor, 0)
+		if errors.Is(err, ErrClaimed) && force {
+			if _, err = s.Release(ctx, id, actor, true); err == nil {
+				claimed, err = s.Claim(ctx, id, actor, 0)
+			}
+		}
+		if err != nil && blocked {
+			err = errors.Join(err, s.Reblock(ctx, id, actor))
+		}
+		return claimed, err
+	case next == StatusReady && current.Status == StatusInProgress && current.Assignee != "":
+		return s.Release(ctx, id, actor, true)
+	default:
+		return s.UpdateStatusBy(ctx, id, next, actor)
+	}
+}
+
+// Release drops agent's claim. An in_progress order returns to ready so it
+// can be picked up again. force releases a claim held by another agent.
+func (s *Store) Release(ctx context.Context, id int64, agent string, force bool) (WorkOrder, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return WorkOrder{}, err
+	}
+	if current.Assignee == "" {
+		return WorkOrder{}, fmt.Errorf("work order %d is not claimed", id)
+	}
+	if current.Assignee != agent && !force {
+		return WorkOrder{}, fmt.Errorf("%w: held by %s", ErrNotClaimant, current.Assignee)
+	}
+
+	now := time.Now().UTC()
+	updated := current
+	if current.Status == StatusInProgress {
+		updated, err = Transition(current, StatusReady, now)
+		if err != nil {
+			return WorkOrder{}, err
+		}
+	}
+	updated.Assignee = ""
+	updated.LeaseUntil = nil
+	updated.UpdatedAt = now
+
+	if err := s.swap(ctx, current, updated); err != nil {
+		return WorkOrder{}, err
+	}
+
+	detail := ""
+	if current.Assignee != agent {
+		detail = fmt.Sprintf("forced release of claim held by %s", current.Assignee)
+	}
+	if _, err := s.AddEvent(ctx, EventInput{WorkOrderID: id, Kind: EventReleased, Actor: agent, Detail: detail}); err != nil {
+		return WorkOrder{}, err
+	}
+
+	return updated, nil
+}
+
+// Heartbeat extends agent's lease on the work order.
+func (s *Store) Heartbeat(ctx context.Context, id int64, agent string, lease time.Duration) (WorkOrder, error) {
+	if lease <= 0 {
+		lease = DefaultLeaseDuration
+	}
+
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return WorkOrder{}, err
+	}
+	if current.Assignee != agent {
+		if current.Assignee == "" {
+			return WorkOrder{}, fmt.Errorf("%w: work order %d is not claimed", ErrNotClaimant, id)
+		}
+		return WorkOrder{}, fmt.Errorf("%w: held by %s", ErrNotClaimant, current.Assignee)
+	}
+
+	now := time.Now().UTC()
+	leaseUntil := now.Add(lease)
+
+	stmt := workOrders.UPDATE(
+		woLeaseUntil,
+		woUpdatedAt,
+	).SET(
+		formatTime(leaseUntil),
+		formatTime(now),
+	).WHERE(
+		woID.EQ(sqlite.Int64(id)).
+			AND(woAssignee.EQ(sqlite.String(agent))),
+	)
+
+	result, err := stmt.ExecContext(ctx, s.db)
+	if err != nil {
+		return WorkOrder{}, fmt.Errorf("heartbeat work order: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err != nil {
+		return WorkOrder{}, fmt.Errorf("heartbeat work order: %w", err)
+	} else if affected == 0 {
+		return WorkOrder{}, fmt.Errorf("%w: claim on work order %d was lost", ErrNotClaimant, id)
+	}
+
+	current.LeaseUntil = &leaseUntil
+	current.UpdatedAt = now
+	return current, nil
+}
+
+// ExpireLeases returns in_progress orders whose lease ran out before now to
+// ready, clearing the claim and logging a lease_expired event for each.
+func (s *Store) ExpireLeases(ctx context.Context, now time.Time) ([]WorkOrder, error) {
+	status := StatusInProgress
+	orders, err := s.List(ctx, ListOptions{Status: &status})
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []WorkOrder
+	for _, current := range orders {
+		if !current.LeaseExpired(now) {
+			continue
+		}
+
+		updated, err := Transition(current, StatusReady, now.UTC())
+		if err != nil {
+			return expired, err
+		}
+		updated.Assignee = ""
+		updated.LeaseUntil = nil
+
+		if err := s.swap(ctx, current, updated); err != nil {
+			if errors.Is(err, ErrClaimed) {
+				// Someone heartbeated or re-claimed it meanwhile.
+				continue
+			}
+			return expired, err
+		}
+
+		detail := fmt.Sprintf("lease held by %s expired at %s", current.Assignee, current.LeaseUntil.Format(time.RFC3339))
+		if _, err := s.AddEvent(ctx, EventInput{WorkOrderID: current.ID, Kind: EventLeaseExpired, Detail: detail}); err != nil {
+			return expired, err
+		}
+		expired = append(expired, updated)
+	}
+
+	return expired, nil
+}
+
+// crewLoad counts crew's in-progress orders inside an UPDATE.
+func crewLoad(crew string) sqlite.IntegerExpression {
+	return sqlite.RawInt(
+		"(SELECT COUNT(*) FROM "+workOrdersTable+" WHERE crew = #crew AND status = #status)",
+		sqlite.RawArgs{"#crew": crew, "#status": string(StatusInProgress)},
+	)
+}
+
+// swap writes updated only if the row still has the status and claim observed
+// in current, making claim changes a single compare-and-swap. Extra
+// conditions must also hold for the write to happen.
+func (s *Store) swap(ctx context.Context, current WorkOrder, updated WorkOrder, extra ...sqlite.BoolExpression) error {
+	condition := woID.EQ(sqlite.Int64(current.ID)).
+		AND(woStatus.EQ(sqlite.String(string(current.Status))))
+	if current.Assignee == "" {
+		condition = condition.AND(woAssignee.IS_NULL())
+	} else {
+		condition = condition.AND(woAssignee.EQ(sqlite.String(current.Assignee)))
+	}
+	if current.LeaseUntil == nil {
+		condition = condition.AND(woLeaseUntil.IS_NULL())
+	} else {
+		// Leases written before timeLayout was fixed-width are stored in
+		// RFC3339Nano; match either form so they can still be swapped.
+		leaseUntil := current.LeaseUntil.UTC()
+		condition = condition.AND(woLeaseUntil.IN(
+			sqlite.String(formatTime(leaseUntil)),
+			sqlite.String(leaseUntil.Format(time.RFC3339Nano)),
+		))
+	}
+	for _, expr := range extra {
+		condition = condition.AND(expr)
+	}
+
+	stmt := workOrders.UPDATE(
+		woStatus,
+		woUpdatedAt,
+		woStartedAt,
+		woCompletedAt,
+		woAssignee,
+		woLeaseUntil,
+	).SET(
+		string(updated.Status),
+		formatTime(updated.UpdatedAt),
+		nullableTime(updated.StartedAt),
+		nullableTime(updated.CompletedAt),
+		nullString(updated.Assignee),
+		nullableTime(updated.LeaseUntil),
+	).WHERE(condition)
+
+	result, err := stmt.ExecContext(ctx, s.db)
+	if err != nil {
+		return fmt.Errorf("update work order claim: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("update work order claim: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("%w: work order %d changed concurrently", ErrClaimed, current.ID)
+	}
+	return nil
+}
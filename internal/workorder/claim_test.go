@@ -0,0 +1,194 @@
+package workorder
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStoreClaimIsExclusive(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "workorders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	order, err := store.Create(ctx, CreateInput{Title: "Claim me", Description: "Work", Status: StatusReady})
+	if err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	claimed, err := store.Claim(ctx, order.ID, "alice", time.Minute)
+	if err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+	if claimed.Status != StatusInProgress || claimed.Assignee != "alice" {
+		t.Fatalf("expected in_progress claimed by alice, got %s/%q", claimed.Status, claimed.Assignee)
+	}
+
+	if _, err := store.Claim(ctx, order.ID, "bob", time.Minute); !errors.Is(err, ErrClaimed) {
+		t.Fatalf("expected ErrClaimed for second agent, got %v", err)
+	}
+	if _, err := store.Heartbeat(ctx, order.ID, "bob", time.Minute); !errors.Is(err, ErrNotClaimant) {
+		t.Fatalf("expected ErrNotClaimant for heartbeat by bob, got %v", err)
+	}
+	if _, err := store.Heartbeat(ctx, order.ID, "alice", time.Minute); err != nil {
+		t.Fatalf("heartbeat: %v", err)
+	}
+
+	released, err := store.Release(ctx, order.ID, "alice", false)
+	if err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if released.Status != StatusReady || released.Assignee != "" {
+		t.Fatalf("expected unclaimed ready order, got %s/%q", released.Status, released.Assignee)
+	}
+
+	mine, err := store.List(ctx, ListOptions{Assignee: "alice"})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(mine) != 0 {
+		t.Fatalf("expected no orders assigned to alice, got %d", len(mine))
+	}
+
+	if _, err := store.Claim(ctx, order.ID, "bob", time.Minute); err != nil {
+		t.Fatalf("claim after release: %v", err)
+	}
+
+	history, err := store.ListEvents(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	kinds := make([]EventKind, 0, len(history))
+	for _, event := range history {
+		kinds = append(kinds, event.Kind)
+	}
+	expected := []EventKind{EventClaimed, EventReleased, EventClaimed}
+	if len(kinds) != len(expected) {
+		t.Fatalf("expected events %v, got %v", expected, kinds)
+	}
+	for i := range expected {
+		if kinds[i] != expected[i] {
+			t.Fatalf("expected events %v, got %v", expected, kinds)
+		}
+	}
+}
+
+func TestStoreExpireLeases(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "workorders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	order, err := store.Create(ctx, CreateInput{Title: "Stale", Description: "Work", Status: StatusReady})
+	if err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+	if _, err := store.Claim(ctx, order.ID, "alice", time.Minute); err != nil {
+		t.Fatalf("claim: %v", err)
+	}
+
+	expired, err := store.ExpireLeases(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("expire leases: %v", err)
+	}
+	if len(expired) != 0 {
+		t.Fatalf("expected live lease to survive, got %d expired", len(expired))
+	}
+
+	expired, err = store.ExpireLeases(ctx, time.Now().Add(2*time.Minute))
+	if err != nil {
+		t.Fatalf("expire leases: %v", err)
+	}
+	if len(expired) != 1 {
+		t.Fatalf("expected 1 expired order, got %d", len(expired))
+	}
+
+	current, err := store.Get(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if current.Status != StatusReady || current.Assignee != "" || current.LeaseUntil != nil {
+		t.Fatalf("expected unclaimed ready order, got %+v", current)
+	}
+
+	history, err := store.ListEvents(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("list events: %v", err)
+	}
+	if last := history[len(history)-1]; last.Kind != EventLeaseExpired {
+		t.Fatalf("expected lease_expired event, got %s", last.Kind)
+	}
+}
+
+func TestStoreClaimWithinCrewLimit(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "workorders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	first, _ := store.Create(ctx, CreateInput{Title: "One", Description: "Work", Status: StatusReady, Crew: "builder"})
+	second, _ := store.Create(ctx, CreateInput{Title: "Two", Description: "Work", Status: StatusReady, Crew: "builder"})
+
+	if _, err := store.ClaimWithin(ctx, first.ID, "alice", time.Minute, 1); err != nil {
+		t.Fatalf("claim within limit: %v", err)
+	}
+	if _, err := store.ClaimWithin(ctx, second.ID, "bob", time.Minute, 1); !errors.Is(err, ErrCrewAtCapacity) {
+		t.Fatalf("expected ErrCrewAtCapacity, got %v", err)
+	}
+	if _, err := store.ClaimWithin(ctx, first.ID, "alice", time.Minute, 1); err != nil {
+		t.Fatalf("re-claim of an order already in progress: %v", err)
+	}
+	if _, err := store.ClaimWithin(ctx, second.ID, "bob", time.Minute, 2); err != nil {
+		t.Fatalf("claim under a higher limit: %v", err)
+	}
+}
+
+func TestStoreMoveFollowsClaims(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "workorders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	order, err := store.Create(ctx, CreateInput{Title: "Move me", Description: "Work", Status: StatusBlocked})
+	if err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	moved, err := store.Move(ctx, order.ID, StatusInProgress, "alice", false)
+	if err != nil {
+		t.Fatalf("move to in_progress: %v", err)
+	}
+	if moved.Status != StatusInProgress || moved.Assignee != "alice" || moved.LeaseUntil == nil {
+		t.Fatalf("expected a claim by alice, got %s/%q", moved.Status, moved.Assignee)
+	}
+
+	if _, err := store.Move(ctx, order.ID, StatusDone, "bob", false); !errors.Is(err, ErrClaimed) {
+		t.Fatalf("expected ErrClaimed for bob, got %v", err)
+	}
+	released, err := store.Move(ctx, order.ID, StatusReady, "bob", true)
+	if err != nil {
+		t.Fatalf("forced move to ready: %v", err)
+	}
+	if released.Status != StatusReady || released.Assignee != "" {
+		t.Fatalf("expected a released order, got %s/%q", released.Status, released.Assignee)
+	}
+
+	events, err := store.ListEvents(ctx, order.ID)
+	if err != nil {
+		t.Fatalf("events: %v", err)
+	}
+	if last := events[len(events)-1]; last.Kind != EventReleased || last.Actor != "bob" {
+		t.Fatalf("expected a release by bob, got %+v", last)
+	}
+}
@@ -0,0 +1,144 @@
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+)
+
+type EventKind string
+
+const (
+	EventStatusChanged EventKind = "status_changed"
+	EventClaimed       EventKind = "claimed"
+	EventReleased      EventKind = "released"
+	EventLeaseExpired  EventKind = "lease_expired"
+	EventRunFailed     EventKind = "run_failed"
+	EventNote          EventKind = "note"
+)
+
+type Event struct {
+	ID          int64     `json:"id"`
+	WorkOrderID int64     `json:"work_order_id"`
+	Kind        EventKind `json:"kind"`
+	Actor       string    `json:"actor,omitempty"`
+	Detail      string    `json:"detail,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type EventInput struct {
+	WorkOrderID int64
+	Kind        EventKind
+	Actor       string
+	Detail      string
+}
+
+func (s *Store) AddEvent(ctx context.Context, input EventInput) (Event, error) {
+	if input.WorkOrderID == 0 {
+		return Event{}, fmt.Errorf("work order id is required")
+	}
+	if input.Kind == "" {
+		return Event{}, fmt.Errorf("event kind is required")
+	}
+
+	event := Event{
+		WorkOrderID: input.WorkOrderID,
+		Kind:        input.Kind,
+		Actor:       input.Actor,
+		Detail:      input.Detail,
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	stmt := events.INSERT(
+		eventWorkOrderID,
+		eventKind,
+		eventActor,
+		eventDetail,
+		eventCreatedAt,
+	).VALUES(
+		event.WorkOrderID,
+		string(event.Kind),
+		nullString(event.Actor),
+		nullString(event.Detail),
+		formatTime(event.CreatedAt),
+	)
+
+	result, err := stmt.ExecContext(ctx, s.db)
+	if err != nil {
+		return Event{}, fmt.Errorf("insert event: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return Event{}, fmt.Errorf("read event id: %w", err)
+	}
+	event.ID = id
+
+	return event, nil
+}
+
+func (s *Store) ListEvents(ctx context.Context, workOrderID int64) ([]Event, error) {
+	stmt := events.SELECT(
+		eventID,
+		eventWorkOrderID,
+		eventKind,
+		eventActor,
+		eventDetail,
+		eventCreatedAt,
+	).WHERE(
+		eventWorkOrderID.EQ(sqlite.Int64(workOrderID)),
+	).ORDER_BY(eventID.ASC())
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Event
+	for rows.Next() {
+		event, err := scanEvent(rows.Rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate events: %w", err)
+	}
+
+	return result, nil
+}
+
+func scanEvent(rows *sql.Rows) (Event, error) {
+	var event Event
+	var kind string
+	var actor sql.NullString
+	var detail sql.NullString
+	var createdAt string
+
+	if err := rows.Scan(
+		&event.ID,
+		&event.WorkOrderID,
+		&kind,
+		&actor,
+		&detail,
+		&createdAt,
+	); err != nil {
+		return Event{}, fmt.Errorf("scan event: %w", err)
+	}
+
+	event.Kind = EventKind(kind)
+	event.Actor = actor.String
+	event.Detail = detail.String
+
+	var err error
+	event.CreatedAt, err = parseTime(createdAt)
+	if err != nil {
+		return Event{}, fmt.Errorf("parse created_at: %w", err)
+	}
+
+	return event, nil
+}
@@ -50,15 +50,24 @@ func (s Status) IsValid() bool {
 }
 
 type WorkOrder struct {
-	ID          int64
-	Title       string
-	Description string
-	BeadID      string
-	Status      Status
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	StartedAt   *time.Time
-	CompletedAt *time.Time
+	ID          int64      `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	BeadID      string     `json:"bead_id,omitempty"`
+	Status      Status     `json:"status"`
+	Crew        string     `json:"crew,omitempty"`
+	Assignee    string     `json:"assignee,omitempty"`
+	LeaseUntil  *time.Time `json:"lease_until,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// LeaseExpired reports whether the order is claimed but its lease ran out
+// before now.
+func (w WorkOrder) LeaseExpired(now time.Time) bool {
+	return w.Assignee != "" && w.LeaseUntil != nil && !w.LeaseUntil.After(now)
 }
 
 func CanTransition(from Status, to Status) bool {
@@ -71,7 +80,7 @@ func CanTransition(from Status, to Status) bool {
 	case StatusReady:
 		return to == StatusInProgress || to == StatusBlocked || to == StatusCanceled
 	case StatusInProgress:
-		return to == StatusBlocked || to == StatusDone || to == StatusCanceled
+		return to == StatusReady || to == StatusBlocked || to == StatusDone || to == StatusCanceled
 	case StatusBlocked:
 		return to == StatusReady || to == StatusInProgress || to == StatusCanceled
 	case StatusDone, StatusCanceled:
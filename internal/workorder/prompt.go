@@ -1,15 +1,16 @@
 package workorder
 
-import (
-	"bytes"
-	"fmt"
-	"text/template"
+import "github.com/rikurb8/carnie/internal/templates"
 
-	"github.com/rikurb8/carnie/internal/templates"
-)
+func init() {
+	templates.RegisterData("workorder.md.tmpl", PromptData{})
+	templates.RegisterData("workorder-resume.md.tmpl", ResumePromptData{})
+}
 
 type PromptData struct {
 	RolePrompt         string
+	CrewName           string
+	CrewPrompt         string
 	WorkOrder          WorkOrder
 	BeadTitle          string
 	BeadDescription    string
@@ -17,20 +18,17 @@ type PromptData struct {
 	ProjectDescription string
 }
 
-func RenderPrompt(data PromptData) (string, error) {
-	tmplContent, err := templates.Load("workorder.md.tmpl")
-	if err != nil {
-		return "", fmt.Errorf("load workorder template: %w", err)
-	}
+// RenderPrompt renders workorder.md.tmpl with engine.
+func RenderPrompt(engine templates.Engine, data PromptData) (string, error) {
+	return engine.Render("workorder.md.tmpl", data)
+}
 
-	tmpl, err := template.New("workorder").Parse(tmplContent)
-	if err != nil {
-		return "", fmt.Errorf("parse workorder template: %w", err)
-	}
+type ResumePromptData struct {
+	WorkOrder WorkOrder
+	Message   string
+}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
-		return "", fmt.Errorf("execute workorder template: %w", err)
-	}
-	return buf.String(), nil
+// RenderResumePrompt renders workorder-resume.md.tmpl with engine.
+func RenderResumePrompt(engine templates.Engine, data ResumePromptData) (string, error) {
+	return engine.Render("workorder-resume.md.tmpl", data)
 }
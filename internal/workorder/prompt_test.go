@@ -3,6 +3,8 @@ package workorder
 import (
 	"strings"
 	"testing"
+
+	"github.com/rikurb8/carnie/internal/templates"
 )
 
 func TestRenderPrompt(t *testing.T) {
@@ -14,7 +16,7 @@ func TestRenderPrompt(t *testing.T) {
 		BeadID:      "cn-ta1.1",
 	}
 
-	prompt, err := RenderPrompt(PromptData{
+	prompt, err := RenderPrompt(templates.Engine{}, PromptData{
 		RolePrompt:      "Role content",
 		WorkOrder:       order,
 		BeadTitle:       "Bead title",
@@ -33,3 +35,40 @@ func TestRenderPrompt(t *testing.T) {
 		t.Fatal("expected prompt to include role content")
 	}
 }
+
+func TestRenderPromptIncludesCrewPersona(t *testing.T) {
+	prompt, err := RenderPrompt(templates.Engine{}, PromptData{
+		RolePrompt: "Role content",
+		CrewName:   "reviewer",
+		CrewPrompt: "You review code carefully.",
+		WorkOrder:  WorkOrder{ID: 3, Title: "Review", Description: "Review it", Status: StatusReady},
+	})
+	if err != nil {
+		t.Fatalf("render prompt: %v", err)
+	}
+	if !strings.Contains(prompt, "Persona: reviewer") {
+		t.Fatal("expected prompt to include the crew persona heading")
+	}
+	if !strings.Contains(prompt, "You review code carefully.") {
+		t.Fatal("expected prompt to include the crew role prompt")
+	}
+}
+
+func TestRenderResumePrompt(t *testing.T) {
+	prompt, err := RenderResumePrompt(templates.Engine{}, ResumePromptData{
+		WorkOrder: WorkOrder{ID: 7, Title: "Refactor store", BeadID: "cn-1"},
+		Message:   "Focus on the tests first.",
+	})
+	if err != nil {
+		t.Fatalf("render resume prompt: %v", err)
+	}
+	if !strings.Contains(prompt, "interrupted") {
+		t.Fatal("expected resume prompt to mention the interruption")
+	}
+	if !strings.Contains(prompt, "bd show cn-1") {
+		t.Fatal("expected resume prompt to reference the bead")
+	}
+	if !strings.Contains(prompt, "Focus on the tests first.") {
+		t.Fatal("expected resume prompt to include the message")
+	}
+}
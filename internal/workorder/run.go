@@ -0,0 +1,284 @@
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/rikurb8/carnie/internal/session"
+)
+
+type Run struct {
+	ID          int64
+	WorkOrderID int64
+	Tool        string
+	Model       string
+	SessionID   string
+	ResumedFrom *int64
+	ExitCode    *int
+	LogPath     string
+	Usage       session.Usage
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+}
+
+type CreateRunInput struct {
+	WorkOrderID int64
+	Tool        string
+	Model       string
+	SessionID   string
+	ResumedFrom *int64
+	LogPath     string
+}
+
+type FinishRunInput struct {
+	ExitCode  int
+	SessionID string
+	Usage     session.Usage
+}
+
+func (r Run) Finished() bool {
+	return r.FinishedAt != nil
+}
+
+func (s *Store) CreateRun(ctx context.Context, input CreateRunInput) (Run, error) {
+	if input.WorkOrderID == 0 {
+		return Run{}, fmt.Errorf("work order id is required")
+	}
+	if input.Tool == "" {
+		return Run{}, fmt.Errorf("tool is required")
+	}
+
+	run := Run{
+		WorkOrderID: input.WorkOrderID,
+		Tool:        input.Tool,
+		Model:       input.Model,
+		SessionID:   input.SessionID,
+		ResumedFrom: input.ResumedFrom,
+		LogPath:     input.LogPath,
+		StartedAt:   time.Now().UTC(),
+	}
+
+	stmt := runs.INSERT(
+		runWorkOrderID,
+		runTool,
+		runModel,
+		runSessionID,
+		runResumedFrom,
+		runLogPath,
+		runStartedAt,
+	).VALUES(
+		run.WorkOrderID,
+		run.Tool,
+		nullString(run.Model),
+		nullString(run.SessionID),
+		nullableInt64(run.ResumedFrom),
+		nullString(run.LogPath),
+		formatTime(run.StartedAt),
+	)
+
+	result, err := stmt.ExecContext(ctx, s.db)
+	if err != nil {
+		return Run{}, fmt.Errorf("insert run: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return Run{}, fmt.Errorf("read run id: %w", err)
+	}
+	run.ID = id
+
+	return run, nil
+}
+
+func (s *Store) FinishRun(ctx context.Context, id int64, input FinishRunInput) (Run, error) {
+	current, err := s.GetRun(ctx, id)
+	if err != nil {
+		return Run{}, err
+	}
+
+	finished := time.Now().UTC()
+	exitCode := input.ExitCode
+	current.ExitCode = &exitCode
+	current.FinishedAt = &finished
+	if input.SessionID != "" {
+		current.SessionID = input.SessionID
+	}
+	current.Usage = input.Usage
+
+	stmt := runs.UPDATE(
+		runSessionID,
+		runExitCode,
+		runFinishedAt,
+		runInputTokens,
+		runOutputTokens,
+		runCacheReadTokens,
+		runCacheWriteTokens,
+		runCostUSD,
+	).SET(
+		nullString(current.SessionID),
+		exitCode,
+		formatTime(finished),
+		current.Usage.InputTokens,
+		current.Usage.OutputTokens,
+		current.Usage.CacheReadTokens,
+		current.Usage.CacheWriteTokens,
+		current.Usage.CostUSD,
+	).WHERE(runID.EQ(sqlite.Int64(id)))
+
+	if _, err := stmt.ExecContext(ctx, s.db); err != nil {
+		return Run{}, fmt.Errorf("update run: %w", err)
+	}
+
+	return current, nil
+}
+
+func (s *Store) GetRun(ctx context.Context, id int64) (Run, error) {
+	stmt := selectRuns().WHERE(runID.EQ(sqlite.Int64(id)))
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return Run{}, fmt.Errorf("select run: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return Run{}, sql.ErrNoRows
+	}
+
+	return scanRun(rows.Rows)
+}
+
+func (s *Store) ListRuns(ctx context.Context, workOrderID int64) ([]Run, error) {
+	stmt := selectRuns().
+		WHERE(runWorkOrderID.EQ(sqlite.Int64(workOrderID))).
+		ORDER_BY(runID.ASC())
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("list runs: %w", err)
+	}
+	defer rows.Close()
+
+	var result []Run
+	for rows.Next() {
+		run, err := scanRun(rows.Rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate runs: %w", err)
+	}
+
+	return result, nil
+}
+
+func (s *Store) LatestResumableRun(ctx context.Context, workOrderID int64) (Run, error) {
+	stmt := selectRuns().
+		WHERE(
+			runWorkOrderID.EQ(sqlite.Int64(workOrderID)).
+				AND(runSessionID.IS_NOT_NULL()),
+		).
+		ORDER_BY(runID.DESC()).
+		LIMIT(1)
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return Run{}, fmt.Errorf("select latest run: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return Run{}, fmt.Errorf("no run with a recorded session for work order %d", workOrderID)
+	}
+
+	return scanRun(rows.Rows)
+}
+
+func selectRuns() sqlite.SelectStatement {
+	return runs.SELECT(
+		runID,
+		runWorkOrderID,
+		runTool,
+		runModel,
+		runSessionID,
+		runResumedFrom,
+		runExitCode,
+		runLogPath,
+		runStartedAt,
+		runFinishedAt,
+		runInputTokens,
+		runOutputTokens,
+		runCacheReadTokens,
+		runCacheWriteTokens,
+		runCostUSD,
+	)
+}
+
+func scanRun(rows *sql.Rows) (Run, error) {
+	var run Run
+	var model sql.NullString
+	var sessionID sql.NullString
+	var resumedFrom sql.NullInt64
+	var exitCode sql.NullInt64
+	var logPath sql.NullString
+	var startedAt string
+	var finishedAt sql.NullString
+
+	if err := rows.Scan(
+		&run.ID,
+		&run.WorkOrderID,
+		&run.Tool,
+		&model,
+		&sessionID,
+		&resumedFrom,
+		&exitCode,
+		&logPath,
+		&startedAt,
+		&finishedAt,
+		&run.Usage.InputTokens,
+		&run.Usage.OutputTokens,
+		&run.Usage.CacheReadTokens,
+		&run.Usage.CacheWriteTokens,
+		&run.Usage.CostUSD,
+	); err != nil {
+		return Run{}, fmt.Errorf("scan run: %w", err)
+	}
+
+	run.Model = model.String
+	run.SessionID = sessionID.String
+	run.LogPath = logPath.String
+	if resumedFrom.Valid {
+		value := resumedFrom.Int64
+		run.ResumedFrom = &value
+	}
+	if exitCode.Valid {
+		value := int(exitCode.Int64)
+		run.ExitCode = &value
+	}
+
+	var err error
+	run.StartedAt, err = parseTime(startedAt)
+	if err != nil {
+		return Run{}, fmt.Errorf("parse started_at: %w", err)
+	}
+	if finishedAt.Valid {
+		parsed, err := parseTime(finishedAt.String)
+		if err != nil {
+			return Run{}, fmt.Errorf("parse finished_at: %w", err)
+		}
+		run.FinishedAt = &parsed
+	}
+
+	return run, nil
+}
+
+func nullableInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
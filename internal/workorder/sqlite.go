@@ -13,6 +13,8 @@ import (
 
 const (
 	workOrdersTable = "work_orders"
+	runsTable       = "work_order_runs"
+	eventsTable     = "work_order_events"
 )
 
 var (
@@ -25,6 +27,9 @@ var (
 	woUpdatedAt   = sqlite.StringColumn("updated_at")
 	woStartedAt   = sqlite.StringColumn("started_at")
 	woCompletedAt = sqlite.StringColumn("completed_at")
+	woCrew        = sqlite.StringColumn("crew")
+	woAssignee    = sqlite.StringColumn("assignee")
+	woLeaseUntil  = sqlite.StringColumn("lease_expires_at")
 
 	workOrders = sqlite.NewTable("", workOrdersTable, "",
 		woID,
@@ -36,16 +41,87 @@ var (
 		woUpdatedAt,
 		woStartedAt,
 		woCompletedAt,
+		woCrew,
+		woAssignee,
+		woLeaseUntil,
+	)
+
+	runID               = sqlite.IntegerColumn("id")
+	runWorkOrderID      = sqlite.IntegerColumn("work_order_id")
+	runTool             = sqlite.StringColumn("tool")
+	runModel            = sqlite.StringColumn("model")
+	runSessionID        = sqlite.StringColumn("session_id")
+	runResumedFrom      = sqlite.IntegerColumn("resumed_from")
+	runExitCode         = sqlite.IntegerColumn("exit_code")
+	runLogPath          = sqlite.StringColumn("log_path")
+	runStartedAt        = sqlite.StringColumn("started_at")
+	runFinishedAt       = sqlite.StringColumn("finished_at")
+	runInputTokens      = sqlite.IntegerColumn("input_tokens")
+	runOutputTokens     = sqlite.IntegerColumn("output_tokens")
+	runCacheReadTokens  = sqlite.IntegerColumn("cache_read_tokens")
+	runCacheWriteTokens = sqlite.IntegerColumn("cache_write_tokens")
+	runCostUSD          = sqlite.FloatColumn("cost_usd")
+
+	runs = sqlite.NewTable("", runsTable, "",
+		runID,
+		runWorkOrderID,
+		runTool,
+		runModel,
+		runSessionID,
+		runResumedFrom,
+		runExitCode,
+		runLogPath,
+		runStartedAt,
+		runFinishedAt,
+		runInputTokens,
+		runOutputTokens,
+		runCacheReadTokens,
+		runCacheWriteTokens,
+		runCostUSD,
+	)
+
+	eventID          = sqlite.IntegerColumn("id")
+	eventWorkOrderID = sqlite.IntegerColumn("work_order_id")
+	eventKind        = sqlite.StringColumn("kind")
+	eventActor       = sqlite.StringColumn("actor")
+	eventDetail      = sqlite.StringColumn("detail")
+	eventCreatedAt   = sqlite.StringColumn("created_at")
+
+	events = sqlite.NewTable("", eventsTable, "",
+		eventID,
+		eventWorkOrderID,
+		eventKind,
+		eventActor,
+		eventDetail,
+		eventCreatedAt,
 	)
 )
 
+// columnMigrations adds columns introduced after a table was first created.
+var columnMigrations = []struct {
+	table      string
+	column     string
+	definition string
+}{
+	{workOrdersTable, "crew", "TEXT"},
+	{workOrdersTable, "assignee", "TEXT"},
+	{workOrdersTable, "lease_expires_at", "TEXT"},
+	{runsTable, "input_tokens", "INTEGER NOT NULL DEFAULT 0"},
+	{runsTable, "output_tokens", "INTEGER NOT NULL DEFAULT 0"},
+	{runsTable, "cache_read_tokens", "INTEGER NOT NULL DEFAULT 0"},
+	{runsTable, "cache_write_tokens", "INTEGER NOT NULL DEFAULT 0"},
+	{runsTable, "cost_usd", "REAL NOT NULL DEFAULT 0"},
+}
+
 func openSQLite(path string) (*sql.DB, error) {
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		return nil, fmt.Errorf("create workorder directory: %w", err)
 	}
 
-	db, err := sql.Open("sqlite", path)
+	// Daemon runs and CLI commands share the database, so wait on locks
+	// instead of failing with SQLITE_BUSY.
+	db, err := sql.Open("sqlite", path+"?_pragma=busy_timeout(5000)")
 	if err != nil {
 		return nil, fmt.Errorf("open sqlite: %w", err)
 	}
@@ -71,15 +147,82 @@ CREATE TABLE IF NOT EXISTS work_orders (
 );
 CREATE INDEX IF NOT EXISTS work_orders_status_idx ON work_orders(status);
 CREATE INDEX IF NOT EXISTS work_orders_bead_idx ON work_orders(bead_id);
+
+CREATE TABLE IF NOT EXISTS work_order_runs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    work_order_id INTEGER NOT NULL REFERENCES work_orders(id),
+    tool TEXT NOT NULL,
+    model TEXT,
+    session_id TEXT,
+    resumed_from INTEGER REFERENCES work_order_runs(id),
+    exit_code INTEGER,
+    log_path TEXT,
+    started_at TEXT NOT NULL,
+    finished_at TEXT
+);
+CREATE INDEX IF NOT EXISTS work_order_runs_order_idx ON work_order_runs(work_order_id);
+
+CREATE TABLE IF NOT EXISTS work_order_events (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    work_order_id INTEGER NOT NULL REFERENCES work_orders(id),
+    kind TEXT NOT NULL,
+    actor TEXT,
+    detail TEXT,
+    created_at TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS work_order_events_order_idx ON work_order_events(work_order_id);
 `
 	if _, err := s.db.Exec(schema); err != nil {
 		return fmt.Errorf("ensure work order schema: %w", err)
 	}
+
+	for _, migration := range columnMigrations {
+		if err := s.ensureColumn(migration.table, migration.column, migration.definition); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+func (s *Store) ensureColumn(table string, column string, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("inspect %s columns: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var cid int
+		var name string
+		var columnType string
+		var notNull int
+		var defaultValue sql.NullString
+		var primaryKey int
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultValue, &primaryKey); err != nil {
+			return fmt.Errorf("scan %s columns: %w", table, err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterate %s columns: %w", table, err)
+	}
+
+	statement := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)
+	if _, err := s.db.Exec(statement); err != nil {
+		return fmt.Errorf("add %s.%s column: %w", table, column, err)
+	}
+	return nil
+}
+
+// timeLayout keeps every fractional digit so stored timestamps sort as text
+// in time order; RFC3339Nano drops trailing zeros and would put "…:00Z"
+// after "…:00.5Z". parseTime still reads rows written with RFC3339Nano.
+const timeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
 func formatTime(value time.Time) string {
-	return value.UTC().Format(time.RFC3339Nano)
+	return value.UTC().Format(timeLayout)
 }
 
 func parseTime(value string) (time.Time, error) {
@@ -18,12 +18,15 @@ type CreateInput struct {
 	Description string
 	BeadID      string
 	Status      Status
+	Crew        string
 }
 
 type ListOptions struct {
-	Status *Status
-	BeadID string
-	Limit  int
+	Status   *Status
+	BeadID   string
+	Crew     string
+	Assignee string
+	Limit    int
 }
 
 func OpenStore(path string) (*Store, error) {
@@ -63,6 +66,7 @@ func (s *Store) Create(ctx context.Context, input CreateInput) (WorkOrder, error
 		Description: input.Description,
 		BeadID:      input.BeadID,
 		Status:      input.Status,
+		Crew:        input.Crew,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -83,6 +87,7 @@ func (s *Store) Create(ctx context.Context, input CreateInput) (WorkOrder, error
 		woUpdatedAt,
 		woStartedAt,
 		woCompletedAt,
+		woCrew,
 	).VALUES(
 		order.Title,
 		order.Description,
@@ -92,6 +97,7 @@ func (s *Store) Create(ctx context.Context, input CreateInput) (WorkOrder, error
 		formatTime(order.UpdatedAt),
 		nullableTime(order.StartedAt),
 		nullableTime(order.CompletedAt),
+		nullString(order.Crew),
 	)
 
 	result, err := stmt.ExecContext(ctx, s.db)
@@ -117,6 +123,9 @@ func (s *Store) Get(ctx context.Context, id int64) (WorkOrder, error) {
 		woUpdatedAt,
 		woStartedAt,
 		woCompletedAt,
+		woCrew,
+		woAssignee,
+		woLeaseUntil,
 	).WHERE(woID.EQ(sqlite.Int64(id)))
 
 	rows, err := stmt.Rows(ctx, s.db)
@@ -145,15 +154,24 @@ func (s *Store) List(ctx context.Context, opts ListOptions) ([]WorkOrder, error)
 		woUpdatedAt,
 		woStartedAt,
 		woCompletedAt,
+		woCrew,
+		woAssignee,
+		woLeaseUntil,
 	)
 
-	conditions := make([]sqlite.BoolExpression, 0, 2)
+	conditions := make([]sqlite.BoolExpression, 0, 4)
 	if opts.Status != nil {
 		conditions = append(conditions, woStatus.EQ(sqlite.String(string(*opts.Status))))
 	}
 	if opts.BeadID != "" {
 		conditions = append(conditions, woBeadID.EQ(sqlite.String(opts.BeadID)))
 	}
+	if opts.Crew != "" {
+		conditions = append(conditions, woCrew.EQ(sqlite.String(opts.Crew)))
+	}
+	if opts.Assignee != "" {
+		conditions = append(conditions, woAssignee.EQ(sqlite.String(opts.Assignee)))
+	}
 	if len(conditions) > 0 {
 		var combined sqlite.BoolExpression
 		for _, condition := range conditions {
@@ -191,6 +209,12 @@ func (s *Store) List(ctx context.Context, opts ListOptions) ([]WorkOrder, error)
 }
 
 func (s *Store) UpdateStatus(ctx context.Context, id int64, next Status) (WorkOrder, error) {
+	return s.UpdateStatusBy(ctx, id, next, "")
+}
+
+// UpdateStatusBy moves a work order to next like UpdateStatus, recording
+// actor on the status_changed event.
+func (s *Store) UpdateStatusBy(ctx context.Context, id int64, next Status, actor string) (WorkOrder, error) {
 	current, err := s.Get(ctx, id)
 	if err != nil {
 		return WorkOrder{}, err
@@ -201,24 +225,123 @@ func (s *Store) UpdateStatus(ctx context.Context, id int64, next Status) (WorkOr
 		return WorkOrder{}, err
 	}
 
+	// A lease only protects active work; the assignee stays as a record.
+	if updated.Status != StatusInProgress {
+		updated.LeaseUntil = nil
+	}
+
 	stmt := workOrders.UPDATE(
 		woStatus,
 		woUpdatedAt,
 		woStartedAt,
 		woCompletedAt,
+		woLeaseUntil,
 	).SET(
 		string(updated.Status),
 		formatTime(updated.UpdatedAt),
 		nullableTime(updated.StartedAt),
 		nullableTime(updated.CompletedAt),
+		nullableTime(updated.LeaseUntil),
 	).WHERE(woID.EQ(sqlite.Int64(id)))
 
 	if _, err := stmt.ExecContext(ctx, s.db); err != nil {
 		return WorkOrder{}, fmt.Errorf("update work order: %w", err)
 	}
+
+	if _, err := s.AddEvent(ctx, EventInput{
+		WorkOrderID: id,
+		Kind:        EventStatusChanged,
+		Actor:       actor,
+		Detail:      fmt.Sprintf("%s -> %s", current.Status, updated.Status),
+	}); err != nil {
+		return WorkOrder{}, err
+	}
 	return updated, nil
 }
 
+func (s *Store) AssignCrew(ctx context.Context, id int64, crew string) (WorkOrder, error) {
+	current, err := s.Get(ctx, id)
+	if err != nil {
+		return WorkOrder{}, err
+	}
+
+	current.Crew = crew
+	current.UpdatedAt = time.Now().UTC()
+
+	stmt := workOrders.UPDATE(
+		woCrew,
+		woUpdatedAt,
+	).SET(
+		nullString(current.Crew),
+		formatTime(current.UpdatedAt),
+	).WHERE(woID.EQ(sqlite.Int64(id)))
+
+	if _, err := stmt.ExecContext(ctx, s.db); err != nil {
+		return WorkOrder{}, fmt.Errorf("assign work order crew: %w", err)
+	}
+
+	return current, nil
+}
+
+func (s *Store) CrewLoad(ctx context.Context) (map[string]int, error) {
+	stmt := workOrders.SELECT(
+		woCrew,
+		sqlite.COUNT(woID),
+	).WHERE(
+		woStatus.EQ(sqlite.String(string(StatusInProgress))).
+			AND(woCrew.IS_NOT_NULL()),
+	).GROUP_BY(woCrew)
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("count crew load: %w", err)
+	}
+	defer rows.Close()
+
+	load := make(map[string]int)
+	for rows.Next() {
+		var crew string
+		var count int
+		if err := rows.Rows.Scan(&crew, &count); err != nil {
+			return nil, fmt.Errorf("scan crew load: %w", err)
+		}
+		load[crew] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate crew load: %w", err)
+	}
+
+	return load, nil
+}
+
+func (s *Store) CountByStatus(ctx context.Context) (map[Status]int, error) {
+	stmt := workOrders.SELECT(
+		woStatus,
+		sqlite.COUNT(woID),
+	).GROUP_BY(woStatus)
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("count work orders: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[Status]int, len(validStatuses))
+	for rows.Next() {
+		var status string
+		var count int
+		if err := rows.Rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("scan work order counts: %w", err)
+		}
+		counts[Status(status)] = count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate work order counts: %w", err)
+	}
+
+	return counts, nil
+}
+
 func scanWorkOrder(rows *sql.Rows) (WorkOrder, error) {
 	var order WorkOrder
 	var status string
@@ -227,6 +350,9 @@ func scanWorkOrder(rows *sql.Rows) (WorkOrder, error) {
 	var beadID sql.NullString
 	var startedAt sql.NullString
 	var completedAt sql.NullString
+	var crew sql.NullString
+	var assignee sql.NullString
+	var leaseUntil sql.NullString
 
 	if err := rows.Scan(
 		&order.ID,
@@ -238,6 +364,9 @@ func scanWorkOrder(rows *sql.Rows) (WorkOrder, error) {
 		&updatedAt,
 		&startedAt,
 		&completedAt,
+		&crew,
+		&assignee,
+		&leaseUntil,
 	); err != nil {
 		return WorkOrder{}, fmt.Errorf("scan work order: %w", err)
 	}
@@ -248,6 +377,8 @@ func scanWorkOrder(rows *sql.Rows) (WorkOrder, error) {
 	}
 	order.Status = parsedStatus
 	order.BeadID = beadID.String
+	order.Crew = crew.String
+	order.Assignee = assignee.String
 
 	order.CreatedAt, err = parseTime(createdAt)
 	if err != nil {
@@ -272,6 +403,13 @@ func scanWorkOrder(rows *sql.Rows) (WorkOrder, error) {
 		}
 		order.CompletedAt = &parsed
 	}
+	if leaseUntil.Valid {
+		parsed, err := parseTime(leaseUntil.String)
+		if err != nil {
+			return WorkOrder{}, fmt.Errorf("parse lease_expires_at: %w", err)
+		}
+		order.LeaseUntil = &parsed
+	}
 	return order, nil
 }
 
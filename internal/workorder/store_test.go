@@ -4,6 +4,9 @@ import (
 	"context"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/rikurb8/carnie/internal/session"
 )
 
 func TestStoreCreateAndUpdate(t *testing.T) {
@@ -83,3 +86,194 @@ func TestStoreListFilters(t *testing.T) {
 		t.Fatalf("expected blocked status, got %s", orders[0].Status)
 	}
 }
+
+func TestStoreCrewAssignmentAndLoad(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(filepath.Join(dir, "workorders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	first, err := store.Create(ctx, CreateInput{
+		Title:       "Reviewer work",
+		Description: "Review the change",
+		Status:      StatusInProgress,
+		Crew:        "reviewer",
+	})
+	if err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+	second, err := store.Create(ctx, CreateInput{
+		Title:       "Unassigned work",
+		Description: "Anything",
+		Status:      StatusInProgress,
+	})
+	if err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	if _, err := store.AssignCrew(ctx, second.ID, "reviewer"); err != nil {
+		t.Fatalf("assign crew: %v", err)
+	}
+
+	orders, err := store.List(ctx, ListOptions{Crew: "reviewer"})
+	if err != nil {
+		t.Fatalf("list work orders: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("expected 2 reviewer work orders, got %d", len(orders))
+	}
+
+	load, err := store.CrewLoad(ctx)
+	if err != nil {
+		t.Fatalf("crew load: %v", err)
+	}
+	if load["reviewer"] != 2 {
+		t.Fatalf("expected reviewer load 2, got %d", load["reviewer"])
+	}
+
+	if _, err := store.AssignCrew(ctx, first.ID, ""); err != nil {
+		t.Fatalf("clear crew: %v", err)
+	}
+	cleared, err := store.Get(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("get work order: %v", err)
+	}
+	if cleared.Crew != "" {
+		t.Fatalf("expected crew to be cleared, got %q", cleared.Crew)
+	}
+}
+
+func TestStoreRunsTrackSessions(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "workorders.db")
+	store, err := OpenStore(dbPath)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	order, err := store.Create(context.Background(), CreateInput{
+		Title:       "Long task",
+		Description: "Takes a while",
+		Status:      StatusReady,
+	})
+	if err != nil {
+		t.Fatalf("create work order: %v", err)
+	}
+
+	if _, err := store.LatestResumableRun(context.Background(), order.ID); err == nil {
+		t.Fatal("expected error when no run has a session")
+	}
+
+	run, err := store.CreateRun(context.Background(), CreateRunInput{WorkOrderID: order.ID, Tool: "claude"})
+	if err != nil {
+		t.Fatalf("create run: %v", err)
+	}
+	if run.Finished() {
+		t.Fatal("expected new run to be unfinished")
+	}
+
+	finished, err := store.FinishRun(context.Background(), run.ID, FinishRunInput{ExitCode: 1, SessionID: "sess-1"})
+	if err != nil {
+		t.Fatalf("finish run: %v", err)
+	}
+	if finished.ExitCode == nil || *finished.ExitCode != 1 {
+		t.Fatalf("expected exit code 1, got %v", finished.ExitCode)
+	}
+
+	latest, err := store.LatestResumableRun(context.Background(), order.ID)
+	if err != nil {
+		t.Fatalf("latest resumable run: %v", err)
+	}
+	if latest.ID != run.ID || latest.SessionID != "sess-1" {
+		t.Fatalf("unexpected latest run: %#v", latest)
+	}
+
+	orderRuns, err := store.ListRuns(context.Background(), order.ID)
+	if err != nil {
+		t.Fatalf("list runs: %v", err)
+	}
+	if len(orderRuns) != 1 {
+		t.Fatalf("expected 1 run, got %d", len(orderRuns))
+	}
+}
+
+func TestStoreUsageReport(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenStore(filepath.Join(dir, "workorders.db"))
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	defer store.Close()
+
+	ctx := context.Background()
+	first, err := store.Create(ctx, CreateInput{Title: "First", Description: "One", BeadID: "cn-1", Status: StatusReady})
+	if err != nil {
+		t.Fatalf("create first work order: %v", err)
+	}
+	second, err := store.Create(ctx, CreateInput{Title: "Second", Description: "Two", BeadID: "cn-2", Status: StatusReady})
+	if err != nil {
+		t.Fatalf("create second work order: %v", err)
+	}
+
+	record := func(orderID int64, model string, usage session.Usage) {
+		run, err := store.CreateRun(ctx, CreateRunInput{WorkOrderID: orderID, Tool: "claude", Model: model})
+		if err != nil {
+			t.Fatalf("create run: %v", err)
+		}
+		if _, err := store.FinishRun(ctx, run.ID, FinishRunInput{Usage: usage}); err != nil {
+			t.Fatalf("finish run: %v", err)
+		}
+	}
+	record(first.ID, "sonnet", session.Usage{InputTokens: 100, OutputTokens: 10, CostUSD: 0.5})
+	record(first.ID, "opus", session.Usage{InputTokens: 50, OutputTokens: 5, CostUSD: 2})
+	record(second.ID, "sonnet", session.Usage{InputTokens: 10, OutputTokens: 1, CostUSD: 0.25})
+
+	totals, err := store.UsageTotals(ctx, UsageFilter{WorkOrderID: first.ID})
+	if err != nil {
+		t.Fatalf("usage totals: %v", err)
+	}
+	if totals.Runs != 2 || totals.Usage.InputTokens != 150 || totals.Usage.CostUSD != 2.5 {
+		t.Fatalf("unexpected work order totals: %+v", totals)
+	}
+
+	byModel, err := store.UsageReport(ctx, UsageByModel, UsageFilter{})
+	if err != nil {
+		t.Fatalf("usage report: %v", err)
+	}
+	if len(byModel) != 2 {
+		t.Fatalf("expected 2 model groups, got %d", len(byModel))
+	}
+	if byModel[0].Key != "opus" || byModel[0].Usage.CostUSD != 2 {
+		t.Fatalf("expected opus to be the most expensive group, got %+v", byModel[0])
+	}
+	if byModel[1].Key != "sonnet" || byModel[1].Runs != 2 || byModel[1].Usage.CostUSD != 0.75 {
+		t.Fatalf("unexpected sonnet group: %+v", byModel[1])
+	}
+
+	future := time.Now().Add(time.Hour)
+	none, err := store.UsageTotals(ctx, UsageFilter{Since: &future})
+	if err != nil {
+		t.Fatalf("usage totals since: %v", err)
+	}
+	if none.Runs != 0 || none.Usage.CostUSD != 0 {
+		t.Fatalf("expected no usage in the future, got %+v", none)
+	}
+}
+
+func TestFormatTimeSortsInTimeOrder(t *testing.T) {
+	second := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	times := []time.Time{second, second.Add(500 * time.Millisecond), second.Add(time.Second)}
+	for i := 1; i < len(times); i++ {
+		if earlier, later := formatTime(times[i-1]), formatTime(times[i]); earlier >= later {
+			t.Fatalf("expected %s to sort before %s", earlier, later)
+		}
+	}
+	parsed, err := parseTime(formatTime(times[1]))
+	if err != nil || !parsed.Equal(times[1]) {
+		t.Fatalf("expected a round trip of %s, got %s (%v)", times[1], parsed, err)
+	}
+}
@@ -0,0 +1,175 @@
+package workorder
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-jet/jet/v2/sqlite"
+	"github.com/rikurb8/carnie/internal/session"
+)
+
+type UsageGroup string
+
+const (
+	UsageByModel     UsageGroup = "model"
+	UsageByBead      UsageGroup = "bead"
+	UsageByStatus    UsageGroup = "status"
+	UsageByWorkOrder UsageGroup = "workorder"
+)
+
+var validUsageGroups = []UsageGroup{
+	UsageByModel,
+	UsageByBead,
+	UsageByStatus,
+	UsageByWorkOrder,
+}
+
+func ParseUsageGroup(value string) (UsageGroup, error) {
+	trimmed := strings.TrimSpace(strings.ToLower(value))
+	for _, group := range validUsageGroups {
+		if string(group) == trimmed {
+			return group, nil
+		}
+	}
+	return UsageGroup(""), fmt.Errorf("invalid usage grouping %q", value)
+}
+
+type UsageFilter struct {
+	Since       *time.Time
+	WorkOrderID int64
+}
+
+type UsageSummary struct {
+	Key   string
+	Runs  int
+	Usage session.Usage
+}
+
+func (s *Store) UsageTotals(ctx context.Context, filter UsageFilter) (UsageSummary, error) {
+	stmt := selectUsage(sqlite.String(""))
+	if condition := usageCondition(filter); condition != nil {
+		stmt = stmt.WHERE(condition)
+	}
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return UsageSummary{}, fmt.Errorf("sum usage: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return UsageSummary{}, nil
+	}
+
+	return scanUsageSummary(rows.Rows)
+}
+
+func (s *Store) UsageReport(ctx context.Context, group UsageGroup, filter UsageFilter) ([]UsageSummary, error) {
+	var key sqlite.Expression
+	switch group {
+	case UsageByModel:
+		key = runModel
+	case UsageByBead:
+		key = woBeadID
+	case UsageByStatus:
+		key = woStatus
+	case UsageByWorkOrder:
+		key = woID
+	default:
+		return nil, fmt.Errorf("invalid usage grouping %q", group)
+	}
+
+	stmt := selectUsage(key)
+	if condition := usageCondition(filter); condition != nil {
+		stmt = stmt.WHERE(condition)
+	}
+	stmt = stmt.GROUP_BY(key).ORDER_BY(sqlite.SUMf(runCostUSD).DESC())
+
+	rows, err := stmt.Rows(ctx, s.db)
+	if err != nil {
+		return nil, fmt.Errorf("group usage: %w", err)
+	}
+	defer rows.Close()
+
+	var summaries []UsageSummary
+	for rows.Next() {
+		summary, err := scanUsageSummary(rows.Rows)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate usage: %w", err)
+	}
+
+	return summaries, nil
+}
+
+func selectUsage(key sqlite.Projection) sqlite.SelectStatement {
+	return runs.INNER_JOIN(workOrders, runWorkOrderID.EQ(woID)).SELECT(
+		key,
+		sqlite.COUNT(runID),
+		sqlite.SUMi(runInputTokens),
+		sqlite.SUMi(runOutputTokens),
+		sqlite.SUMi(runCacheReadTokens),
+		sqlite.SUMi(runCacheWriteTokens),
+		sqlite.SUMf(runCostUSD),
+	)
+}
+
+func usageCondition(filter UsageFilter) sqlite.BoolExpression {
+	var condition sqlite.BoolExpression
+	add := func(next sqlite.BoolExpression) {
+		if condition == nil {
+			condition = next
+			return
+		}
+		condition = condition.AND(next)
+	}
+
+	if filter.Since != nil {
+		add(runStartedAt.GT_EQ(sqlite.String(formatTime(*filter.Since))))
+	}
+	if filter.WorkOrderID != 0 {
+		add(runWorkOrderID.EQ(sqlite.Int64(filter.WorkOrderID)))
+	}
+
+	return condition
+}
+
+func scanUsageSummary(rows *sql.Rows) (UsageSummary, error) {
+	var summary UsageSummary
+	var key sql.NullString
+	var inputTokens sql.NullInt64
+	var outputTokens sql.NullInt64
+	var cacheReadTokens sql.NullInt64
+	var cacheWriteTokens sql.NullInt64
+	var cost sql.NullFloat64
+
+	if err := rows.Scan(
+		&key,
+		&summary.Runs,
+		&inputTokens,
+		&outputTokens,
+		&cacheReadTokens,
+		&cacheWriteTokens,
+		&cost,
+	); err != nil {
+		return UsageSummary{}, fmt.Errorf("scan usage: %w", err)
+	}
+
+	summary.Key = key.String
+	summary.Usage = session.Usage{
+		InputTokens:      inputTokens.Int64,
+		OutputTokens:     outputTokens.Int64,
+		CacheReadTokens:  cacheReadTokens.Int64,
+		CacheWriteTokens: cacheWriteTokens.Int64,
+		CostUSD:          cost.Float64,
+	}
+
+	return summary, nil
+}